@@ -0,0 +1,203 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package apply
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/delete"
+	"github.com/datasance/potctl/internal/deploy"
+	"github.com/datasance/potctl/internal/execute"
+	"github.com/datasance/potctl/internal/kustomize"
+	rsc "github.com/datasance/potctl/internal/resource"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+type Options struct {
+	deploy.Options
+	// Name of the record of deployed resources, defaults to the name and a hash of the path of the input file
+	ApplySet string
+	Prune    bool
+	// Skip the confirmation prompt before pruning
+	Yes bool
+}
+
+// Number of hex digits of the path hash in default apply set names
+const applySetHashLength = 8
+
+// Kinds never pruned, deleting them tears down the Namespace
+var unprunableKinds = map[config.Kind]bool{
+	config.KubernetesControlPlaneKind: true,
+	config.RemoteControlPlaneKind:     true,
+	config.LocalControlPlaneKind:      true,
+	config.RemoteControllerKind:       true,
+	config.LocalControllerKind:        true,
+}
+
+// Execute deploys the input file and records the resources it contains in the apply set of the Namespace.
+// With Prune, the resources recorded by previous applies that are no longer in the file are deleted.
+func Execute(opt *Options) error {
+	if opt.ApplySet == "" {
+		if len(opt.InputFiles) != 1 || opt.InputFiles[0] == execute.StdinInput || strings.ContainsAny(opt.InputFiles[0], "*?[") {
+			return util.NewInputError("Must specify --apply-set when applying stdin, glob patterns or several inputs")
+		}
+		opt.ApplySet = DefaultApplySet(opt.InputFiles[0])
+	}
+	ns, err := config.GetNamespace(opt.Namespace)
+	if err != nil {
+		return err
+	}
+	headers, err := execute.GetHeadersFromYAML(opt.InputFiles, opt.Recursive)
+	if err != nil {
+		return err
+	}
+	resources := trackedResources(headers)
+	previous, _ := ns.GetApplySet(opt.ApplySet)
+	stale := staleResources(previous.Resources, resources)
+
+	// Dry runs print the diff of the documents
+	if err := deploy.Execute(&opt.Options); err != nil {
+		return err
+	}
+	if opt.DryRun {
+		if opt.Prune {
+			printStale(opt.ApplySet, stale, "Would prune")
+		}
+		return nil
+	}
+
+	// Resources stay recorded until they are pruned
+	pruned := false
+	if opt.Prune && len(stale) > 0 {
+		printStale(opt.ApplySet, stale, "Pruning")
+		confirmed := opt.Yes
+		if !confirmed {
+			if confirmed, err = confirm(); err != nil {
+				return err
+			}
+		}
+		if confirmed {
+			if err := delete.ExecuteResources(opt.Namespace, groupByKind(stale)); err != nil {
+				return err
+			}
+			pruned = true
+		} else {
+			util.PrintNotify("Skipped pruning, the resources stay recorded in apply set " + opt.ApplySet)
+		}
+	}
+	if !pruned {
+		resources = append(resources, stale...)
+	}
+	ns.SetApplySet(rsc.ApplySet{Name: opt.ApplySet, Resources: resources})
+	return config.Flush()
+}
+
+// DefaultApplySet returns the name of the input file without its extension, or the name of the directory of
+// a kustomization, followed by a hash of its absolute path so that same-named inputs of different directories
+// get distinct apply sets
+func DefaultApplySet(inputFile string) string {
+	path := inputFile
+	file, isKustomization := kustomize.Find(inputFile)
+	if isKustomization {
+		path = filepath.Dir(file)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	name := filepath.Base(path)
+	if !isKustomization {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	sum := sha256.Sum256([]byte(filepath.Clean(path)))
+	return fmt.Sprintf("%s-%s", name, hex.EncodeToString(sum[:])[:applySetHashLength])
+}
+
+// trackedResources returns the prunable resources of the documents, without duplicates
+func trackedResources(headers []config.Header) (resources []rsc.ApplySetResource) {
+	seen := make(map[rsc.ApplySetResource]bool)
+	for idx := range headers {
+		header := &headers[idx]
+		if unprunableKinds[header.Kind] || !delete.CanDelete(header.Kind) {
+			continue
+		}
+		resource := rsc.ApplySetResource{Kind: string(header.Kind), Name: deploy.ResourceName(header)}
+		if !seen[resource] {
+			seen[resource] = true
+			resources = append(resources, resource)
+		}
+	}
+	return
+}
+
+// staleResources returns the recorded resources that are not in the current documents
+func staleResources(recorded, current []rsc.ApplySetResource) (stale []rsc.ApplySetResource) {
+	found := make(map[rsc.ApplySetResource]bool)
+	for _, resource := range current {
+		found[resource] = true
+	}
+	for _, resource := range recorded {
+		if !found[resource] {
+			stale = append(stale, resource)
+		}
+	}
+	return
+}
+
+func groupByKind(resources []rsc.ApplySetResource) map[config.Kind][]string {
+	names := make(map[config.Kind][]string)
+	for _, resource := range resources {
+		kind := config.Kind(resource.Kind)
+		names[kind] = append(names[kind], resource.Name)
+	}
+	return names
+}
+
+func printStale(applySet string, stale []rsc.ApplySetResource, action string) {
+	if len(stale) == 0 {
+		util.PrintInfo(fmt.Sprintf("No resources to prune from apply set %s", applySet))
+		return
+	}
+	util.PrintInfo(fmt.Sprintf("%s %d resources removed from apply set %s:", action, len(stale), applySet))
+	for _, resource := range stale {
+		fmt.Printf("  %s %s\n", resource.Kind, resource.Name)
+	}
+}
+
+func confirm() (bool, error) {
+	util.SpinHandlePrompt()
+	defer util.SpinHandlePromptComplete()
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Print("Delete these resources? (y/n): ")
+		resp, err := reader.ReadString('\n')
+		if err != nil {
+			return false, util.NewInputError("Could not read confirmation, use --yes to prune without prompting")
+		}
+		switch strings.ToLower(strings.TrimSpace(resp)) {
+		case "y":
+			return true, nil
+		case "n":
+			return false, nil
+		default:
+			fmt.Println("Please enter 'y' or 'n'.")
+		}
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package apply
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/datasance/potctl/internal/kustomize"
+)
+
+func TestDefaultApplySet(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"site-a/ecn.yaml", "site-b/ecn.yaml", "site-a/overlay/" + kustomize.Filename, "site-b/overlay/" + kustomize.Filename} {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte{}, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+
+	siteA, siteB := DefaultApplySet("site-a/ecn.yaml"), DefaultApplySet("site-b/ecn.yaml")
+	if siteA == siteB {
+		t.Errorf("Expected same-named files of different directories to get distinct apply sets, got %s", siteA)
+	}
+	for _, name := range []string{siteA, siteB} {
+		if !strings.HasPrefix(name, "ecn-") {
+			t.Errorf("Expected apply set %s to be named after the input file", name)
+		}
+	}
+
+	// The same file reached through different paths shares its apply set
+	for _, path := range []string{filepath.Join(dir, "site-a", "ecn.yaml"), "./site-b/../site-a/ecn.yaml"} {
+		if name := DefaultApplySet(path); name != siteA {
+			t.Errorf("Expected apply set %s for %s, got %s", siteA, path, name)
+		}
+	}
+
+	// Kustomizations are named after their directory
+	overlayA, overlayB := DefaultApplySet("site-a/overlay"), DefaultApplySet("site-b/overlay/"+kustomize.Filename)
+	if overlayA == overlayB {
+		t.Errorf("Expected kustomizations of different directories to get distinct apply sets, got %s", overlayA)
+	}
+	if !strings.HasPrefix(overlayA, "overlay-") || !strings.HasPrefix(overlayB, "overlay-") {
+		t.Errorf("Expected apply sets %s and %s to be named after the kustomization directory", overlayA, overlayB)
+	}
+	if name := DefaultApplySet("site-a/overlay/" + kustomize.Filename); name != overlayA {
+		t.Errorf("Expected apply set %s for the kustomization file, got %s", overlayA, name)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/datasance/potctl/internal/apply"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newApplyCommand() *cobra.Command {
+	// Instantiate options
+	opt := &apply.Options{}
+
+	// Instantiate command
+	cmd := &cobra.Command{
+		Use: "apply",
+		Example: `apply -f ecn.yaml
+apply -f ecn.yaml --prune
+apply -f ecn.yaml --prune --dry-run
+apply -f edge/apps.yaml --apply-set edge-apps --prune --yes`,
+		Args:  cobra.ExactArgs(0),
+		Short: "Deploy resources and prune the ones removed from the file",
+		Long: `Deploy resources and prune the ones removed from the file.
+
+Resources are deployed as with potctl deploy, then recorded in an apply set of the Namespace file. The apply set
+is named after the input file, or the directory of a kustomization, followed by a hash of its absolute path, so
+that same-named files of different directories don't share an apply set. --apply-set overrides the name and is
+required when applying stdin, glob patterns or several inputs.
+
+With --prune, the resources recorded by previous applies of the same apply set that are no longer in the file are
+deleted, in the same order as potctl delete, after a confirmation prompt unless --yes is set. Resources stay
+recorded until they are pruned. Control Planes and Controllers are never pruned.
+
+With --dry-run, the diff of the file is printed along with the resources that would be pruned. Nothing is deployed
+or deleted.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			opt.Namespace, err = cmd.Flags().GetString("namespace")
+			util.Check(err)
+
+			// Check file
+			if len(opt.InputFiles) == 0 {
+				util.Check(errors.New("provided empty value for input file via the -f flag"))
+			}
+			err = setTemplate(cmd)
+			util.Check(err)
+
+			// Execute command
+			err = apply.Execute(opt)
+			util.Check(err)
+
+			if !opt.DryRun {
+				util.PrintSuccess("Successfully applied resources")
+			}
+		},
+	}
+
+	// Register flags
+	cmd.Flags().StringArrayVarP(&opt.InputFiles, "file", "f", []string{}, pkg.flagDescInputs)
+	cmd.Flags().BoolVarP(&opt.Recursive, "recursive", "R", false, pkg.flagDescRecurse)
+	cmd.Flags().BoolVar(&opt.NoCache, "no-cache", false, "Disable caching for OfflineImage images after download")
+	cmd.Flags().IntVar(&opt.TransferPool, "transfer-pool", 2, "Maximum number of concurrent OfflineImage transfers")
+	cmd.Flags().BoolVar(&opt.DryRun, "dry-run", false, "Print what would change and be pruned without deploying or deleting anything")
+	cmd.Flags().StringVar(&opt.ApplySet, "apply-set", "", "Name of the record of applied resources, defaults to the name and a hash of the path of the input file")
+	cmd.Flags().BoolVar(&opt.Prune, "prune", false, "Delete the resources of the apply set that are no longer in the file")
+	cmd.Flags().BoolVarP(&opt.Yes, "yes", "y", false, "Prune without prompting for confirmation")
+	addTemplateFlags(cmd)
+
+	return cmd
+}
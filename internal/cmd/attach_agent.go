@@ -43,6 +43,7 @@ The Agent will be provisioned with the Controller within the Namespace.`,
 
 			util.PrintSuccess("Successfully attached Agent " + opt.Name + " to namespace " + opt.Namespace)
 		},
+		ValidArgsFunction: completeArgs("detached-agent"),
 	}
 
 	return cmd
@@ -46,6 +46,7 @@ func newAttachEdgeResourceCommand() *cobra.Command {
 			msg := fmt.Sprintf("Successfully attached EdgeResource %s/%s to Agent %s", opt.Name, opt.Version, opt.Agent)
 			util.PrintSuccess(msg)
 		},
+		ValidArgsFunction: completeArgs("edge-resource", "", "agent"),
 	}
 
 	return cmd
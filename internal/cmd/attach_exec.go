@@ -44,6 +44,7 @@ func NewAttachExecMicroserviceCommand() *cobra.Command {
 			msg := fmt.Sprintf("Successfully attached Exec Session to Microservice %s", opt.Name)
 			util.PrintSuccess(msg)
 		},
+		ValidArgsFunction: completeArgs("microservice"),
 	}
 
 	return cmd
@@ -74,6 +75,7 @@ func newAttachExecAgentCommand() *cobra.Command {
 			msg := fmt.Sprintf("Successfully attached Exec Session to Agent %s", opt.Name)
 			util.PrintSuccess(msg)
 		},
+		ValidArgsFunction: completeArgs("agent"),
 	}
 
 	return cmd
@@ -45,6 +45,7 @@ func newAttachVolumeMountCommand() *cobra.Command {
 			msg := fmt.Sprintf("Successfully attached Volume Mount %s to Agents %s", opt.Name, strings.Join(opt.Agents, ", "))
 			util.PrintSuccess(msg)
 		},
+		ValidArgsFunction: completeVariadicArgs("volume-mount", "agent"),
 	}
 
 	return cmd
@@ -34,11 +34,16 @@ func newBashCompleteCommand(rootCmd *cobra.Command) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:    "autocomplete SHELL",
 		Hidden: true,
-		Short:  "Generate bash autocomplete file",
-		Long:   "Generate bash autocomplete file",
+		Short:  "Generate shell autocomplete file",
+		Long: `Generate shell autocomplete file
+
+Resource names are completed dynamically from the namespace files and the Controller of the namespace.`,
 		Example: `potctl autocomplete bash
-                      zsh`,
-		Args: cobra.ExactValidArgs(1),
+                      zsh
+                      fish
+                      powershell`,
+		ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+		Args:      cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			switch t := strings.ToLower(args[0]); t {
 			case "bash":
@@ -52,12 +57,28 @@ func newBashCompleteCommand(rootCmd *cobra.Command) *cobra.Command {
 				}
 				util.PrintInfo(fmt.Sprintf("Add `source %s` to your bash profile to have it saved", completionFilePath))
 			case "zsh":
-				completionFilePath := configDir + "completion.bash.sh"
+				completionFilePath := configDir + "completion.zsh"
 				err = rootCmd.GenZshCompletionFile(completionFilePath)
 				util.Check(err)
 				util.PrintSuccess(fmt.Sprintf("%s generated", completionFilePath))
+				util.PrintInfo(fmt.Sprintf("Run `source %s` to update your current session", completionFilePath))
+				util.PrintInfo(fmt.Sprintf("Add `source %s` to your .zshrc to have it saved, after `autoload -U compinit; compinit`", completionFilePath))
+			case "fish":
+				completionFilePath := configDir + "completion.fish"
+				err = rootCmd.GenFishCompletionFile(completionFilePath, true)
+				util.Check(err)
+				util.PrintSuccess(fmt.Sprintf("%s generated", completionFilePath))
+				util.PrintInfo(fmt.Sprintf("Run `source %s` to update your current session", completionFilePath))
+				util.PrintInfo(fmt.Sprintf("Copy %s to ~/.config/fish/completions/potctl.fish to have it saved", completionFilePath))
+			case "powershell":
+				completionFilePath := configDir + "completion.ps1"
+				err = rootCmd.GenPowerShellCompletionFileWithDesc(completionFilePath)
+				util.Check(err)
+				util.PrintSuccess(fmt.Sprintf("%s generated", completionFilePath))
+				util.PrintInfo(fmt.Sprintf("Run `. %s` to update your current session", completionFilePath))
+				util.PrintInfo(fmt.Sprintf("Add `. %s` to your PowerShell profile to have it saved", completionFilePath))
 			default:
-				util.Check(util.NewNotFoundError(fmt.Sprintf("%s shell not supported for autocompletion\n Supported shells are BASH and ZSH", t)))
+				util.Check(util.NewNotFoundError(fmt.Sprintf("%s shell not supported for autocompletion\n Supported shells are BASH, ZSH, FISH and POWERSHELL", t)))
 			}
 		},
 	}
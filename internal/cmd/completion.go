@@ -0,0 +1,82 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/datasance/potctl/internal/complete"
+	"github.com/spf13/cobra"
+)
+
+type completionFunc func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)
+
+// completeArgs completes each positional argument with the names of the resources of the kind at the same
+// position, no completion is offered for empty kinds
+func completeArgs(kinds ...string) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= len(kinds) || kinds[len(args)] == "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeNames(cmd, kinds[len(args)], toComplete)
+	}
+}
+
+// completeVariadicArgs completes positional arguments like completeArgs, the last kind being repeated
+// for all the remaining arguments
+func completeVariadicArgs(kinds ...string) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		idx := len(args)
+		if idx >= len(kinds) {
+			idx = len(kinds) - 1
+		}
+		return completeArgs(kinds[idx])(cmd, nil, toComplete)
+	}
+}
+
+// completeResourceArgs completes a resource type followed by the name of a resource of that type.
+// resources maps each resource type to the kind of its names, empty for types that take no name.
+func completeResourceArgs(resources map[string]string) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		switch len(args) {
+		case 0:
+			types := []string{}
+			for resource := range resources {
+				if strings.HasPrefix(resource, toComplete) {
+					types = append(types, resource)
+				}
+			}
+			sort.Strings(types)
+			return types, cobra.ShellCompDirectiveNoFileComp
+		case 1:
+			if kind := resources[args[0]]; kind != "" {
+				return completeNames(cmd, kind, toComplete)
+			}
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func completeNames(cmd *cobra.Command, kind, toComplete string) ([]string, cobra.ShellCompDirective) {
+	namespace, err := cmd.Flags().GetString("namespace")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return complete.Names(kind, namespace, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeNamespaces(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return complete.Names("namespace", "", toComplete), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,39 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "View, validate and migrate the configuration of potctl",
+		Long: `View, validate and migrate the configuration of potctl
+
+The configuration is made of the config file and of a file per namespace, in ~/.iofog/v3. Files written by older
+versions of potctl or iofogctl are upgraded in memory when they are read, and on disk by potctl config migrate.`,
+	}
+
+	cmd.AddCommand(
+		newConfigViewCommand(),
+		newConfigSetDefaultNamespaceCommand(),
+		newConfigGetNamespacesCommand(),
+		newConfigValidateCommand(),
+		newConfigMigrateCommand(),
+	)
+
+	return cmd
+}
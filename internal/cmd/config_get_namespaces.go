@@ -0,0 +1,55 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/util/printer"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newConfigGetNamespacesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-namespaces",
+		Short: "List the namespace files",
+		Long: `List the namespace files
+
+Unlike potctl get namespaces, the files are not loaded, so that those of older versions are listed as they are.
+The revision of a namespace file is incremented each time it is written.`,
+		Example: `potctl config get-namespaces`,
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			files, err := config.GetNamespaceFiles()
+			util.Check(err)
+
+			table := [][]string{{"NAMESPACE", "VERSION", "REVISION", "MODIFIED"}}
+			for _, file := range files {
+				name := file.Name
+				if name == config.GetDefaultNamespaceName() {
+					name += "*"
+				}
+				table = append(table, []string{name, file.APIVersion, strconv.Itoa(file.Revision), util.FormatDuration(time.Since(file.Modified))})
+			}
+			err = printer.PrintTable(os.Stdout, table)
+			util.Check(err)
+		},
+	}
+
+	return cmd
+}
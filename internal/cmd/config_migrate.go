@@ -0,0 +1,62 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newConfigMigrateCommand() *cobra.Command {
+	var legacyDir string
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade the config and namespace files to the current version",
+		Long: `Upgrade the config and namespace files to the current version
+
+Files of older versions are rewritten in place, after being copied to a new directory of ~/.iofog/v3/backups.
+Use --from to also import the namespaces of a legacy config directory, e.g. ~/.iofog/v2 of iofogctl v2.
+Namespaces already present are not imported.`,
+		Example: `potctl config migrate
+potctl config migrate --from ~/.iofog/v2`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := config.Migrate(legacyDir)
+			util.Check(err)
+
+			if result.BackupDir != "" {
+				util.PrintInfo(fmt.Sprintf("Backed up the previous files to %s", result.BackupDir))
+			}
+			if len(result.Skipped) > 0 {
+				util.PrintNotify(fmt.Sprintf("Skipped namespaces already present: %s", strings.Join(result.Skipped, ", ")))
+			}
+			if len(result.Imported) > 0 {
+				util.PrintSuccess(fmt.Sprintf("Imported namespaces: %s", strings.Join(result.Imported, ", ")))
+			}
+			if len(result.Migrated) > 0 {
+				util.PrintSuccess(fmt.Sprintf("Migrated namespaces: %s", strings.Join(result.Migrated, ", ")))
+			}
+			if len(result.Imported) == 0 && len(result.Migrated) == 0 {
+				util.PrintSuccess(fmt.Sprintf("All namespace files are already of version %s", config.CurrentConfigVersion))
+			}
+		},
+	}
+	cmd.Flags().StringVar(&legacyDir, "from", "", "Legacy config directory to import namespaces from")
+
+	return cmd
+}
@@ -0,0 +1,38 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newConfigSetDefaultNamespaceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "set-default-namespace NAME",
+		Short:   "Set the namespace used when --namespace is not specified",
+		Long:    `Set the namespace used when --namespace is not specified`,
+		Example: `potctl config set-default-namespace NAME`,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := config.SetDefaultNamespace(args[0])
+			util.Check(err)
+			util.PrintSuccess("Default namespace set to " + args[0])
+		},
+		ValidArgsFunction: completeArgs("namespace"),
+	}
+
+	return cmd
+}
@@ -0,0 +1,53 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newConfigValidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the config and namespace files",
+		Long: `Validate the config and namespace files
+
+Every namespace file must be of the current version and match the namespace types of this version of potctl,
+without unknown fields.`,
+		Example: `potctl config validate`,
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			files, err := config.ValidateNamespaceFiles()
+			util.Check(err)
+
+			invalid := 0
+			for _, file := range files {
+				if file.Error != nil {
+					invalid++
+					util.PrintError(fmt.Sprintf("Namespace %s: %s", file.Name, file.Error.Error()))
+				}
+			}
+			if invalid > 0 {
+				util.Check(util.NewInputError(fmt.Sprintf("%d of %d namespace files are invalid", invalid, len(files))))
+			}
+			util.PrintSuccess(fmt.Sprintf("Config file and %d namespace files are valid", len(files)))
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,44 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/util/printer"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newConfigViewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "Print the config file",
+		Long: `Print the config file
+
+The status lists the config directory and the namespaces it contains.`,
+		Example: `potctl config view`,
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			header, err := config.GetConfigHeader()
+			util.Check(err)
+			outputOpt := printer.Options{Format: printer.FormatYAML}
+			err = outputOpt.PrintObject(os.Stdout, header)
+			util.Check(err)
+		},
+	}
+
+	return cmd
+}
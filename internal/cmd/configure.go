@@ -30,14 +30,35 @@ func newConfigureCommand() *cobra.Command {
 		Short: "Configure potctl or ioFog resources",
 		Long: `Configure potctl or ioFog resources
 
-If you would like to replace the host value of Remote Controllers or Agents, you should delete and redeploy those resources.`,
+If you would like to replace the host value of Remote Controllers or Agents, you should delete and redeploy those resources.
+
+Encrypted private keys are unlocked with the passphrase in the POTCTL_SSH_PASSPHRASE environment variable, or prompted for when running in a terminal.
+
+The secret-store resource selects where the passwords, tokens and client secrets of namespaces are kept, and moves
+the existing ones there. Namespace files then only hold secret:// references to them:
+  none     in the namespace files (default)
+  vault    in secrets.vault of the config directory, encrypted with AES-256-GCM using a key derived from the
+           passphrase in the POTCTL_VAULT_PASSPHRASE environment variable, or prompted for when running in a terminal
+  keyring  in the keyring of the OS, through security on macOS or secret-tool on Linux
+
+Config and namespace files are only readable by their owner.`,
 		Example: `potctl configure current-namespace NAME
 
+potctl configure secret-store vault
+                              keyring
+                              none
+
 potctl configure controller  NAME --user USER --key KEYFILE --port PORTNUM
                    controllers
                    agent
                    agents
 
+potctl configure agent NAME --accept-host-key
+
+potctl configure agent NAME --use-agent --cert ~/.ssh/id_ed25519-cert.pub
+
+potctl configure agents --proxy-jump admin@bastion:2222,10.0.0.5
+
 potctl configure controlplane --kube FILE`,
 		Args: cobra.RangeArgs(1, 2),
 		Run: func(cmd *cobra.Command, args []string) {
@@ -59,6 +80,16 @@ potctl configure controlplane --kube FILE`,
 			util.Check(err)
 			opt.UseDetached, err = cmd.Flags().GetBool("detached")
 			util.Check(err)
+			if cmd.Flags().Changed("use-agent") {
+				useAgent, err := cmd.Flags().GetBool("use-agent")
+				util.Check(err)
+				opt.UseAgent = &useAgent
+			}
+			if cmd.Flags().Changed("proxy-jump") {
+				proxyJump, err := cmd.Flags().GetString("proxy-jump")
+				util.Check(err)
+				opt.ProxyJump = &proxyJump
+			}
 
 			// Get executor for configure command
 			exe, err := configure.NewExecutor(&opt)
@@ -70,11 +101,16 @@ potctl configure controlplane --kube FILE`,
 
 			util.PrintSuccess(fmt.Sprintf("Succesfully configured %s %s", opt.ResourceType, opt.Name))
 		},
+		ValidArgsFunction: completeResourceArgs(map[string]string{"current-namespace": "namespace", "default-namespace": "namespace", "secret-store": "", "controlplane": "", "controller": "controller", "controllers": "", "agent": "agent", "agents": ""}),
 	}
 	cmd.Flags().StringVar(&opt.User, "user", "", "Username of remote host")
 	cmd.Flags().StringVar(&opt.KeyFile, "key", "", "Path to private SSH key")
+	cmd.Flags().StringVar(&opt.CertFile, "cert", "", "Path to OpenSSH certificate of the private SSH key")
+	cmd.Flags().Bool("use-agent", false, "Authenticate with the keys of the SSH agent on SSH_AUTH_SOCK. Set to false to stop using it")
+	cmd.Flags().String("proxy-jump", "", "Comma separated jump hosts [user@]host[:port] to connect through, in order. Hops use the key of the remote host. Set to \"\" to connect directly")
 	cmd.Flags().StringVar(&opt.KubeConfig, "kube", "", "Path to Kubernetes configuration file")
 	cmd.Flags().IntVar(&opt.Port, "port", 0, "Port number that potctl uses to SSH into remote hosts")
+	cmd.Flags().BoolVar(&opt.AcceptHostKey, "accept-host-key", false, "Trust the SSH host key currently presented by remote hosts, e.g. after they were reinstalled")
 	cmd.Flags().Bool("detached", false, pkg.flagDescDetached)
 
 	return cmd
@@ -0,0 +1,60 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"github.com/datasance/potctl/internal/cp"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newCpCommand() *cobra.Command {
+	opt := cp.Options{}
+
+	cmd := &cobra.Command{
+		Use:   "cp SOURCE DESTINATION",
+		Short: "Copy files and directories to and from Microservices and Agents",
+		Long: `Copy files and directories to and from Microservices and Agents.
+
+Remote paths are specified as AppName/MsvcName:PATH, or AgentName:PATH with --agent. Files are streamed as a
+tar archive through the Exec Session of the resource, so the container must provide tar, exec must be
+enabled for the Microservice or Agent and the Agent must support running commands without a terminal.
+
+When copying to a remote path ending with /, the source is copied into that directory. When copying to an
+existing local directory, the source is copied into it.`,
+		Example: `potctl cp AppName/MsvcName:/var/log/app.log ./app.log
+potctl cp ./config AppName/MsvcName:/etc/app/
+potctl cp --agent AgentName:/var/log/iofog-agent ./agent-logs`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			opt.Source = args[0]
+			opt.Destination = args[1]
+			opt.Namespace, err = cmd.Flags().GetString("namespace")
+			util.Check(err)
+
+			exe, err := cp.NewExecutor(&opt)
+			util.Check(err)
+
+			err = exe.Execute()
+			util.Check(err)
+
+			util.PrintSuccess("Successfully copied " + opt.Source + " to " + opt.Destination)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opt.Agent, "agent", false, "Copy from or to the debug container of an Agent instead of a Microservice")
+
+	return cmd
+}
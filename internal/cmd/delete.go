@@ -36,9 +36,11 @@ func newDeleteCommand() *cobra.Command {
 			util.Check(err)
 
 			// Check file
-			if opt.InputFile == "" {
+			if len(opt.InputFiles) == 0 {
 				util.Check(errors.New("provided empty value for input file via the -f flag"))
 			}
+			err = setTemplate(cmd)
+			util.Check(err)
 
 			// Execute command
 			err = delete.Execute(opt)
@@ -74,7 +76,9 @@ func newDeleteCommand() *cobra.Command {
 	)
 
 	// Register flags
-	cmd.Flags().StringVarP(&opt.InputFile, "file", "f", "", pkg.flagDescYaml)
+	cmd.Flags().StringArrayVarP(&opt.InputFiles, "file", "f", []string{}, pkg.flagDescInputs)
+	cmd.Flags().BoolVarP(&opt.Recursive, "recursive", "R", false, pkg.flagDescRecurse)
+	addTemplateFlags(cmd)
 
 	return cmd
 }
@@ -15,6 +15,8 @@ package cmd
 
 import (
 	delete "github.com/datasance/potctl/internal/delete/agent"
+	"github.com/datasance/potctl/internal/execute"
+	clientutil "github.com/datasance/potctl/internal/util/client"
 	"github.com/datasance/potctl/pkg/util"
 	"github.com/spf13/cobra"
 )
@@ -22,7 +24,7 @@ import (
 func newDeleteAgentCommand() *cobra.Command {
 	var force bool
 	cmd := &cobra.Command{
-		Use:   "agent NAME",
+		Use:   "agent [NAME]",
 		Short: "Delete an Agent",
 		Long: `Delete an Agent.
 
@@ -30,33 +32,44 @@ The Agent will be unprovisioned from the Controller within the namespace.
 
 The Agent stack will be uninstalled from the host.
 
-If you wish to not remove the Agent stack from the host, please use potctl detach agent`,
-		Example: `potctl delete agent NAME`,
-		Args:    cobra.ExactArgs(1),
+If you wish to not remove the Agent stack from the host, please use potctl detach agent
+
+Use --selector instead of a name to delete all the Agents whose tags match.`,
+		Example: `potctl delete agent NAME
+potctl delete agent -l site=plant-3`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			// Get name and namespace of agent
-			name := args[0]
 			namespace, err := cmd.Flags().GetString("namespace")
 			util.Check(err)
 			useDetached, err := cmd.Flags().GetBool("detached")
 			util.Check(err)
+			if useDetached && cmd.Flags().Changed("selector") {
+				util.Check(util.NewInputError("Cannot use --selector with detached Agents"))
+			}
+			names, err := getTargetNames(cmd, args, namespace, clientutil.SelectAgentNames)
+			util.Check(err)
 
 			// Run the command
-			exe, err := delete.NewExecutor(namespace, name, useDetached, force)
-			util.Check(err)
-			err = exe.Execute()
+			err = executeForEach(names, "delete agents", func(name string) (execute.Executor, error) {
+				return delete.NewExecutor(namespace, name, useDetached, force)
+			})
 			util.Check(err)
 
-			printName := name
-			if !useDetached {
-				printName = namespace + "/" + name
+			for _, name := range names {
+				printName := name
+				if !useDetached {
+					printName = namespace + "/" + name
+				}
+				util.PrintSuccess("Successfully deleted " + printName)
 			}
-			util.PrintSuccess("Successfully deleted " + printName)
 		},
+		ValidArgsFunction: completeArgs("agent"),
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Remove even if there are still Microservices running on the Agent")
 	cmd.Flags().Bool("detached", false, pkg.flagDescDetached)
+	addSelectorFlag(cmd)
 
 	return cmd
 }
@@ -38,6 +38,7 @@ func newDeleteApplicationCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully deleted " + namespace + "/" + name)
 		},
+		ValidArgsFunction: completeArgs("application"),
 	}
 
 	return cmd
@@ -40,6 +40,7 @@ func newDeleteCertificateCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully deleted certificate " + name)
 		},
+		ValidArgsFunction: completeArgs("certificate"),
 	}
 
 	return cmd
@@ -40,6 +40,7 @@ func newDeleteConfigMapCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully deleted configmap " + name)
 		},
+		ValidArgsFunction: completeArgs("configmap"),
 	}
 
 	return cmd
@@ -40,6 +40,7 @@ func newDeleteControllerCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully deleted " + namespace + "/" + name)
 		},
+		ValidArgsFunction: completeArgs("controller"),
 	}
 
 	return cmd
@@ -46,6 +46,7 @@ Agents that this Edge Resource are attached to will be notified of the deletion.
 			msg := fmt.Sprintf("Successfully deleted %s/%s", name, version)
 			util.PrintSuccess(msg)
 		},
+		ValidArgsFunction: completeArgs("edge-resource"),
 	}
 
 	return cmd
@@ -15,32 +15,42 @@ package cmd
 
 import (
 	delete "github.com/datasance/potctl/internal/delete/microservice"
+	"github.com/datasance/potctl/internal/execute"
+	clientutil "github.com/datasance/potctl/internal/util/client"
 	"github.com/datasance/potctl/pkg/util"
 	"github.com/spf13/cobra"
 )
 
 func newDeleteMicroserviceCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "microservice NAME",
-		Short:   "Delete a Microservice",
-		Long:    `Delete a Microservice`,
-		Example: `potctl delete microservice NAME`,
-		Args:    cobra.ExactArgs(1),
+		Use:   "microservice [NAME]",
+		Short: "Delete a Microservice",
+		Long: `Delete a Microservice
+
+Use --selector instead of a name to delete all the Microservices running on the Agents whose tags match.`,
+		Example: `potctl delete microservice NAME
+potctl delete microservice -l site=plant-3`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			// Get name and namespace
-			name := args[0]
 			namespace, err := cmd.Flags().GetString("namespace")
 			util.Check(err)
+			names, err := getTargetNames(cmd, args, namespace, clientutil.SelectMicroserviceNames)
+			util.Check(err)
 
 			// Get an executor for the command
-			exe, err := delete.NewExecutor(namespace, name)
-			util.Check(err)
-			err = exe.Execute()
+			err = executeForEach(names, "delete microservices", func(name string) (execute.Executor, error) {
+				return delete.NewExecutor(namespace, name)
+			})
 			util.Check(err)
 
-			util.PrintSuccess("Successfully deleted microservice " + name)
+			for _, name := range names {
+				util.PrintSuccess("Successfully deleted microservice " + name)
+			}
 		},
+		ValidArgsFunction: completeArgs("microservice"),
 	}
+	addSelectorFlag(cmd)
 
 	return cmd
 }
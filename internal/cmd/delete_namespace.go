@@ -41,6 +41,7 @@ If you would like to delete all resources in the Namespace, use the --force flag
 
 			util.PrintSuccess("Successfully deleted Namespace " + name)
 		},
+		ValidArgsFunction: completeArgs("namespace"),
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Force deletion of all resources within the Namespace")
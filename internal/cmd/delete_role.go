@@ -38,6 +38,7 @@ func newDeleteRoleCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully deleted role " + name)
 		},
+		ValidArgsFunction: completeArgs("role"),
 	}
 
 	return cmd
@@ -38,6 +38,7 @@ func newDeleteRoleBindingCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully deleted rolebinding " + name)
 		},
+		ValidArgsFunction: completeArgs("rolebinding"),
 	}
 
 	return cmd
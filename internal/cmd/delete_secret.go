@@ -40,6 +40,7 @@ func newDeleteSecretCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully deleted secret " + name)
 		},
+		ValidArgsFunction: completeArgs("secret"),
 	}
 
 	return cmd
@@ -40,6 +40,7 @@ func newDeleteServiceCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully deleted secret " + name)
 		},
+		ValidArgsFunction: completeArgs("service"),
 	}
 
 	return cmd
@@ -38,6 +38,7 @@ func newDeleteApplicationTemplateCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully deleted " + namespace + "/" + name)
 		},
+		ValidArgsFunction: completeArgs("application-template"),
 	}
 
 	return cmd
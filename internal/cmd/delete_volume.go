@@ -42,6 +42,7 @@ The Volume will be deleted from the Agents that it is stored on.`,
 
 			util.PrintSuccess("Successfully deleted " + namespace + "/" + name)
 		},
+		ValidArgsFunction: completeArgs("volume"),
 	}
 
 	return cmd
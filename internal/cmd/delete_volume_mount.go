@@ -40,6 +40,7 @@ func newDeleteVolumeMountCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully deleted secret " + name)
 		},
+		ValidArgsFunction: completeArgs("volume-mount"),
 	}
 
 	return cmd
@@ -39,34 +39,51 @@ func newDeployCommand() *cobra.Command {
           secret.yaml
           configmap.yaml
           service.yaml
-          volume-mount.yaml`,
+          volume-mount.yaml
+
+deploy -f application.yaml --dry-run
+deploy -f secrets.yaml -f apps/ -R
+deploy -f 'sites/*.yaml'
+deploy -f - < ecn.yaml
+deploy -f https://example.com/ecn.yaml`,
 
 		Args:  cobra.ExactArgs(0),
 		Short: "Deploy Edge Compute Network components on existing infrastructure",
 		Long: `Deploy Edge Compute Network components on existing infrastructure.
-Visit iofog.org to view all YAML specifications usable with this command.`,
+Visit iofog.org to view all YAML specifications usable with this command.
+
+-f can be repeated and accepts directories, glob patterns, - for stdin and file:// or http(s):// URLs. The YAML and
+JSON files of directories are read in lexical order, recursively with -R. The documents of all inputs are deployed
+together, in the order of their kinds.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			var err error
 			opt.Namespace, err = cmd.Flags().GetString("namespace")
 			util.Check(err)
 
 			// Check file
-			if opt.InputFile == "" {
+			if len(opt.InputFiles) == 0 {
 				util.Check(errors.New("provided empty value for input file via the -f flag"))
 			}
+			err = setTemplate(cmd)
+			util.Check(err)
 
 			// Execute command
 			err = deploy.Execute(opt)
 			util.Check(err)
 
-			util.PrintSuccess("Successfully deployed resources")
+			if !opt.DryRun {
+				util.PrintSuccess("Successfully deployed resources")
+			}
 		},
 	}
 
 	// Register flags
-	cmd.Flags().StringVarP(&opt.InputFile, "file", "f", "", pkg.flagDescYaml)
+	cmd.Flags().StringArrayVarP(&opt.InputFiles, "file", "f", []string{}, pkg.flagDescInputs)
+	cmd.Flags().BoolVarP(&opt.Recursive, "recursive", "R", false, pkg.flagDescRecurse)
 	cmd.Flags().BoolVar(&opt.NoCache, "no-cache", false, "Disable caching for OfflineImage images after download")
 	cmd.Flags().IntVar(&opt.TransferPool, "transfer-pool", 2, "Maximum number of concurrent OfflineImage transfers")
+	cmd.Flags().BoolVar(&opt.DryRun, "dry-run", false, "Print what would change against the live Controller and Namespace state without deploying anything")
+	addTemplateFlags(cmd)
 
 	return cmd
 }
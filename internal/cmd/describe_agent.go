@@ -37,6 +37,9 @@ func newDescribeAgentCommand() *cobra.Command {
 			opt.Namespace, err = cmd.Flags().GetString("namespace")
 			util.Check(err)
 
+			opt.Watch, err = getWatchOptions(cmd)
+			util.Check(err)
+
 			// Get executor for describe command
 			exe, err := describe.NewExecutor(&opt)
 			util.Check(err)
@@ -45,9 +48,11 @@ func newDescribeAgentCommand() *cobra.Command {
 			err = exe.Execute()
 			util.Check(err)
 		},
+		ValidArgsFunction: completeArgs("agent"),
 	}
 	cmd.Flags().StringVarP(&opt.Filename, "output-file", "o", "", "YAML output file")
 	cmd.Flags().BoolVarP(&opt.IsDetached, "detached", "", false, pkg.flagDescDetached)
+	addWatchFlags(cmd)
 
 	return cmd
 }
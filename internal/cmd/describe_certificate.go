@@ -37,6 +37,9 @@ func newDescribeCertificateCommand() *cobra.Command {
 			opt.Namespace, err = cmd.Flags().GetString("namespace")
 			util.Check(err)
 
+			opt.Watch, err = getWatchOptions(cmd)
+			util.Check(err)
+
 			// Get executor for describe command
 			exe, err := describe.NewExecutor(&opt)
 			util.Check(err)
@@ -45,8 +48,10 @@ func newDescribeCertificateCommand() *cobra.Command {
 			err = exe.Execute()
 			util.Check(err)
 		},
+		ValidArgsFunction: completeArgs("certificate"),
 	}
 	cmd.Flags().StringVarP(&opt.Filename, "output-file", "o", "", "YAML output file")
+	addWatchFlags(cmd)
 
 	return cmd
 }
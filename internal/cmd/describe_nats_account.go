@@ -35,6 +35,7 @@ func newDescribeNatsAccountCommand() *cobra.Command {
 			}
 			util.Check(printNatsOutput(account, output, table))
 		},
+		ValidArgsFunction: completeArgs("application"),
 	}
 	cmd.Flags().StringVarP(&output, "output", "", natsOutputYAML, "Output format: yaml|json|wide")
 	cmd.Flags().BoolVar(&jwtOnly, "jwt", false, "Output decoded JWT payload only")
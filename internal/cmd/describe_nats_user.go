@@ -37,6 +37,7 @@ func newDescribeNatsUserCommand() *cobra.Command {
 			}
 			util.Check(printNatsOutput(user, output, table))
 		},
+		ValidArgsFunction: completeArgs("application"),
 	}
 	cmd.Flags().StringVarP(&output, "output", "", natsOutputYAML, "Output format: yaml|json|wide")
 	cmd.Flags().BoolVar(&jwtOnly, "jwt", false, "Output decoded JWT payload only")
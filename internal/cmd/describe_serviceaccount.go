@@ -36,6 +36,9 @@ func newDescribeServiceAccountCommand() *cobra.Command {
 			opt.Namespace, err = cmd.Flags().GetString("namespace")
 			util.Check(err)
 
+			opt.Watch, err = getWatchOptions(cmd)
+			util.Check(err)
+
 			exe, err := describe.NewExecutor(&opt)
 			util.Check(err)
 
@@ -44,6 +47,7 @@ func newDescribeServiceAccountCommand() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&opt.Filename, "output-file", "o", "", "YAML output file")
+	addWatchFlags(cmd)
 
 	return cmd
 }
@@ -47,6 +47,7 @@ The Agent stack will not be uninstalled from the host.`,
 
 			util.PrintSuccess("Successfully detached " + name)
 		},
+		ValidArgsFunction: completeArgs("agent"),
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Detach Agent even if it is running Microservices")
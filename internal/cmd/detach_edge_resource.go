@@ -44,6 +44,7 @@ func newDetachEdgeResourceCommand() *cobra.Command {
 			msg := fmt.Sprintf("Successfully detached %s/%s", name, version)
 			util.PrintSuccess(msg)
 		},
+		ValidArgsFunction: completeArgs("edge-resource", "", "agent"),
 	}
 
 	return cmd
@@ -44,6 +44,7 @@ func NewDetachExecMicroserviceCommand() *cobra.Command {
 			msg := fmt.Sprintf("Successfully detached Exec Session from Microservice %s", opt.Name)
 			util.PrintSuccess(msg)
 		},
+		ValidArgsFunction: completeArgs("microservice"),
 	}
 
 	return cmd
@@ -71,6 +72,7 @@ func newDetachExecAgentCommand() *cobra.Command {
 			msg := fmt.Sprintf("Successfully detached Exec Session from Agent %s", opt.Name)
 			util.PrintSuccess(msg)
 		},
+		ValidArgsFunction: completeArgs("agent"),
 	}
 
 	return cmd
@@ -45,6 +45,7 @@ func newDetachVolumeMountCommand() *cobra.Command {
 			msg := fmt.Sprintf("Successfully detached Volume Mount %s from Agents %s", opt.Name, strings.Join(opt.Agents, ", "))
 			util.PrintSuccess(msg)
 		},
+		ValidArgsFunction: completeVariadicArgs("volume-mount", "agent"),
 	}
 
 	return cmd
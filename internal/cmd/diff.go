@@ -0,0 +1,65 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/datasance/potctl/internal/deploy"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newDiffCommand() *cobra.Command {
+	// Instantiate options
+	opt := &deploy.Options{}
+
+	// Instantiate command
+	cmd := &cobra.Command{
+		Use:     "diff",
+		Example: `diff -f application.yaml`,
+		Args:    cobra.ExactArgs(0),
+		Short:   "Show what deploy would change",
+		Long: `Show what deploy would change.
+
+Each document is compared with the live state of the Controller, or with the Namespace file for Control Planes and Agents.
+A unified diff is printed for every resource that would be updated, followed by a create/update/unchanged summary.
+Kinds whose live state cannot be read, e.g. Catalog Items, Registries and Offline Images, are reported as not compared.
+Only fields present in the file are compared. Nothing is deployed.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			opt.Namespace, err = cmd.Flags().GetString("namespace")
+			util.Check(err)
+
+			// Check file
+			if len(opt.InputFiles) == 0 {
+				util.Check(errors.New("provided empty value for input file via the -f flag"))
+			}
+			err = setTemplate(cmd)
+			util.Check(err)
+
+			// Execute command
+			_, err = deploy.Diff(opt, os.Stdout)
+			util.Check(err)
+		},
+	}
+
+	// Register flags
+	cmd.Flags().StringArrayVarP(&opt.InputFiles, "file", "f", []string{}, pkg.flagDescInputs)
+	cmd.Flags().BoolVarP(&opt.Recursive, "recursive", "R", false, pkg.flagDescRecurse)
+	addTemplateFlags(cmd)
+
+	return cmd
+}
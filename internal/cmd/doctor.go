@@ -0,0 +1,89 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/datasance/potctl/internal/doctor"
+	"github.com/datasance/potctl/internal/util/printer"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newDoctorCommand() *cobra.Command {
+	opt := &doctor.Options{}
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the hosts and Control Plane of a Namespace or YAML file",
+		Long: `Diagnose the hosts and Control Plane of a Namespace or YAML file.
+
+Without -f, the Control Plane and Agents of the Namespace are diagnosed. With -f, those of the YAML file are, e.g. before deploying it.
+Nothing is installed or modified.
+
+  ssh                SSH connection to every remote Controller and Agent
+  sudo               Passwordless sudo on every remote host
+  prerequisites      The bundled check_prereqs.sh run on every remote host
+  container engine   Docker or Podman installed on every remote host
+  tls                Validity and expiry of the Https, site CA and local CA certificates and of https endpoints
+  kubeconfig         Access to the cluster of a Kubernetes Control Plane
+  api                Controller API reachability
+  login              Login to the Control Plane deployed in the Namespace
+  websocket          Websocket used by logs and exec, opened to a running Agent
+  version skew       Agent versions against the Controller version
+
+Each check passes, warns or fails. The command exits with a non-zero code if any check fails.`,
+		Example: `potctl doctor
+potctl doctor -n NAMESPACE -o json
+potctl doctor -f ecn.yaml`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			opt.Namespace, err = cmd.Flags().GetString("namespace")
+			util.Check(err)
+			output, err := cmd.Flags().GetString("output")
+			util.Check(err)
+			outputOpt, err := printer.Parse(output)
+			util.Check(err)
+			if !outputOpt.IsTable() && outputOpt.Format != printer.FormatYAML && outputOpt.Format != printer.FormatJSON {
+				util.Check(util.NewInputError(fmt.Sprintf("Unsupported output format: %s. One of: yaml|json", output)))
+			}
+			err = setTemplate(cmd)
+			util.Check(err)
+
+			// Keep yaml and json output parseable
+			if outputOpt.IsTable() {
+				util.SpinStart("Running diagnostics")
+			}
+			report, err := doctor.Run(opt)
+			util.SpinStop()
+			util.Check(err)
+
+			err = doctor.Print(os.Stdout, report, outputOpt)
+			util.Check(err)
+			if report.Failed() {
+				util.Check(util.NewError(fmt.Sprintf("%d checks failed", report.Summary.Fail)))
+			}
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opt.InputFiles, "file", "f", []string{}, pkg.flagDescInputs)
+	cmd.Flags().BoolVarP(&opt.Recursive, "recursive", "R", false, pkg.flagDescRecurse)
+	cmd.Flags().StringP("output", "o", "", "Output format. One of: yaml|json")
+	addTemplateFlags(cmd)
+
+	return cmd
+}
@@ -14,6 +14,12 @@
 package cmd
 
 import (
+	"os"
+
+	"github.com/datasance/potctl/internal/exec"
+	"github.com/datasance/potctl/internal/execute"
+	"github.com/datasance/potctl/internal/util/selector"
+	"github.com/datasance/potctl/pkg/util"
 	"github.com/spf13/cobra"
 )
 
@@ -33,3 +39,54 @@ func newExecCommand() *cobra.Command {
 
 	return cmd
 }
+
+// getExecCommand returns the resource name and the command following -- in the arguments, if any.
+// The name is empty when resources are selected with --selector.
+func getExecCommand(cmd *cobra.Command, args []string) (name string, command []string, err error) {
+	names := 1
+	if cmd.Flags().Changed("selector") {
+		names = 0
+	}
+	dash := cmd.ArgsLenAtDash()
+	if dash == -1 {
+		if len(args) != names {
+			return "", nil, util.NewInputError("Specify a single resource name or --selector, and separate the command to run with --")
+		}
+	} else {
+		if dash != names {
+			return "", nil, util.NewInputError("Specify a single resource name or --selector before --")
+		}
+		if len(args) == dash {
+			return "", nil, util.NewInputError("No command specified after --")
+		}
+		command = args[dash:]
+	}
+	if names == 1 {
+		name = args[0]
+	}
+	return name, command, nil
+}
+
+// newExecExecutor returns an executor for the named resource, or for all the resources matching --selector
+func newExecExecutor(cmd *cobra.Command, opt *exec.Options, selectNames func(string, *selector.Selector) ([]string, error)) (execute.Executor, error) {
+	sel, err := getSelector(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if sel == nil {
+		return exec.NewExecutor(opt)
+	}
+	names, err := selectNames(opt.Namespace, sel)
+	if err != nil {
+		return nil, err
+	}
+	return exec.NewMultiExecutor(opt, names)
+}
+
+// checkExecError exits with the exit code of the remote command if it failed
+func checkExecError(err error) {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		os.Exit(exitErr.Code)
+	}
+	util.Check(err)
+}
@@ -15,6 +15,7 @@ package cmd
 
 import (
 	"github.com/datasance/potctl/internal/exec"
+	clientutil "github.com/datasance/potctl/internal/util/client"
 	"github.com/datasance/potctl/pkg/util"
 	"github.com/spf13/cobra"
 )
@@ -25,27 +26,40 @@ func newExecAgentCommand() *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:     "agent AgentName",
-		Short:   "Connect to an Exec Session of an Agent",
-		Long:    `Connect to an Exec Session of an Agent to interact with its container.`,
-		Example: `potctl exec agent AgentName`,
-		Args:    cobra.ExactArgs(1),
+		Use:   "agent [AgentName] [-- COMMAND [args...]]",
+		Short: "Connect to an Exec Session of an Agent",
+		Long: `Connect to an Exec Session of an Agent to interact with its container.
+
+If a command is given after --, it is run without a terminal instead. Its stdout and stderr are written
+to the local stdout and stderr, local stdin is piped to it unless stdin is a terminal, and potctl exits
+with the exit code of the command. If the session ends without reporting an exit code, potctl fails.
+If the Agent does not acknowledge the command, potctl fails before sending anything to its stdin.
+
+With --selector instead of a name, the command is run on all the Agents whose tags match. Each line of output
+is prefixed with the name of its resource and potctl exits with the highest exit code.`,
+		Example: `potctl exec agent AgentName
+potctl exec agent AgentName -- cat /etc/hosts
+cat config.json | potctl exec agent AgentName -- sh -c 'cat > /tmp/config.json'
+potctl exec agent -l 'site in (plant-1,plant-3)' -- uptime`,
 		Run: func(cmd *cobra.Command, args []string) {
 			// Get resource type and name
 			var err error
-			opt.Name = args[0]
 			opt.Namespace, err = cmd.Flags().GetString("namespace")
 			util.Check(err)
+			opt.Name, opt.Command, err = getExecCommand(cmd, args)
+			util.Check(err)
 
 			// Get executor for exec command
-			exe, err := exec.NewExecutor(&opt)
+			exe, err := newExecExecutor(cmd, &opt, clientutil.SelectAgentNames)
 			util.Check(err)
 
 			// Execute the command
 			err = exe.Execute()
-			util.Check(err)
+			checkExecError(err)
 		},
+		ValidArgsFunction: completeArgs("agent"),
 	}
+	addSelectorFlag(cmd)
 
 	return cmd
 }
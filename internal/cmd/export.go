@@ -0,0 +1,57 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"github.com/datasance/potctl/internal/export"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newExportCommand() *cobra.Command {
+	opt := export.Options{}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export all the resources of a Namespace as YAML",
+		Long: `Export all the resources of a Namespace as a multi-document YAML file that potctl deploy accepts.
+
+Documents are ordered as deploy runs them, starting with the Control Plane. Applications include their
+Microservices and Agents include their configuration. Catalog items, registries and offline images are not exported.
+
+Status, identifiers, timestamps and tokens generated by the Controller are stripped, as is the Namespace of each
+document, so that the export can be deployed to another Namespace with -n. Secret data, passwords and private keys
+are kept unless --redact-secrets is set, the output file is only readable by its owner.`,
+		Example: `potctl export --output-file ecn.yaml
+potctl export --redact-secrets > ecn.yaml
+potctl export -n prod --output-file prod.yaml && potctl deploy -n staging -f prod.yaml`,
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			opt.Namespace, err = cmd.Flags().GetString("namespace")
+			util.Check(err)
+			if opt.Filename == "" {
+				// Keep stdout parseable
+				util.SpinEnable(false)
+			}
+
+			err = export.NewExecutor(opt).Execute()
+			util.Check(err)
+		},
+	}
+	cmd.Flags().StringVar(&opt.Filename, "output-file", "", "YAML output file, stdout if empty")
+	cmd.Flags().BoolVar(&opt.RedactSecrets, "redact-secrets", false, "Replace Secret data, passwords, keys and tokens with "+export.RedactedValue)
+
+	return cmd
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/datasance/potctl/internal/config"
 	"github.com/datasance/potctl/internal/get"
+	"github.com/datasance/potctl/internal/util/printer"
 	"github.com/datasance/potctl/pkg/util"
 	"github.com/spf13/cobra"
 )
@@ -80,7 +81,17 @@ Resources like Agents will require a working Controller in the namespace to disp
              nats-accounts
              nats-users
              nats-account-rules
-             nats-user-rules`,
+             nats-user-rules
+
+potctl get agents -o wide
+potctl get microservices -o yaml
+potctl get agents -o name
+potctl get agents -o jsonpath='{.items[*].metadata.name}'
+potctl get agents -o custom-columns=NAME:.metadata.name,HOST:.spec.host
+potctl get agents -l site=plant-3
+potctl get microservices -l 'site in (plant-1,plant-3),!maintenance'
+potctl get microservices -w
+potctl get agents --watch-events --watch-interval 5s`,
 		ValidArgs: validResources,
 		Args:      cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
@@ -90,6 +101,14 @@ Resources like Agents will require a working Controller in the namespace to disp
 			util.Check(err)
 			showDetached, err := cmd.Flags().GetBool("detached")
 			util.Check(err)
+			output, err := cmd.Flags().GetString("output")
+			util.Check(err)
+			outputOpt, err := printer.Parse(output)
+			util.Check(err)
+			watchOpt, err := getWatchOptions(cmd)
+			util.Check(err)
+			sel, err := getSelector(cmd)
+			util.Check(err)
 
 			// TODO: Break out resources as subcommands to avoid this kind of logic and improve --help accuracy
 			if showDetached && resource != "agents" {
@@ -102,7 +121,14 @@ Resources like Agents will require a working Controller in the namespace to disp
 			}
 
 			// Get executor for get command
-			exe, err := get.NewExecutor(resource, namespace, showDetached)
+			exe, err := get.NewExecutor(&get.Options{
+				Resource:     resource,
+				Namespace:    namespace,
+				ShowDetached: showDetached,
+				Output:       outputOpt,
+				Watch:        watchOpt,
+				Selector:     sel,
+			})
 			util.Check(err)
 
 			// Execute the get command
@@ -112,6 +138,9 @@ Resources like Agents will require a working Controller in the namespace to disp
 	}
 
 	cmd.Flags().Bool("detached", false, pkg.flagDescDetached)
+	cmd.Flags().StringP("output", "o", "", printer.FlagDesc)
+	addWatchFlags(cmd)
+	addSelectorFlag(cmd)
 
 	return cmd
 }
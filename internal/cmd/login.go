@@ -0,0 +1,69 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/datasance/potctl/internal/login"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newLoginCommand() *cobra.Command {
+	opt := login.Options{}
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in to the Controller of a namespace with the identity provider",
+		Long: `Log in to the Controller of a namespace with the identity provider
+
+The namespace then only keeps the refresh token of the session, its password is removed. Sessions are
+refreshed transparently, run potctl login again once the refresh token has expired.
+
+Users log in with the Keycloak realm of the Control Plane:
+  device   Open the printed URL on any device and enter the code, e.g. when potctl runs over SSH
+  browser  Log in with the browser, redirected back to potctl with an authorization code and PKCE
+
+CI jobs log in as a service account with the client-credentials mode, the client secret being read from --client-secret
+or the POTCTL_CLIENT_SECRET environment variable. Clients of this mode log in again when their session expires,
+with the secret read from POTCTL_CLIENT_SECRET. The secret of a client other than the Controller client is only kept
+in the namespace when a secret store is configured with potctl configure secret-store.
+
+The Controller client of the realm is used by default. It must allow the device authorization grant, the standard flow
+with loopback redirect URIs, or service accounts depending on the mode.`,
+		Example: `potctl login -n NAMESPACE
+potctl login -n NAMESPACE --mode browser
+POTCTL_CLIENT_SECRET=SECRET potctl login -n NAMESPACE --mode client-credentials --client-id ci`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			opt.Namespace, err = cmd.Flags().GetString("namespace")
+			util.Check(err)
+
+			exe, err := login.NewExecutor(opt)
+			util.Check(err)
+			err = exe.Execute()
+			util.Check(err)
+
+			util.PrintSuccess(fmt.Sprintf("Logged in to namespace %s", opt.Namespace))
+		},
+	}
+	cmd.Flags().StringVar(&opt.Mode, "mode", login.ModeDevice, fmt.Sprintf("Login mode. One of: %s", strings.Join(login.Modes(), "|")))
+	cmd.Flags().StringVar(&opt.ClientID, "client-id", "", "Client of the realm to log in with, defaults to the Controller client")
+	cmd.Flags().StringVar(&opt.ClientSecret, "client-secret", "", "Secret of the client, defaults to "+login.ClientSecretEnv)
+	cmd.Flags().BoolVar(&opt.NoBrowser, "no-browser", false, "Print the URL of the browser mode instead of opening the browser")
+
+	return cmd
+}
@@ -15,25 +15,59 @@ package cmd
 
 import (
 	"github.com/datasance/potctl/internal/logs"
+	clientutil "github.com/datasance/potctl/internal/util/client"
 	"github.com/datasance/potctl/pkg/util"
 	"github.com/spf13/cobra"
 )
 
 func newLogsCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "logs RESOURCE NAME",
+		Use:   "logs RESOURCE [NAME]",
 		Short: "Get log contents of deployed resource",
-		Long:  `Get log contents of deployed resource`,
+		Long: `Get log contents of deployed resource
+
+Use --selector instead of a name to stream the logs of all the Agents whose tags match, or of all the
+Microservices running on them. Use the application resource to stream the logs of all the Microservices of an
+Application. When streaming several resources at once, each line is prefixed with the name of its resource,
+colored when writing to a terminal unless NO_COLOR is set.
+
+Lines are filtered client side with --grep. --timestamps prepends the time each line was received, and
+--output json writes one JSON record per line with its time, resource, name and line.`,
 		Example: `potctl logs controller   NAME
               agent        NAME
-              microservice AppName/MsvcName`,
-		Args: cobra.ExactArgs(2),
+              microservice AppName/MsvcName
+              application  AppName
+
+potctl logs agent -l site=plant-3
+potctl logs agent -l edge --grep 'ERROR|WARN'
+potctl logs microservice -l site=plant-3 --tail 20
+potctl logs application AppName --timestamps -o json`,
+		Args: cobra.RangeArgs(1, 2),
 		Run: func(cmd *cobra.Command, args []string) {
 			// Get Resource type and name
 			resource := args[0]
-			name := args[1]
 			namespace, err := cmd.Flags().GetString("namespace")
 			util.Check(err)
+			var names []string
+			switch resource {
+			case "agent":
+				names, err = getTargetNames(cmd, args[1:], namespace, clientutil.SelectAgentNames)
+			case "microservice":
+				names, err = getTargetNames(cmd, args[1:], namespace, clientutil.SelectMicroserviceNames)
+			default:
+				if cmd.Flags().Changed("selector") {
+					err = util.NewInputError("Can only use --selector with Agents and Microservices")
+				} else {
+					names, err = getTargetNames(cmd, args[1:], namespace, nil)
+				}
+			}
+			util.Check(err)
+			if resource == "application" {
+				// Stream all the Microservices of the Application
+				resource = "microservice"
+				names, err = clientutil.GetApplicationMicroserviceNames(namespace, names[0])
+			}
+			util.Check(err)
 
 			// Parse log tail configuration flags
 			tail, err := cmd.Flags().GetInt("tail")
@@ -57,14 +91,30 @@ func newLogsCommand() *cobra.Command {
 			err = logConfig.Validate()
 			util.Check(err)
 
+			// Parse output configuration flags
+			outConfig := &logs.OutputConfig{}
+			outConfig.Grep, err = cmd.Flags().GetString("grep")
+			util.Check(err)
+			outConfig.Timestamps, err = cmd.Flags().GetBool("timestamps")
+			util.Check(err)
+			outConfig.Output, err = cmd.Flags().GetString("output")
+			util.Check(err)
+			err = outConfig.Validate()
+			util.Check(err)
+			if outConfig.Output == "json" {
+				// Keep stdout parseable
+				util.SpinEnable(false)
+			}
+
 			// Instantiate logs executor
-			exe, err := logs.NewExecutor(resource, namespace, name, logConfig)
+			exe, err := logs.NewMultiExecutor(resource, namespace, names, logConfig, outConfig)
 			util.Check(err)
 
 			// Run the logs command
 			err = exe.Execute()
 			util.Check(err)
 		},
+		ValidArgsFunction: completeResourceArgs(map[string]string{"controller": "controller", "agent": "agent", "microservice": "microservice", "application": "application"}),
 	}
 
 	// Add flags for log tail configuration
@@ -72,6 +122,10 @@ func newLogsCommand() *cobra.Command {
 	cmd.Flags().Bool("follow", true, "Follow log output")
 	cmd.Flags().String("since", "", "Start time in ISO 8601 format (e.g., 2024-01-01T00:00:00Z)")
 	cmd.Flags().String("until", "", "End time in ISO 8601 format (e.g., 2024-01-02T00:00:00Z)")
+	cmd.Flags().String("grep", "", "Only print the lines matching this regular expression")
+	cmd.Flags().Bool("timestamps", false, "Prepend the time each line was received")
+	cmd.Flags().StringP("output", "o", "", "Output format. One of: json")
+	addSelectorFlag(cmd)
 
 	return cmd
 }
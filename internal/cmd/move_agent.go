@@ -51,6 +51,7 @@ func newMoveAgentCommand() *cobra.Command {
 
 			util.PrintSuccess(getMoveSuccessMessage("Agent", name, "Namespace", destNamespace))
 		},
+		ValidArgsFunction: completeArgs("agent", "namespace"),
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Move Agent even if it is running Microservices")
@@ -39,6 +39,7 @@ func newMoveMicroserviceCommand() *cobra.Command {
 
 			util.PrintSuccess(getMoveSuccessMessage("Microservice", name, "Agent", agent))
 		},
+		ValidArgsFunction: completeArgs("microservice", "agent"),
 	}
 
 	return cmd
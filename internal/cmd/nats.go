@@ -6,19 +6,15 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"text/tabwriter"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
 	clientutil "github.com/datasance/potctl/internal/util/client"
+	"github.com/datasance/potctl/internal/util/printer"
 	"github.com/datasance/potctl/pkg/util"
 	"github.com/spf13/cobra"
 )
 
-const (
-	natsOutputYAML = "yaml"
-	natsOutputJSON = "json"
-	natsOutputWide = "wide"
-)
+const natsOutputYAML = printer.FormatYAML
 
 func newNatsCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -123,6 +119,7 @@ func newNatsAccountsEnsureCommand() *cobra.Command {
 			}
 			util.Check(printNatsOutput(account, output, table))
 		},
+		ValidArgsFunction: completeArgs("application"),
 	}
 	cmd.Flags().StringVarP(&output, "output", "", natsOutputYAML, "Output format: yaml|json|wide")
 	cmd.Flags().StringVar(&natsRule, "nats-rule", "", "NATS account rule name")
@@ -181,6 +178,7 @@ func newNatsUsersCreateCommand() *cobra.Command {
 			}
 			util.Check(printNatsOutput(user, output, table))
 		},
+		ValidArgsFunction: completeArgs("application"),
 	}
 	cmd.Flags().StringVarP(&output, "output", "", natsOutputYAML, "Output format: yaml|json|wide")
 	cmd.Flags().Int64Var(&expiresIn, "expires-in", 0, "Expiry in seconds")
@@ -203,6 +201,7 @@ func newNatsUsersDeleteCommand() *cobra.Command {
 			util.Check(err)
 			util.PrintSuccess(fmt.Sprintf("Deleted NATS user %s/%s", args[0], args[1]))
 		},
+		ValidArgsFunction: completeArgs("application"),
 	}
 	return cmd
 }
@@ -239,6 +238,7 @@ func newNatsUsersCreateMqttBearerCommand() *cobra.Command {
 			}
 			util.Check(printNatsOutput(user, output, table))
 		},
+		ValidArgsFunction: completeArgs("application"),
 	}
 	cmd.Flags().StringVarP(&output, "output", "", natsOutputYAML, "Output format: yaml|json|wide")
 	cmd.Flags().Int64Var(&expiresIn, "expires-in", 0, "Expiry in seconds")
@@ -261,6 +261,7 @@ func newNatsUsersDeleteMqttBearerCommand() *cobra.Command {
 			util.Check(err)
 			util.PrintSuccess(fmt.Sprintf("Deleted MQTT bearer user %s/%s", args[0], args[1]))
 		},
+		ValidArgsFunction: completeArgs("application"),
 	}
 	return cmd
 }
@@ -292,47 +293,24 @@ func newNatsUsersCredsCommand() *cobra.Command {
 			util.Check(err)
 			util.PrintSuccess(fmt.Sprintf("Saved NATS creds to %s", outputFile))
 		},
+		ValidArgsFunction: completeArgs("application"),
 	}
 	cmd.Flags().StringVarP(&outputFile, "output-file", "o", "", "Destination creds file path (always overwritten)")
 	return cmd
 }
 
 func printNatsOutput(obj interface{}, output string, wide [][]string) error {
-	switch strings.ToLower(output) {
-	case natsOutputYAML:
-		return util.Print(obj)
-	case natsOutputJSON:
-		j, err := json.MarshalIndent(obj, "", "  ")
-		if err != nil {
-			return err
-		}
-		_, err = fmt.Fprintln(os.Stdout, string(j))
+	outputOpt, err := printer.Parse(output)
+	if err != nil {
 		return err
-	case natsOutputWide:
+	}
+	if outputOpt.IsTable() {
 		if len(wide) == 0 {
 			return nil
 		}
-		return printWideTable(wide)
-	default:
-		return util.NewInputError("invalid --output, use yaml|json|wide")
-	}
-}
-
-func printWideTable(table [][]string) error {
-	writer := tabwriter.NewWriter(os.Stdout, 16, 8, 1, '\t', 0)
-	defer writer.Flush()
-	for _, row := range table {
-		for _, col := range row {
-			if _, err := fmt.Fprintf(writer, "%s\t", col); err != nil {
-				return err
-			}
-		}
-		if _, err := fmt.Fprintln(writer); err != nil {
-			return err
-		}
+		return printer.PrintTable(os.Stdout, wide)
 	}
-	_, err := fmt.Fprintln(writer)
-	return err
+	return outputOpt.PrintObject(os.Stdout, obj)
 }
 
 func findNatsUserByName(users []client.NatsUserInfo, name string) (client.NatsUserInfo, error) {
@@ -1,10 +1,10 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/describe"
 	"github.com/datasance/potctl/pkg/util"
 )
 
@@ -18,17 +18,9 @@ func findNatsRuleByName(rules []client.NatsRuleInfo, name string) (client.NatsRu
 }
 
 func buildNatsRuleManifest(kind string, rule client.NatsRuleInfo) map[string]interface{} {
-	b, err := json.Marshal(rule)
-	util.Check(err)
-
-	spec := map[string]interface{}{}
-	err = json.Unmarshal(b, &spec)
-	util.Check(err)
-
 	// Keep rule manifests Controller-compatible: identity belongs in metadata.
-	delete(spec, "id")
-	delete(spec, "name")
-	delete(spec, "isSystem")
+	spec, err := describe.NatsSpec(rule, describe.NatsRuleIdentityFields...)
+	util.Check(err)
 
 	return map[string]interface{}{
 		"apiVersion": "datasance.com/v3",
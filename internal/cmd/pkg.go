@@ -21,6 +21,8 @@ import (
 var pkg struct {
 	flagDescDetached string
 	flagDescYaml     string
+	flagDescInputs   string
+	flagDescRecurse  string
 	succRename       string
 	succMove         string
 }
@@ -28,6 +30,8 @@ var pkg struct {
 func init() {
 	pkg.flagDescDetached = "Specify command is to run against detached resources"
 	pkg.flagDescYaml = "YAML file containing specifications for ioFog resources to deploy"
+	pkg.flagDescInputs = "YAML file, directory, glob pattern, - for stdin, or file:// or http(s):// URL containing specifications for ioFog resources, can be repeated"
+	pkg.flagDescRecurse = "Read the files of directories passed to -f recursively"
 	pkg.succRename = "Successfully renamed %s %s to %s"
 	pkg.succMove = "Successfully moved %s %s to %s %s"
 }
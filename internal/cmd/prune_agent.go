@@ -14,36 +14,50 @@
 package cmd
 
 import (
+	"github.com/datasance/potctl/internal/execute"
 	prune "github.com/datasance/potctl/internal/prune/agent"
+	clientutil "github.com/datasance/potctl/internal/util/client"
 	"github.com/datasance/potctl/pkg/util"
 	"github.com/spf13/cobra"
 )
 
 func newPruneAgentCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "agent NAME",
-		Short:   "Remove all dangling images from Agent",
-		Long:    `Remove all the images which are not used by existing containers on the specified Agent`,
-		Example: `potctl prune agent NAME`,
-		Args:    cobra.ExactArgs(1),
+		Use:   "agent [NAME]",
+		Short: "Remove all dangling images from Agent",
+		Long: `Remove all the images which are not used by existing containers on the specified Agent
+
+Use --selector instead of a name to prune all the Agents whose tags match.`,
+		Example: `potctl prune agent NAME
+potctl prune agent -l site=plant-3`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			// Get name and namespace of agent
-			name := args[0]
 			namespace, err := cmd.Flags().GetString("namespace")
 			util.Check(err)
 			useDetached, err := cmd.Flags().GetBool("detached")
 			util.Check(err)
+			if useDetached && cmd.Flags().Changed("selector") {
+				util.Check(util.NewInputError("Cannot use --selector with detached Agents"))
+			}
+			names, err := getTargetNames(cmd, args, namespace, clientutil.SelectAgentNames)
+			util.Check(err)
 
 			// Run the command
-			exe := prune.NewExecutor(namespace, name, useDetached)
-			err = exe.Execute()
+			err = executeForEach(names, "prune agents", func(name string) (execute.Executor, error) {
+				return prune.NewExecutor(namespace, name, useDetached), nil
+			})
 			util.Check(err)
 
-			util.PrintSuccess("Successfully pruned " + name)
+			for _, name := range names {
+				util.PrintSuccess("Successfully pruned " + name)
+			}
 		},
+		ValidArgsFunction: completeArgs("agent"),
 	}
 
 	cmd.Flags().Bool("detached", false, pkg.flagDescDetached)
+	addSelectorFlag(cmd)
 
 	return cmd
 }
@@ -44,6 +44,7 @@ func newRebuildMicroserviceCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully rebuild Microservice " + opt.Name)
 		},
+		ValidArgsFunction: completeArgs("microservice"),
 	}
 	return cmd
 }
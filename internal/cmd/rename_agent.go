@@ -41,6 +41,7 @@ func newRenameAgentCommand() *cobra.Command {
 
 			util.PrintSuccess(getRenameSuccessMessage("Agent", name, newName))
 		},
+		ValidArgsFunction: completeArgs("agent"),
 	}
 
 	cmd.Flags().Bool("detached", false, pkg.flagDescDetached)
@@ -39,6 +39,7 @@ func newRenameApplicationCommand() *cobra.Command {
 
 			util.PrintSuccess(getRenameSuccessMessage("Application", name, newName))
 		},
+		ValidArgsFunction: completeArgs("application"),
 	}
 
 	return cmd
@@ -39,6 +39,7 @@ func newRenameControllerCommand() *cobra.Command {
 
 			util.PrintSuccess(getRenameSuccessMessage("Controller", name, newName))
 		},
+		ValidArgsFunction: completeArgs("controller"),
 	}
 
 	return cmd
@@ -39,6 +39,7 @@ func newRenameEdgeResourceCommand() *cobra.Command {
 
 			util.PrintSuccess(getRenameSuccessMessage("Edge Resource", name, newName))
 		},
+		ValidArgsFunction: completeArgs("edge-resource"),
 	}
 
 	return cmd
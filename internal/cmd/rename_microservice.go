@@ -39,6 +39,7 @@ func newRenameMicroserviceCommand() *cobra.Command {
 
 			util.PrintSuccess(getRenameSuccessMessage("Microservice", name, newName))
 		},
+		ValidArgsFunction: completeArgs("microservice"),
 	}
 
 	return cmd
@@ -37,6 +37,7 @@ func newRenameNamespaceCommand() *cobra.Command {
 
 			util.PrintSuccess(getRenameSuccessMessage("Namespace", name, newName))
 		},
+		ValidArgsFunction: completeArgs("namespace"),
 	}
 
 	return cmd
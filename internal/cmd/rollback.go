@@ -52,6 +52,7 @@ func newRollbackCommand() *cobra.Command {
 
 			util.PrintSuccess(fmt.Sprintf("Succesfully scheduled rollback for %s %s", strings.Title(opt.ResourceType), opt.Name))
 		},
+		ValidArgsFunction: completeResourceArgs(map[string]string{"agent": "agent"}),
 	}
 
 	return cmd
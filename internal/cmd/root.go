@@ -16,6 +16,7 @@ package cmd
 import (
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
 	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/execute"
 	"github.com/datasance/potctl/pkg/iofog/install"
 	"github.com/datasance/potctl/pkg/util"
 	"github.com/spf13/cobra"
@@ -63,13 +64,24 @@ func NewRootCommand() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Toggle for displaying verbose output of potctl")
 	cmd.PersistentFlags().BoolVar(&debug, "debug", false, "Toggle for displaying verbose output of API clients (HTTP and SSH)")
 	cmd.PersistentFlags().StringP("namespace", "n", config.GetDefaultNamespaceName(), "Namespace to execute respective command within")
+	cmd.PersistentFlags().IntVar(&parallelism, "parallelism", execute.DefaultParallelism, "Maximum number of resources processed at once, unbounded if 0")
+	cmd.PersistentFlags().BoolVar(&failFast, "fail-fast", false, "Do not start the remaining resources after the first failure, those in progress are completed")
+	cmd.PersistentFlags().IntVar(&retries, "retries", 0, "Number of retries of operations failing with transient errors, operations may not be idempotent. The delay doubles on each retry, starting longer for SSH based resources")
+	util.Check(cmd.RegisterFlagCompletionFunc("namespace", completeNamespaces))
 
 	// Register all commands
 	cmd.AddCommand(
+		newApplyCommand(),
 		newConnectCommand(),
+		newLoginCommand(),
 		newConfigureCommand(),
+		newConfigCommand(),
 		newDisconnectCommand(),
 		newDeployCommand(),
+		newDiffCommand(),
+		newDoctorCommand(),
+		newExportCommand(),
+		newTemplateCommand(),
 		newDeleteCommand(),
 		newDetachCommand(),
 		newAttachCommand(),
@@ -91,6 +103,8 @@ func NewRootCommand() *cobra.Command {
 		newUpgradeCommand(),
 		newRollbackCommand(),
 		newExecCommand(),
+		newCpCommand(),
+		newWaitCommand(),
 		newNatsCommand(),
 	)
 
@@ -103,6 +117,13 @@ var verbose bool
 // Toggle set by --debug persistent flag
 var debug bool
 
+// Execution policy set by --parallelism, --fail-fast and --retries persistent flags
+var (
+	parallelism int
+	failFast    bool
+	retries     int
+)
+
 // Callback for cobra on initialization
 func initialize() {
 	client.SetGlobalRetries(client.Retries{
@@ -118,4 +139,9 @@ func initialize() {
 	install.SetVerbosity(verbose)
 	util.SpinEnable(!verbose && !debug)
 	util.SetDebug(debug)
+	execute.SetPolicy(execute.Policy{
+		Parallelism: parallelism,
+		FailFast:    failFast,
+		Retries:     retries,
+	})
 }
@@ -0,0 +1,67 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"github.com/datasance/potctl/internal/execute"
+	"github.com/datasance/potctl/internal/util/selector"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func addSelectorFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("selector", "l", "", selector.FlagDesc)
+}
+
+// getSelector returns nil unless --selector is set
+func getSelector(cmd *cobra.Command) (*selector.Selector, error) {
+	expression, err := cmd.Flags().GetString("selector")
+	if err != nil || expression == "" {
+		return nil, err
+	}
+	return selector.Parse(expression)
+}
+
+// getTargetNames returns the name passed as argument, or the names of the resources matching --selector
+func getTargetNames(cmd *cobra.Command, args []string, namespace string, selectNames func(string, *selector.Selector) ([]string, error)) ([]string, error) {
+	sel, err := getSelector(cmd)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case sel != nil && len(args) > 0:
+		return nil, util.NewInputError("Cannot specify a name together with --selector")
+	case sel != nil:
+		return selectNames(namespace, sel)
+	case len(args) == 0:
+		return nil, util.NewInputError("Must specify a name or --selector")
+	}
+	return args[:1], nil
+}
+
+// executeForEach runs an executor for each of the names in parallel
+func executeForEach(names []string, execType string, newExecutor func(name string) (execute.Executor, error)) error {
+	exes := make([]execute.Executor, len(names))
+	for idx, name := range names {
+		exe, err := newExecutor(name)
+		if err != nil {
+			return err
+		}
+		exes[idx] = exe
+	}
+	if errs := execute.RunExecutors(exes, execType); len(errs) > 0 {
+		return execute.CoalesceErrors(errs)
+	}
+	return nil
+}
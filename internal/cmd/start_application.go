@@ -44,6 +44,7 @@ func newStartApplicationCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully started Application " + opt.Name)
 		},
+		ValidArgsFunction: completeArgs("application"),
 	}
 	return cmd
 }
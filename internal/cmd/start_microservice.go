@@ -44,6 +44,7 @@ func newStartMicroserviceCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully started Microservice " + opt.Name)
 		},
+		ValidArgsFunction: completeArgs("microservice"),
 	}
 	return cmd
 }
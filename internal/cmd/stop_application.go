@@ -44,6 +44,7 @@ func newStopApplicationCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully stopped Application " + opt.Name)
 		},
+		ValidArgsFunction: completeArgs("application"),
 	}
 	return cmd
 }
@@ -44,6 +44,7 @@ func newStopMicroserviceCommand() *cobra.Command {
 
 			util.PrintSuccess("Successfully stopped Microservice " + opt.Name)
 		},
+		ValidArgsFunction: completeArgs("microservice"),
 	}
 	return cmd
 }
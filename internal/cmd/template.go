@@ -0,0 +1,104 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/datasance/potctl/internal/execute"
+	"github.com/datasance/potctl/internal/template"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newTemplateCommand() *cobra.Command {
+	inputFiles := []string{}
+	recursive := false
+	outputFile := ""
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Render a templated YAML file or kustomization without deploying it",
+		Long: `Render a templated YAML file or kustomization without deploying it.
+
+Templating is opt-in: deploy, apply, diff and delete render their input file when any of --values, --set or
+--expand-env is provided. ${VAR} references are expanded first with --expand-env, then the file is rendered as a Go
+text/template with the merged values as .Values. --set values are merged after the values files, in order.
+
+Referencing a value that is not set fails the render, unless it is read with index,
+e.g. {{ index .Values "tag" | default "latest" }}. Available functions besides the text/template builtins are
+env, default, required, quote, upper, lower, trim, replace, join, indent, nindent and toYaml.
+
+-f also accepts a potctl.kustomization.yaml file, or a directory containing one. Kustomizations list resources,
+which are files of documents or other kustomizations, patches merged into the documents with the same kind and
+metadata.name, a namePrefix and commonTags added to every document. The namePrefix is also added to the references
+to the documents, such as the Agents of Microservices, the Secrets and ConfigMaps of VolumeMounts and the Roles of
+RoleBindings, while references to resources outside of the kustomization are kept. Lists of items with a name or
+key, such as Microservices and env vars, are patched item by item, and items with "$patch: delete" are removed.
+Each file is templated before the kustomization is built.`,
+		Example: `potctl template -f app.yaml --values plant-1.yaml
+potctl template -f app.yaml --values sites.yaml --set site.name=plant-2 -o plant-2.yaml
+potctl template -f overlays/prod
+potctl deploy -f app.yaml --values plant-1.yaml --expand-env`,
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(inputFiles) == 0 {
+				util.Check(errors.New("provided empty value for input file via the -f flag"))
+			}
+			err := setTemplate(cmd)
+			util.Check(err)
+
+			rendered, err := execute.ReadInputs(inputFiles, recursive)
+			util.Check(err)
+
+			if outputFile == "" {
+				_, err = os.Stdout.Write(rendered)
+				util.Check(err)
+				return
+			}
+			err = os.WriteFile(outputFile, rendered, 0600)
+			util.Check(err)
+			util.PrintSuccess(outputFile + " rendered")
+		},
+	}
+	cmd.Flags().StringArrayVarP(&inputFiles, "file", "f", []string{}, pkg.flagDescInputs)
+	cmd.Flags().BoolVarP(&recursive, "recursive", "R", false, pkg.flagDescRecurse)
+	cmd.Flags().StringVarP(&outputFile, "output-file", "o", "", "YAML output file, stdout if empty")
+	addTemplateFlags(cmd)
+
+	return cmd
+}
+
+func addTemplateFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("values", []string{}, template.FlagDescValues)
+	cmd.Flags().StringArray("set", []string{}, template.FlagDescSet)
+	cmd.Flags().Bool("expand-env", false, template.FlagDescExpandEnv)
+}
+
+// setTemplate enables the templating of input files configured by the template flags
+func setTemplate(cmd *cobra.Command) error {
+	opt := &template.Options{}
+	var err error
+	if opt.ValuesFiles, err = cmd.Flags().GetStringArray("values"); err != nil {
+		return err
+	}
+	if opt.Set, err = cmd.Flags().GetStringArray("set"); err != nil {
+		return err
+	}
+	if opt.ExpandEnv, err = cmd.Flags().GetBool("expand-env"); err != nil {
+		return err
+	}
+	execute.SetTemplate(opt)
+	return nil
+}
@@ -27,20 +27,33 @@ func newUpgradeCommand() *cobra.Command {
 	var opt upgrade.Options
 
 	cmd := &cobra.Command{
-		Use:     "upgrade RESOURCE NAME",
-		Short:   "Upgrade ioFog resources",
-		Long:    `Upgrade ioFog resources to latest versions available.`,
-		Example: `potctl upgrade agent NAME`,
-		Args:    cobra.ExactArgs(2),
+		Use:   "upgrade RESOURCE [NAME]",
+		Short: "Upgrade ioFog resources",
+		Long: `Upgrade ioFog resources to latest versions available.
+
+A single named Agent is upgraded in the background. Fleets of Agents selected with --tag, --selector or
+--all are rolled out in batches of --batch-size Agents: each batch must report the new version and a RUNNING
+status within --timeout before the next batch starts. Agents failing this health gate after changing version are
+rolled back. The rollout halts once --max-unavailable Agents failed the health gate. Agents that were already down
+are skipped and don't count against --max-unavailable.`,
+		Example: `potctl upgrade agent NAME
+potctl upgrade agent --all --batch-size 5
+potctl upgrade agent --tag edge --tag eu-west --max-unavailable 2 --version 3.5.0
+potctl upgrade agent -l 'site=plant-3,tier!=canary' --batch-size 2`,
+		Args: cobra.RangeArgs(1, 2),
 		Run: func(cmd *cobra.Command, args []string) {
 			// Get resource type and name
 			opt.ResourceType = args[0]
-			opt.Name = args[1]
+			if len(args) > 1 {
+				opt.Name = args[1]
+			}
 
 			var err error
 			// Get namespace option
 			opt.Namespace, err = cmd.Flags().GetString("namespace")
 			util.Check(err)
+			opt.Selector, err = getSelector(cmd)
+			util.Check(err)
 
 			// Get executor for upgrade command
 			exe, err := upgrade.NewExecutor(opt)
@@ -50,9 +63,22 @@ func newUpgradeCommand() *cobra.Command {
 			err = exe.Execute()
 			util.Check(err)
 
+			if opt.Name == "" {
+				util.PrintSuccess(fmt.Sprintf("Successfully upgraded %s", exe.GetName()))
+				return
+			}
 			util.PrintSuccess(fmt.Sprintf("Succesfully scheduled upgrade for %s %s", strings.Title(opt.ResourceType), opt.Name))
 		},
+		ValidArgsFunction: completeResourceArgs(map[string]string{"agent": "agent"}),
 	}
 
+	cmd.Flags().StringSliceVar(&opt.Tags, "tag", []string{}, "Upgrade the Agents having all of these tags")
+	addSelectorFlag(cmd)
+	cmd.Flags().BoolVar(&opt.All, "all", false, "Upgrade all Agents of the Namespace")
+	cmd.Flags().IntVar(&opt.BatchSize, "batch-size", 1, "Number of Agents upgraded at once")
+	cmd.Flags().IntVar(&opt.MaxUnavailable, "max-unavailable", 0, "Number of Agents failing the health gate that halts the rollout, defaults to the batch size")
+	cmd.Flags().StringVar(&opt.Version, "version", "", "Version the Agents must report after the upgrade, any new version if empty")
+	cmd.Flags().DurationVar(&opt.Timeout, "timeout", upgrade.DefaultRolloutTimeout, "Time each Agent has to report the new version and RUNNING")
+
 	return cmd
 }
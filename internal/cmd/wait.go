@@ -0,0 +1,76 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"github.com/datasance/potctl/internal/wait"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newWaitCommand() *cobra.Command {
+	opt := wait.Options{}
+
+	cmd := &cobra.Command{
+		Use:   "wait RESOURCE [NAME]",
+		Short: "Wait for a resource to reach a condition",
+		Long: `Wait for a resource to reach a condition.
+
+Conditions are matched against the status reported by the Controller, as shown by potctl describe, ignoring
+case. Ready is accepted as an alias of Running.
+
+  agent          Any daemon status, e.g. Running
+  microservice   Any Microservice status, e.g. Running. Fails as soon as the Microservice is FAILED or DELETED
+  application    Any Microservice status, met when all Microservices of the Application have it
+  controlplane   Ready, met once the Controller API answers. The Control Plane takes no name
+  certificate    Ready, met once the Certificate exists. Fails if the Certificate is expired
+
+The command exits with a non-zero code when the timeout is reached or the resource fails.`,
+		Example: `potctl wait --for=condition=Running microservice AppName/MsvcName --timeout 5m
+potctl wait --for=condition=Running agent NAME
+potctl wait --for=condition=Running application NAME
+potctl wait --for=condition=Ready controlplane
+potctl wait --for=condition=Ready certificate NAME`,
+		ValidArgsFunction: completeResourceArgs(map[string]string{
+			"agent":        "agent",
+			"microservice": "microservice",
+			"application":  "application",
+			"controlplane": "",
+			"certificate":  "certificate",
+		}),
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			opt.Resource = args[0]
+			if len(args) > 1 {
+				opt.Name = args[1]
+			}
+			opt.Namespace, err = cmd.Flags().GetString("namespace")
+			util.Check(err)
+
+			exe, err := wait.NewExecutor(&opt)
+			util.Check(err)
+
+			err = exe.Execute()
+			util.Check(err)
+
+			util.PrintSuccess("Condition " + opt.For + " met")
+		},
+	}
+
+	cmd.Flags().StringVar(&opt.For, "for", "", "Condition to wait for, in the form condition=NAME")
+	cmd.Flags().DurationVar(&opt.Timeout, "timeout", wait.DefaultTimeout, "Time to wait for the condition before failing")
+
+	return cmd
+}
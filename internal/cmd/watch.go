@@ -0,0 +1,48 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"github.com/datasance/potctl/internal/util/watch"
+	"github.com/spf13/cobra"
+)
+
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("watch", "w", false, watch.FlagDescWatch)
+	cmd.Flags().Bool("watch-events", false, watch.FlagDescEvents)
+	cmd.Flags().Duration("watch-interval", watch.DefaultInterval, watch.FlagDescInterval)
+}
+
+// getWatchOptions returns nil unless --watch or --watch-events is set
+func getWatchOptions(cmd *cobra.Command) (*watch.Options, error) {
+	enabled, err := cmd.Flags().GetBool("watch")
+	if err != nil {
+		return nil, err
+	}
+	events, err := cmd.Flags().GetBool("watch-events")
+	if err != nil {
+		return nil, err
+	}
+	if !enabled && !events {
+		return nil, nil
+	}
+	interval, err := cmd.Flags().GetDuration("watch-interval")
+	if err != nil {
+		return nil, err
+	}
+	return &watch.Options{
+		Events:   events,
+		Interval: interval,
+	}, nil
+}
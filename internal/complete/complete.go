@@ -0,0 +1,124 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package complete
+
+import (
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/pkg/util"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// CacheTTL is the time during which names fetched from the Controller are reused by subsequent completions
+const CacheTTL = 10 * time.Second
+
+type lister func(namespace string) ([]string, error)
+
+// Listers per resource kind. Namespaces, Controllers, Agents, detached Agents and Volumes are read from the config files,
+// other resources are fetched from the Controller and cached.
+var listers = map[string]lister{
+	"namespace":            listNamespaces,
+	"controller":           listControllers,
+	"agent":                listAgents,
+	"detached-agent":       listDetachedAgents,
+	"volume":               listVolumes,
+	"application":          cached("application", listApplications),
+	"microservice":         cached("microservice", listMicroservices),
+	"application-template": cached("application-template", listTemplates),
+	"certificate":          cached("certificate", listCertificates),
+	"configmap":            cached("configmap", listConfigMaps),
+	"edge-resource":        cached("edge-resource", listEdgeResources),
+	"role":                 cached("role", listRoles),
+	"rolebinding":          cached("rolebinding", listRoleBindings),
+	"secret":               cached("secret", listSecrets),
+	"service":              cached("service", listServices),
+	"volume-mount":         cached("volume-mount", listVolumeMounts),
+}
+
+// Names returns the sorted names of the resources of a kind starting with prefix.
+// Completion must not fail, so errors result in no names.
+func Names(kind, namespace, prefix string) []string {
+	list, found := listers[kind]
+	if !found {
+		return nil
+	}
+	names, err := list(namespace)
+	if err != nil {
+		return nil
+	}
+	return filter(names, prefix)
+}
+
+func filter(names []string, prefix string) []string {
+	matches := []string{}
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if !strings.HasPrefix(name, prefix) || seen[name] {
+			continue
+		}
+		seen[name] = true
+		matches = append(matches, name)
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+type cacheEntry struct {
+	Updated time.Time `yaml:"updated"`
+	Names   []string  `yaml:"names"`
+}
+
+// cached reuses the names listed within CacheTTL, each completion being a separate process
+func cached(kind string, list lister) lister {
+	return func(namespace string) ([]string, error) {
+		file := path.Join(config.GetCacheDir(namespace), "completion-"+kind+".yaml")
+		if names, found := readCache(file, time.Now()); found {
+			return names, nil
+		}
+		names, err := list(namespace)
+		if err != nil {
+			// Cache the failure as well so that an unreachable Controller does not slow down every completion
+			names = []string{}
+		}
+		writeCache(file, names, time.Now())
+		return names, err
+	}
+}
+
+func readCache(file string, now time.Time) ([]string, bool) {
+	entry := cacheEntry{}
+	if err := util.UnmarshalYAML(file, &entry); err != nil {
+		return nil, false
+	}
+	if now.Sub(entry.Updated) > CacheTTL || now.Before(entry.Updated) {
+		return nil, false
+	}
+	return entry.Names, true
+}
+
+func writeCache(file string, names []string, now time.Time) {
+	bytes, err := yaml.Marshal(cacheEntry{Updated: now, Names: names})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(path.Dir(file), 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(file, bytes, 0600)
+}
@@ -0,0 +1,47 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package complete
+
+import (
+	"path"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFilter(t *testing.T) {
+	names := []string{"edge-2", "cloud-1", "edge-1", "edge-2"}
+	if matches := filter(names, "edge"); !reflect.DeepEqual(matches, []string{"edge-1", "edge-2"}) {
+		t.Errorf("Unexpected matches %v", matches)
+	}
+	if matches := filter(names, "none"); len(matches) != 0 {
+		t.Errorf("Unexpected matches %v", matches)
+	}
+}
+
+func TestCache(t *testing.T) {
+	file := path.Join(t.TempDir(), "cache", "completion-secret.yaml")
+	now := time.Now()
+	if _, found := readCache(file, now); found {
+		t.Errorf("Found missing cache file")
+	}
+	writeCache(file, []string{"db-password"}, now)
+	names, found := readCache(file, now.Add(CacheTTL/2))
+	if !found || !reflect.DeepEqual(names, []string{"db-password"}) {
+		t.Errorf("Unexpected cached names %v", names)
+	}
+	if _, found := readCache(file, now.Add(2*CacheTTL)); found {
+		t.Errorf("Found expired cache entry")
+	}
+}
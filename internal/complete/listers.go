@@ -0,0 +1,226 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package complete
+
+import (
+	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
+	clientutil "github.com/datasance/potctl/internal/util/client"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+func listNamespaces(string) ([]string, error) {
+	return config.GetNamespaces(), nil
+}
+
+func listControllers(namespace string) (names []string, err error) {
+	ns, err := config.GetNamespace(namespace)
+	if err != nil {
+		return
+	}
+	for _, ctrl := range ns.GetControllers() {
+		names = append(names, ctrl.GetName())
+	}
+	return
+}
+
+func listAgents(namespace string) (names []string, err error) {
+	ns, err := config.GetNamespace(namespace)
+	if err != nil {
+		return
+	}
+	for _, agent := range ns.GetAgents() {
+		names = append(names, agent.GetName())
+	}
+	return
+}
+
+func listDetachedAgents(string) (names []string, err error) {
+	for _, agent := range config.GetDetachedAgents() {
+		names = append(names, agent.GetName())
+	}
+	return
+}
+
+func listVolumes(namespace string) (names []string, err error) {
+	ns, err := config.GetNamespace(namespace)
+	if err != nil {
+		return
+	}
+	for _, volume := range ns.GetVolumes() {
+		names = append(names, volume.Name)
+	}
+	return
+}
+
+// listFromController runs list against the Controller of the namespace
+func listFromController(namespace string, list func(*client.Client) ([]string, error)) (names []string, err error) {
+	err = clientutil.ExecuteWithAuthRetry(namespace, func(clt *client.Client) error {
+		var err error
+		names, err = list(clt)
+		return err
+	})
+	return
+}
+
+func listApplications(namespace string) ([]string, error) {
+	return listFromController(namespace, func(clt *client.Client) (names []string, err error) {
+		response, err := clt.GetAllApplications()
+		if err != nil {
+			return
+		}
+		for idx := range response.Applications {
+			if !response.Applications[idx].IsSystem {
+				names = append(names, response.Applications[idx].Name)
+			}
+		}
+		return
+	})
+}
+
+// listMicroservices returns the fully qualified names AppName/MsvcName of the Microservices
+func listMicroservices(namespace string) ([]string, error) {
+	return listFromController(namespace, func(clt *client.Client) (names []string, err error) {
+		response, err := clt.GetAllMicroservices()
+		if err != nil {
+			return
+		}
+		for idx := range response.Microservices {
+			msvc := &response.Microservices[idx]
+			if util.IsSystemMsvc(msvc) {
+				continue
+			}
+			names = append(names, msvc.Application+"/"+msvc.Name)
+		}
+		return
+	})
+}
+
+func listTemplates(namespace string) ([]string, error) {
+	return listFromController(namespace, func(clt *client.Client) (names []string, err error) {
+		response, err := clt.ListApplicationTemplates()
+		if err != nil {
+			return
+		}
+		for idx := range response.ApplicationTemplates {
+			names = append(names, response.ApplicationTemplates[idx].Name)
+		}
+		return
+	})
+}
+
+func listCertificates(namespace string) ([]string, error) {
+	return listFromController(namespace, func(clt *client.Client) (names []string, err error) {
+		response, err := clt.ListCertificates()
+		if err != nil {
+			return
+		}
+		for idx := range response.Certificates {
+			names = append(names, response.Certificates[idx].Name)
+		}
+		return
+	})
+}
+
+func listConfigMaps(namespace string) ([]string, error) {
+	return listFromController(namespace, func(clt *client.Client) (names []string, err error) {
+		response, err := clt.ListConfigMaps()
+		if err != nil {
+			return
+		}
+		for idx := range response.ConfigMaps {
+			names = append(names, response.ConfigMaps[idx].Name)
+		}
+		return
+	})
+}
+
+// listEdgeResources returns the names of the Edge Resources, once for all their versions
+func listEdgeResources(namespace string) ([]string, error) {
+	return listFromController(namespace, func(clt *client.Client) (names []string, err error) {
+		response, err := clt.ListEdgeResources()
+		if err != nil {
+			return
+		}
+		for idx := range response.EdgeResources {
+			names = append(names, response.EdgeResources[idx].Name)
+		}
+		return
+	})
+}
+
+func listRoles(namespace string) ([]string, error) {
+	return listFromController(namespace, func(clt *client.Client) (names []string, err error) {
+		response, err := clt.ListRoles()
+		if err != nil {
+			return
+		}
+		for idx := range response.Roles {
+			names = append(names, response.Roles[idx].Name)
+		}
+		return
+	})
+}
+
+func listRoleBindings(namespace string) ([]string, error) {
+	return listFromController(namespace, func(clt *client.Client) (names []string, err error) {
+		response, err := clt.ListRoleBindings()
+		if err != nil {
+			return
+		}
+		for idx := range response.Bindings {
+			names = append(names, response.Bindings[idx].Name)
+		}
+		return
+	})
+}
+
+func listSecrets(namespace string) ([]string, error) {
+	return listFromController(namespace, func(clt *client.Client) (names []string, err error) {
+		response, err := clt.ListSecrets()
+		if err != nil {
+			return
+		}
+		for idx := range response.Secrets {
+			names = append(names, response.Secrets[idx].Name)
+		}
+		return
+	})
+}
+
+func listServices(namespace string) ([]string, error) {
+	return listFromController(namespace, func(clt *client.Client) (names []string, err error) {
+		response, err := clt.ListServices()
+		if err != nil {
+			return
+		}
+		for idx := range response.Services {
+			names = append(names, response.Services[idx].Name)
+		}
+		return
+	})
+}
+
+func listVolumeMounts(namespace string) ([]string, error) {
+	return listFromController(namespace, func(clt *client.Client) (names []string, err error) {
+		response, err := clt.ListVolumeMounts()
+		if err != nil {
+			return
+		}
+		for idx := range response.VolumeMounts {
+			names = append(names, response.VolumeMounts[idx].Name)
+		}
+		return
+	})
+}
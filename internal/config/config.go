@@ -18,8 +18,12 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"reflect"
+	"runtime"
 	"strings"
+	"sync"
 
+	"github.com/datasance/potctl/internal/config/secretstore"
 	rsc "github.com/datasance/potctl/internal/resource"
 	"github.com/datasance/potctl/pkg/util"
 	homedir "github.com/mitchellh/go-homedir"
@@ -32,6 +36,13 @@ var (
 	configFilename     string // config file name
 	namespaceDirectory string // Path of namespace directory
 	namespaces         map[string]*rsc.Namespace
+	secretStore        secretstore.Store            // nil when secrets are kept in namespace files
+	namespaceSecrets   map[string]map[string]string // Secrets of the store loaded per namespace
+	// Revision and fields of each namespace file when it was loaded or last written, to merge the changes of other
+	// potctl processes
+	namespaceRevisions map[string]int
+	namespaceSnapshots map[string]map[interface{}]interface{}
+	flushMux           sync.Mutex
 )
 
 const (
@@ -42,7 +53,12 @@ const (
 	namespaceDirname     = "namespaces/"
 	offlineImagesDirname = "offline-images"
 	airgapImagesDirname  = "airgap-images"
+	cacheDirname         = "cache"
+	locksDirname         = "locks"
 	defaultFilename      = "config.yaml"
+	knownHostsFilename   = "known_hosts"
+	vaultFilename        = "secrets.vault"
+	configFileMode       = 0600
 	configV3             = "potctl/v3"
 	CurrentConfigVersion = configV3
 	detachedNamespace    = "_detached"
@@ -51,6 +67,9 @@ const (
 // Init initializes config, namespace and unmarshalls the files
 func Init(configFolderArg string) {
 	namespaces = make(map[string]*rsc.Namespace)
+	namespaceSecrets = make(map[string]map[string]string)
+	namespaceRevisions = make(map[string]int)
+	namespaceSnapshots = make(map[string]map[interface{}]interface{})
 
 	var err error
 	configFolder, err = util.FormatPath(configFolderArg)
@@ -73,6 +92,7 @@ func Init(configFolderArg string) {
 	filename := path.Join(configFolder, defaultFilename)
 	configFilename = filename
 	namespaceDirectory = path.Join(configFolder, namespaceDirname)
+	util.SetKnownHostsFile(path.Join(configFolder, knownHostsFilename))
 
 	// Check config file already exists
 	if _, err := os.Stat(configFilename); os.IsNotExist(err) {
@@ -93,6 +113,12 @@ func Init(configFolderArg string) {
 	conf, err = getConfigFromHeader(&confHeader)
 	util.Check(err)
 
+	secretStore, err = secretstore.New(conf.SecretStore, getVaultFile())
+	util.Check(err)
+
+	err = os.MkdirAll(path.Join(configFolder, locksDirname), 0755)
+	util.Check(err)
+
 	// Check namespace dir exists
 	initNamespaces := []string{"default", detachedNamespace}
 	flush := false
@@ -113,6 +139,36 @@ func Init(configFolderArg string) {
 		err = flushNamespaces()
 		util.Check(err)
 	}
+	util.Check(restrictNamespaceFiles())
+}
+
+// restrictNamespaceFiles makes the namespace files only readable by their owner, previous versions wrote them
+// readable by all users and they are only rewritten when their namespace changes
+func restrictNamespaceFiles() error {
+	// Windows file modes don't restrict other users
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	files, err := os.ReadDir(namespaceDirectory)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".yaml") {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			return err
+		}
+		if info.Mode().Perm()&^configFileMode == 0 {
+			continue
+		}
+		if err := os.Chmod(path.Join(namespaceDirectory, file.Name()), configFileMode); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // getNamespaceFile helper function that returns the full path to a namespace file
@@ -120,16 +176,24 @@ func getNamespaceFile(name string) string {
 	return path.Join(namespaceDirectory, name+".yaml")
 }
 
-func getConfigFromHeader(header *potctlConfig) (conf configuration, err error) {
-	switch header.APIVersion {
-	case CurrentConfigVersion:
-		// All good
-		break
-		// Example for further maintenance
-		// case PreviousConfigVersion
-		// 	updateFromPreviousVersion()
-		// 	break
+func getVaultFile() string {
+	return path.Join(configFolder, vaultFilename)
+}
 
+// getNamespaceLockFile returns the path of the file locked while writing a namespace file
+func getNamespaceLockFile(name string) string {
+	return path.Join(configFolder, locksDirname, name+".lock")
+}
+
+// writeConfigFile atomically replaces a config file, readable by the user only
+func writeConfigFile(filename string, data []byte) error {
+	return util.WriteFileAtomic(filename, data, configFileMode)
+}
+
+func getConfigFromHeader(header *potctlConfig) (conf configuration, err error) {
+	// Upgrade files of previous versions, they are written in the current version on next flush
+	if _, err = migrateHeader(&header.Header); err != nil {
+		return
 	}
 	bytes, err := yaml.Marshal(header.Spec)
 	if err != nil {
@@ -142,15 +206,17 @@ func getConfigFromHeader(header *potctlConfig) (conf configuration, err error) {
 }
 
 func getNamespaceFromHeader(header *potctlNamespace) (ns *rsc.Namespace, err error) {
-	// Check header not supported
-	switch header.APIVersion {
-	case CurrentConfigVersion:
-		// All good
-		break
-
+	// Upgrade files of previous versions, they are written in the current version on next flush
+	if _, err = migrateHeader(&header.Header); err != nil {
+		return
+	}
+	// Replace references with the secrets of the store
+	spec, secrets, err := secretstore.Resolve(header.Spec, secretStore)
+	if err != nil {
+		return
 	}
 	// Unmarshal Namespace spec
-	bytes, err := yaml.Marshal(header.Spec)
+	bytes, err := yaml.Marshal(spec)
 	if err != nil {
 		return
 	}
@@ -158,6 +224,23 @@ func getNamespaceFromHeader(header *potctlNamespace) (ns *rsc.Namespace, err err
 	if err = yaml.Unmarshal(bytes, &ns); err != nil {
 		return
 	}
+	snapshot, err := getNamespaceSpec(ns)
+	if err != nil {
+		return
+	}
+	namespaceSecrets[ns.Name] = secrets
+	namespaceRevisions[ns.Name] = header.Revision
+	namespaceSnapshots[ns.Name] = snapshot
+	return
+}
+
+// getNamespaceSpec returns the fields of a namespace as a generic YAML document
+func getNamespaceSpec(ns interface{}) (spec map[interface{}]interface{}, err error) {
+	bytes, err := yaml.Marshal(ns)
+	if err != nil {
+		return
+	}
+	err = yaml.Unmarshal(bytes, &spec)
 	return
 }
 
@@ -173,7 +256,9 @@ func getConfigYAMLFile(conf configuration) ([]byte, error) {
 	return yaml.Marshal(confHeader)
 }
 
-func getNamespaceYAMLFile(ns *rsc.Namespace) ([]byte, error) {
+// getNamespaceYAMLFile returns the namespace file, with the sensitive values replaced by references when a
+// secret store is configured, and the secrets referenced
+func getNamespaceYAMLFile(ns *rsc.Namespace, revision int) ([]byte, map[string]string, error) {
 	namespaceHeader := potctlNamespace{
 		Header{
 			Kind:       potctlNamespaceKind,
@@ -183,20 +268,119 @@ func getNamespaceYAMLFile(ns *rsc.Namespace) ([]byte, error) {
 			},
 			Spec: ns,
 		},
+		revision,
 	}
-	return yaml.Marshal(namespaceHeader)
+	bytes, err := yaml.Marshal(namespaceHeader)
+	if err != nil || secretStore == nil {
+		return bytes, nil, err
+	}
+	// Keep the order of the fields
+	doc := yaml.MapSlice{}
+	if err = yaml.Unmarshal(bytes, &doc); err != nil {
+		return nil, nil, err
+	}
+	extracted, secrets := secretstore.Extract(doc, ns.Name)
+	bytes, err = yaml.Marshal(extracted)
+	return bytes, secrets, err
 }
 
-func flushNamespaces() error {
-	for _, ns := range namespaces {
-		// Marshal the runtime data
-		marshal, err := getNamespaceYAMLFile(ns)
-		if err != nil {
+// writeNamespaceFile stores the secrets of the namespace and overwrites its file if the namespace changed since
+// it was loaded. The namespace file is locked meanwhile, and the changes made by other potctl processes since it
+// was loaded are merged first.
+func writeNamespaceFile(ns *rsc.Namespace) error {
+	flushMux.Lock()
+	defer flushMux.Unlock()
+	unlock, err := util.LockFile(getNamespaceLockFile(ns.Name))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	changed, err := mergeNamespaceFile(ns)
+	if err != nil || !changed {
+		return err
+	}
+	revision := namespaceRevisions[ns.Name] + 1
+
+	// Marshal the runtime data
+	marshal, secrets, err := getNamespaceYAMLFile(ns, revision)
+	if err != nil {
+		return err
+	}
+	snapshot, err := getNamespaceSpec(ns)
+	if err != nil {
+		return err
+	}
+	if secretStore != nil {
+		if err := secretstore.Sync(secretStore, namespaceSecrets[ns.Name], secrets); err != nil {
 			return err
 		}
-		// Overwrite the file
-		err = os.WriteFile(getNamespaceFile(ns.Name), marshal, 0644)
-		if err != nil {
+		namespaceSecrets[ns.Name] = secrets
+	}
+	// Overwrite the file
+	if err := writeConfigFile(getNamespaceFile(ns.Name), marshal); err != nil {
+		return err
+	}
+	namespaceRevisions[ns.Name] = revision
+	namespaceSnapshots[ns.Name] = snapshot
+	return nil
+}
+
+// mergeNamespaceFile returns whether the namespace changed since it was loaded. If another potctl process
+// wrote the namespace file meanwhile, the fields it changed are merged into the namespace, failing if both
+// changed the same field.
+func mergeNamespaceFile(ns *rsc.Namespace) (changed bool, err error) {
+	current, err := getNamespaceSpec(ns)
+	if err != nil {
+		return
+	}
+	snapshot, loaded := namespaceSnapshots[ns.Name]
+	if loaded && reflect.DeepEqual(snapshot, current) {
+		return false, nil
+	}
+
+	header := potctlNamespace{}
+	if err = util.UnmarshalYAML(getNamespaceFile(ns.Name), &header); err != nil {
+		if os.IsNotExist(err) {
+			return true, nil
+		}
+		return
+	}
+	if header.Revision == namespaceRevisions[ns.Name] {
+		return true, nil
+	}
+	conflictMsg := fmt.Sprintf("Namespace %s was modified by another potctl process while this one was running", ns.Name)
+	if !loaded {
+		return false, util.NewError(conflictMsg + ". Run the command again")
+	}
+
+	spec, _, err := secretstore.Resolve(header.Spec, secretStore)
+	if err != nil {
+		return
+	}
+	theirs, err := getNamespaceSpec(spec)
+	if err != nil {
+		return
+	}
+	merged, conflicts := mergeSpecs(snapshot, current, theirs)
+	if len(conflicts) > 0 {
+		return false, util.NewError(fmt.Sprintf("%s, both changed %s. Run the command again", conflictMsg, strings.Join(conflicts, ", ")))
+	}
+	bytes, err := yaml.Marshal(merged)
+	if err != nil {
+		return
+	}
+	*ns = rsc.Namespace{}
+	if err = yaml.Unmarshal(bytes, ns); err != nil {
+		return
+	}
+	namespaceRevisions[ns.Name] = header.Revision
+	return true, nil
+}
+
+func flushNamespaces() error {
+	for _, ns := range namespaces {
+		if err := writeNamespaceFile(ns); err != nil {
 			return err
 		}
 	}
@@ -210,7 +394,7 @@ func flushShared() error {
 		return nil
 	}
 	// Overwrite the file
-	err = os.WriteFile(configFilename, marshal, 0644)
+	err = writeConfigFile(configFilename, marshal)
 	if err != nil {
 		return nil
 	}
@@ -227,6 +411,11 @@ func GetOfflineImageNamespaceDir(namespace string) string {
 	return path.Join(configFolder, offlineImagesDirname, namespace)
 }
 
+// GetCacheDir returns the directory path used to cache Controller responses for a namespace.
+func GetCacheDir(namespace string) string {
+	return path.Join(configFolder, cacheDirname, namespace)
+}
+
 // GetOfflineImageCacheDir returns the directory path for a specific OfflineImage resource and platform.
 func GetOfflineImageCacheDir(namespace, resourceName, platform string) string {
 	pathElems := []string{configFolder, offlineImagesDirname, namespace}
@@ -0,0 +1,52 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package config
+
+import (
+	"os"
+	"path"
+	"runtime"
+	"testing"
+)
+
+func TestRestrictNamespaceFiles(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows file modes don't restrict other users")
+	}
+	defer func(dir string) { namespaceDirectory = dir }(namespaceDirectory)
+	namespaceDirectory = t.TempDir()
+
+	// Namespace files written by previous versions
+	for _, name := range []string{"default.yaml", "edge.yaml"} {
+		if err := os.WriteFile(path.Join(namespaceDirectory, name), []byte("kind: Namespace\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		// WriteFile is subject to the umask
+		if err := os.Chmod(path.Join(namespaceDirectory, name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := restrictNamespaceFiles(); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"default.yaml", "edge.yaml"} {
+		info, err := os.Stat(path.Join(namespaceDirectory, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != configFileMode {
+			t.Errorf("Unexpected mode %v of %s", info.Mode().Perm(), name)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Lists of resources merged by name, so that processes changing different resources of a list don't conflict
+var namedLists = map[string]bool{
+	"localAgents":  true,
+	"remoteAgents": true,
+	"volumes":      true,
+}
+
+// mergeSpecs merges the fields of a namespace changed by this process since it was loaded with the fields
+// changed by another process, and returns the fields both changed differently
+func mergeSpecs(base, ours, theirs map[interface{}]interface{}) (merged map[interface{}]interface{}, conflicts []string) {
+	merged = make(map[interface{}]interface{})
+	keys := make(map[interface{}]bool)
+	for _, spec := range []map[interface{}]interface{}{base, ours, theirs} {
+		for key := range spec {
+			keys[key] = true
+		}
+	}
+	for key := range keys {
+		name := fmt.Sprint(key)
+		if namedLists[name] {
+			list, listConflicts, ok := mergeNamedLists(base[key], ours[key], theirs[key])
+			if ok {
+				for _, conflict := range listConflicts {
+					conflicts = append(conflicts, fmt.Sprintf("%s[%s]", name, conflict))
+				}
+				if list != nil {
+					merged[key] = list
+				}
+				continue
+			}
+		}
+		value, ok := mergeValues(base[key], ours[key], theirs[key])
+		if !ok {
+			conflicts = append(conflicts, name)
+			continue
+		}
+		if value != nil {
+			merged[key] = value
+		}
+	}
+	sort.Strings(conflicts)
+	return merged, conflicts
+}
+
+// mergeValues returns the value changed by either side, false if both changed it differently
+func mergeValues(base, ours, theirs interface{}) (interface{}, bool) {
+	switch {
+	case reflect.DeepEqual(ours, theirs), reflect.DeepEqual(theirs, base):
+		return ours, true
+	case reflect.DeepEqual(ours, base):
+		return theirs, true
+	}
+	return nil, false
+}
+
+// mergeNamedLists merges lists of resources by name, and returns the names of the resources both sides changed
+// differently. Resources keep the order of our list, those only added by the other side come last. False if the
+// values are not lists of named resources.
+func mergeNamedLists(base, ours, theirs interface{}) (merged []interface{}, conflicts []string, ok bool) {
+	baseItems, baseOK := namedItems(base)
+	ourItems, oursOK := namedItems(ours)
+	theirItems, theirsOK := namedItems(theirs)
+	if !baseOK || !oursOK || !theirsOK {
+		return nil, nil, false
+	}
+
+	names := []string{}
+	seen := make(map[string]bool)
+	for _, list := range []interface{}{ours, theirs, base} {
+		items, _ := list.([]interface{})
+		for _, item := range items {
+			name := itemName(item)
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	for _, name := range names {
+		value, ok := mergeValues(baseItems[name], ourItems[name], theirItems[name])
+		if !ok {
+			conflicts = append(conflicts, name)
+			continue
+		}
+		if value != nil {
+			merged = append(merged, value)
+		}
+	}
+	return merged, conflicts, true
+}
+
+// namedItems indexes a list of resources by name, false if an item has no name
+func namedItems(value interface{}) (map[string]interface{}, bool) {
+	items := make(map[string]interface{})
+	if value == nil {
+		return items, true
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	for _, item := range list {
+		name := itemName(item)
+		if name == "" {
+			return nil, false
+		}
+		items[name] = item
+	}
+	return items, true
+}
+
+func itemName(item interface{}) string {
+	fields, ok := item.(map[interface{}]interface{})
+	if !ok {
+		return ""
+	}
+	name, _ := fields["name"].(string)
+	return name
+}
@@ -0,0 +1,90 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeSpecs(t *testing.T) {
+	base := map[interface{}]interface{}{
+		"name":         "default",
+		"remoteAgents": []interface{}{"agent-1"},
+		"volumes":      []interface{}{"volume-1"},
+	}
+	ours := map[interface{}]interface{}{
+		"name":         "default",
+		"remoteAgents": []interface{}{"agent-1", "agent-2"},
+		"volumes":      []interface{}{"volume-1"},
+	}
+	theirs := map[interface{}]interface{}{
+		"name":         "default",
+		"remoteAgents": []interface{}{"agent-1"},
+		"applySets":    []interface{}{"edge"},
+	}
+	merged, conflicts := mergeSpecs(base, ours, theirs)
+	expected := map[interface{}]interface{}{
+		"name":         "default",
+		"remoteAgents": []interface{}{"agent-1", "agent-2"},
+		"applySets":    []interface{}{"edge"},
+	}
+	if len(conflicts) != 0 || !reflect.DeepEqual(merged, expected) {
+		t.Errorf("Unexpected merge %v, conflicts %v", merged, conflicts)
+	}
+
+	theirs["remoteAgents"] = []interface{}{"agent-3"}
+	if _, conflicts := mergeSpecs(base, ours, theirs); !reflect.DeepEqual(conflicts, []string{"remoteAgents"}) {
+		t.Errorf("Unexpected conflicts %v", conflicts)
+	}
+}
+
+func agentSpec(name, host string) map[interface{}]interface{} {
+	return map[interface{}]interface{}{"name": name, "host": host}
+}
+
+func TestMergeNamedLists(t *testing.T) {
+	base := map[interface{}]interface{}{
+		"remoteAgents": []interface{}{agentSpec("agent-1", "10.0.0.1"), agentSpec("agent-2", "10.0.0.2")},
+		"volumes":      []interface{}{map[interface{}]interface{}{"name": "volume-1"}},
+	}
+	// We deploy agent-3 and move agent-1, they delete agent-2, deploy agent-4 and delete the only volume
+	ours := map[interface{}]interface{}{
+		"remoteAgents": []interface{}{
+			agentSpec("agent-1", "10.0.1.1"), agentSpec("agent-2", "10.0.0.2"), agentSpec("agent-3", "10.0.0.3"),
+		},
+		"volumes": []interface{}{map[interface{}]interface{}{"name": "volume-1"}},
+	}
+	theirs := map[interface{}]interface{}{
+		"remoteAgents": []interface{}{agentSpec("agent-1", "10.0.0.1"), agentSpec("agent-4", "10.0.0.4")},
+		"localAgents":  []interface{}{agentSpec("local", "localhost")},
+	}
+	merged, conflicts := mergeSpecs(base, ours, theirs)
+	expected := map[interface{}]interface{}{
+		"remoteAgents": []interface{}{
+			agentSpec("agent-1", "10.0.1.1"), agentSpec("agent-3", "10.0.0.3"), agentSpec("agent-4", "10.0.0.4"),
+		},
+		"localAgents": []interface{}{agentSpec("local", "localhost")},
+	}
+	if len(conflicts) != 0 || !reflect.DeepEqual(merged, expected) {
+		t.Errorf("Unexpected merge %v, conflicts %v", merged, conflicts)
+	}
+
+	// Both changing the same agent conflict, and so do a change and a deletion
+	theirs["remoteAgents"] = []interface{}{agentSpec("agent-1", "10.0.2.1"), agentSpec("agent-2", "10.0.2.2")}
+	ours["remoteAgents"] = []interface{}{agentSpec("agent-1", "10.0.1.1")}
+	if _, conflicts := mergeSpecs(base, ours, theirs); !reflect.DeepEqual(conflicts, []string{"remoteAgents[agent-1]", "remoteAgents[agent-2]"}) {
+		t.Errorf("Unexpected conflicts %v", conflicts)
+	}
+}
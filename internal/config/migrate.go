@@ -0,0 +1,219 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/datasance/potctl/pkg/util"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Config versions of the CLIs potctl derives from
+const (
+	configIofogctlV2 = "iofogctl/v2"
+	configIofogctlV3 = "iofogctl/v3"
+	backupsDirname   = "backups"
+)
+
+// migration upgrades config and namespace files from one version to the next
+type migration struct {
+	from      string
+	to        string
+	namespace func(spec map[interface{}]interface{}) error // Nil if only the version changes
+}
+
+// migrations are applied in order, each one to the files of its version
+var migrations = []migration{
+	{
+		from:      configIofogctlV2,
+		to:        configIofogctlV3,
+		namespace: migrateNamespaceV2,
+	},
+	{
+		from: configIofogctlV3,
+		to:   configV3,
+	},
+}
+
+// migrateNamespaceV2 renames the fields of iofogctl v2 namespaces
+func migrateNamespaceV2(spec map[interface{}]interface{}) error {
+	renames := map[string]string{
+		"kubernetesControlPlane": "k8sControlPlane",
+	}
+	for from, to := range renames {
+		value, found := spec[from]
+		if !found {
+			continue
+		}
+		if _, exists := spec[to]; exists {
+			return util.NewInputError(fmt.Sprintf("Namespace has both %s and %s", from, to))
+		}
+		spec[to] = value
+		delete(spec, from)
+	}
+	return nil
+}
+
+// migrateHeader upgrades a config or namespace header to the current version, and returns whether it changed
+func migrateHeader(header *Header) (migrated bool, err error) {
+	for header.APIVersion != CurrentConfigVersion {
+		idx := findMigration(header.APIVersion)
+		if idx < 0 {
+			return migrated, util.NewInputError(fmt.Sprintf("Unsupported config version %s, supported versions are %s", header.APIVersion, strings.Join(GetConfigVersions(), ", ")))
+		}
+		if migrations[idx].namespace != nil && header.Kind == potctlNamespaceKind && header.Spec != nil {
+			spec, ok := header.Spec.(map[interface{}]interface{})
+			if !ok {
+				return migrated, util.NewInputError(fmt.Sprintf("Invalid spec of %s %s", header.Kind, header.Metadata.Name))
+			}
+			if err = migrations[idx].namespace(spec); err != nil {
+				return
+			}
+		}
+		header.APIVersion = migrations[idx].to
+		migrated = true
+	}
+	return
+}
+
+func findMigration(version string) int {
+	for idx := range migrations {
+		if migrations[idx].from == version {
+			return idx
+		}
+	}
+	return -1
+}
+
+// GetConfigVersions returns the config versions potctl reads, the current one last
+func GetConfigVersions() (versions []string) {
+	for idx := range migrations {
+		versions = append(versions, migrations[idx].from)
+	}
+	return append(versions, CurrentConfigVersion)
+}
+
+// MigrateResult describes the files rewritten by Migrate
+type MigrateResult struct {
+	Migrated  []string // Namespaces upgraded in place
+	Imported  []string // Namespaces copied from the legacy directory
+	Skipped   []string // Namespaces of the legacy directory already present
+	BackupDir string   // Directory holding the files as they were before, empty if none was rewritten
+}
+
+// Migrate upgrades the namespace files of older versions in place, after copying them to a backup directory.
+// Namespaces of a legacy config directory, e.g. ~/.iofog/v2, are imported too if it is specified.
+func Migrate(legacyDir string) (result MigrateResult, err error) {
+	backupDir := path.Join(configFolder, backupsDirname, time.Now().UTC().Format("20060102T150405Z"))
+	backup := func(filename string) error {
+		if err := os.MkdirAll(backupDir, 0700); err != nil {
+			return err
+		}
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		result.BackupDir = backupDir
+		return os.WriteFile(path.Join(backupDir, path.Base(filename)), content, configFileMode)
+	}
+
+	// Upgrade the config file
+	confHeader := potctlConfig{}
+	if err = util.UnmarshalYAML(configFilename, &confHeader); err != nil {
+		return
+	}
+	if confHeader.APIVersion != CurrentConfigVersion {
+		if err = backup(configFilename); err != nil {
+			return
+		}
+		if err = flushShared(); err != nil {
+			return
+		}
+	}
+
+	// Upgrade the namespace files in place
+	for _, name := range append(GetNamespaces(), detachedNamespace) {
+		filename := getNamespaceFile(name)
+		var migrated bool
+		migrated, err = migrateNamespaceFile(filename, filename, func() error { return backup(filename) })
+		if err != nil {
+			return result, util.NewError(fmt.Sprintf("Failed to migrate namespace %s: %s", name, err.Error()))
+		}
+		if migrated {
+			result.Migrated = append(result.Migrated, name)
+		}
+	}
+
+	if legacyDir == "" {
+		return
+	}
+	// Import the namespaces of the legacy directory
+	legacyDir, err = util.FormatPath(legacyDir)
+	if err != nil {
+		return
+	}
+	files, err := os.ReadDir(path.Join(legacyDir, namespaceDirname))
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".yaml") {
+			continue
+		}
+		name := strings.TrimSuffix(file.Name(), ".yaml")
+		if _, err = os.Stat(getNamespaceFile(name)); err == nil {
+			result.Skipped = append(result.Skipped, name)
+			continue
+		}
+		if _, err = migrateNamespaceFile(path.Join(legacyDir, namespaceDirname, file.Name()), getNamespaceFile(name), nil); err != nil {
+			return result, util.NewError(fmt.Sprintf("Failed to import namespace %s: %s", name, err.Error()))
+		}
+		result.Imported = append(result.Imported, name)
+	}
+	return result, nil
+}
+
+// migrateNamespaceFile writes the upgraded namespace of a file to another, or in place once backed up
+func migrateNamespaceFile(from, to string, backup func() error) (bool, error) {
+	// Prevent other potctl processes from writing the namespace meanwhile
+	unlock, err := util.LockFile(getNamespaceLockFile(strings.TrimSuffix(path.Base(to), ".yaml")))
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	header := potctlNamespace{}
+	if err := util.UnmarshalYAML(from, &header); err != nil {
+		return false, err
+	}
+	migrated, err := migrateHeader(&header.Header)
+	if err != nil || (!migrated && from == to) {
+		return false, err
+	}
+	if backup != nil {
+		if err := backup(); err != nil {
+			return false, err
+		}
+	}
+	content, err := yaml.Marshal(header)
+	if err != nil {
+		return false, err
+	}
+	return migrated, writeConfigFile(to, content)
+}
@@ -0,0 +1,50 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMigrateHeader(t *testing.T) {
+	header := Header{
+		APIVersion: configIofogctlV2,
+		Kind:       potctlNamespaceKind,
+		Spec: map[interface{}]interface{}{
+			"name":                   "default",
+			"kubernetesControlPlane": map[interface{}]interface{}{"config": "~/.kube/config"},
+		},
+	}
+	migrated, err := migrateHeader(&header)
+	if err != nil || !migrated {
+		t.Fatalf("Failed to migrate header: %v", err)
+	}
+	expected := map[interface{}]interface{}{
+		"name":            "default",
+		"k8sControlPlane": map[interface{}]interface{}{"config": "~/.kube/config"},
+	}
+	if header.APIVersion != CurrentConfigVersion || !reflect.DeepEqual(header.Spec, expected) {
+		t.Errorf("Unexpected migrated header %v", header)
+	}
+
+	if migrated, err := migrateHeader(&header); err != nil || migrated {
+		t.Errorf("Migrated a header of the current version: %v", err)
+	}
+
+	header.APIVersion = "iofogctl/v1"
+	if _, err := migrateHeader(&header); err == nil {
+		t.Errorf("Migrated a header of an unsupported version")
+	}
+}
@@ -17,7 +17,9 @@ import (
 	"errors"
 	"os"
 	"sort"
+	"strings"
 
+	"github.com/datasance/potctl/internal/config/secretstore"
 	rsc "github.com/datasance/potctl/internal/resource"
 	"github.com/datasance/potctl/pkg/util"
 )
@@ -54,6 +56,10 @@ func GetNamespaces() (namespaces []string) {
 	})
 
 	for _, file := range files {
+		// Skip the temporary files of writes in progress
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") || !strings.HasSuffix(file.Name(), ".yaml") {
+			continue
+		}
 		name := util.Before(file.Name(), ".yaml")
 		if name != detachedNamespace {
 			namespaces = append(namespaces, name)
@@ -112,14 +118,7 @@ func AddNamespace(name, created string) error {
 	}
 
 	// Write namespace file
-	// Marshal the runtime data
-	marshal, err := getNamespaceYAMLFile(&newNamespace)
-	if err != nil {
-		return err
-	}
-	// Overwrite the file
-	err = os.WriteFile(getNamespaceFile(name), marshal, 0644)
-	if err != nil {
+	if err := writeNamespaceFile(&newNamespace); err != nil {
 		return err
 	}
 	namespaces[name] = &newNamespace
@@ -135,28 +134,18 @@ func UpdateUser(name, accessToken, refreshToken string) error {
 	}
 
 	if ns.KubernetesControlPlane != nil {
-		ns.KubernetesControlPlane.IofogUser.AccessToken = accessToken
-		ns.KubernetesControlPlane.IofogUser.RefreshToken = refreshToken
+		setUserTokens(&ns.KubernetesControlPlane.IofogUser, accessToken, refreshToken)
 	}
 	if ns.RemoteControlPlane != nil {
-		ns.RemoteControlPlane.IofogUser.AccessToken = accessToken
-		ns.RemoteControlPlane.IofogUser.RefreshToken = refreshToken
+		setUserTokens(&ns.RemoteControlPlane.IofogUser, accessToken, refreshToken)
 	}
 	if ns.LocalControlPlane != nil {
-		ns.LocalControlPlane.IofogUser.AccessToken = accessToken
-		ns.LocalControlPlane.IofogUser.RefreshToken = refreshToken
+		setUserTokens(&ns.LocalControlPlane.IofogUser, accessToken, refreshToken)
 	}
 
-	// Marshal the updated namespace into YAML
-	marshal, err := getNamespaceYAMLFile(ns)
-	if err != nil {
-		return err // Error in marshaling
-	}
-
-	// Write the updated YAML data back to the file
-	err = os.WriteFile(getNamespaceFile(name), marshal, 0644)
-	if err != nil {
-		return err // Error in writing to the file
+	// Write the updated namespace back to the file
+	if err := writeNamespaceFile(ns); err != nil {
+		return err // Error in marshaling or writing to the file
 	}
 
 	// Update the in-memory cache
@@ -165,6 +154,36 @@ func UpdateUser(name, accessToken, refreshToken string) error {
 	return nil
 }
 
+// setUserTokens updates the tokens of a user, users of potctl login only keep their refresh token
+func setUserTokens(user *rsc.IofogUser, accessToken, refreshToken string) {
+	user.AccessToken = accessToken
+	if user.Login != "" {
+		user.AccessToken = ""
+	}
+	user.RefreshToken = refreshToken
+}
+
+// SetUser replaces the user of the Control Plane of an existing namespace
+func SetUser(name string, user rsc.IofogUser) error {
+	ns, err := getNamespace(name)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case ns.KubernetesControlPlane != nil:
+		ns.KubernetesControlPlane.IofogUser = user
+	case ns.RemoteControlPlane != nil:
+		ns.RemoteControlPlane.IofogUser = user
+	case ns.LocalControlPlane != nil:
+		ns.LocalControlPlane.IofogUser = user
+	default:
+		return util.NewNotFoundError(name + " Control Plane")
+	}
+
+	return writeNamespaceFile(ns)
+}
+
 // // UpdateUser modifies the user data of an existing namespace
 // func UpdateSubscriptionKey(name, subscriptionKey string) error {
 // 	// Fetch the existing namespace
@@ -211,13 +230,25 @@ func DeleteNamespace(name string) error {
 		}
 	}
 
+	// Remove the secrets of the namespace from the store, loading them first
+	if secretStore != nil {
+		if _, err := getNamespace(name); err != nil {
+			return err
+		}
+		if err := secretstore.Sync(secretStore, namespaceSecrets[name], nil); err != nil {
+			return err
+		}
+	}
+
 	filename := getNamespaceFile(name)
 	if err := os.Remove(filename); err != nil {
 		msg := "could not delete namespace file " + filename
 		return util.NewNotFoundError(msg)
 	}
-
 	delete(namespaces, name)
+	delete(namespaceSecrets, name)
+	delete(namespaceRevisions, name)
+	delete(namespaceSnapshots, name)
 
 	return nil
 }
@@ -233,9 +264,72 @@ func RenameNamespace(name, newName string) error {
 	if err := os.Rename(getNamespaceFile(name), getNamespaceFile(newName)); err != nil {
 		return err
 	}
+	// Write the namespace under its new name
+	namespaceRevisions[newName] = namespaceRevisions[name]
+	delete(namespaceRevisions, name)
+	delete(namespaceSnapshots, name)
+	// Move the secrets of the namespace under its new name
+	if secretStore != nil {
+		previousSecrets := namespaceSecrets[name]
+		delete(namespaceSecrets, name)
+		if err := writeNamespaceFile(ns); err != nil {
+			return err
+		}
+		if err := secretstore.Sync(secretStore, previousSecrets, nil); err != nil {
+			return err
+		}
+	}
 	if name == conf.DefaultNamespace {
 		return SetDefaultNamespace(newName)
 	}
 
 	return nil
 }
+
+// GetSecretStore returns the backend keeping the sensitive values of namespaces
+func GetSecretStore() string {
+	if conf.SecretStore == "" {
+		return secretstore.BackendNone
+	}
+	return conf.SecretStore
+}
+
+// SetSecretStore moves the sensitive values of all namespaces to the store of a backend
+func SetSecretStore(backend string) error {
+	if backend == GetSecretStore() {
+		return nil
+	}
+	store, err := secretstore.New(backend, getVaultFile())
+	if err != nil {
+		return err
+	}
+	// Load all namespaces with the previous store
+	for _, name := range append(GetNamespaces(), detachedNamespace) {
+		if _, err := getNamespace(name); err != nil {
+			return err
+		}
+	}
+	previousStore, previousSecrets := secretStore, namespaceSecrets
+
+	// Write all namespaces with the new store
+	secretStore = store
+	namespaceSecrets = make(map[string]map[string]string)
+	namespaceSnapshots = make(map[string]map[interface{}]interface{})
+	if err := flushNamespaces(); err != nil {
+		return err
+	}
+	conf.SecretStore = backend
+	if err := flushShared(); err != nil {
+		return err
+	}
+
+	// Remove the secrets from the previous store
+	if previousStore != nil {
+		for _, secrets := range previousSecrets {
+			if err := secretstore.Sync(previousStore, secrets, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package secretstore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// Service under which the secrets are kept in the keyring
+const keyringService = "potctl"
+
+// keyring keeps secrets in the keyring of the OS through its command line tool, security on macOS and
+// secret-tool of libsecret on Linux
+type keyring struct {
+	goos string
+	run  func(stdin string, name string, args ...string) (string, error)
+}
+
+func newKeyring() (*keyring, error) {
+	tool := ""
+	switch runtime.GOOS {
+	case "darwin":
+		tool = "security"
+	case "linux":
+		tool = "secret-tool"
+	default:
+		return nil, util.NewInputError(fmt.Sprintf("The keyring secret store is not supported on %s", runtime.GOOS))
+	}
+	if _, err := exec.LookPath(tool); err != nil {
+		return nil, util.NewInputError(fmt.Sprintf("The keyring secret store requires %s: %s", tool, err.Error()))
+	}
+	return &keyring{goos: runtime.GOOS, run: runTool}, nil
+}
+
+func (k *keyring) Get(key string) (string, error) {
+	var out string
+	var err error
+	if k.goos == "darwin" {
+		out, err = k.run("", "security", "find-generic-password", "-s", keyringService, "-a", key, "-w")
+	} else {
+		out, err = k.run("", "secret-tool", "lookup", "service", keyringService, "key", key)
+	}
+	if err != nil || out == "" {
+		return "", util.NewNotFoundError(fmt.Sprintf("Secret %s in keyring", key))
+	}
+	return strings.TrimSuffix(out, "\n"), nil
+}
+
+func (k *keyring) Set(key, value string) (err error) {
+	if k.goos == "darwin" {
+		// Arguments of security are visible to other processes, the command is read from stdin instead
+		command := fmt.Sprintf("add-generic-password -U -s %s -a %q -X %s\n", keyringService, key, hex.EncodeToString([]byte(value)))
+		if _, err = k.run(command, "security", "-i"); err != nil {
+			return
+		}
+		// security -i does not fail when one of its commands does
+		if stored, getErr := k.Get(key); getErr != nil || stored != value {
+			return util.NewError(fmt.Sprintf("Failed to store secret %s in keyring", key))
+		}
+		return
+	}
+	_, err = k.run(value, "secret-tool", "store", "--label", keyringService+" "+key, "service", keyringService, "key", key)
+	return
+}
+
+func (k *keyring) Delete(key string) (err error) {
+	if k.goos == "darwin" {
+		_, err = k.run("", "security", "delete-generic-password", "-s", keyringService, "-a", key)
+	} else {
+		_, err = k.run("", "secret-tool", "clear", "service", keyringService, "key", key)
+	}
+	return
+}
+
+func runTool(stdin string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", util.NewError(fmt.Sprintf("%s failed: %s %s", name, err.Error(), strings.TrimSpace(stderr.String())))
+	}
+	return stdout.String(), nil
+}
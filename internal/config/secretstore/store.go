@@ -0,0 +1,221 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package secretstore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/datasance/potctl/pkg/util"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Backends of the secret store
+const (
+	// Sensitive values are kept in the namespace files
+	BackendNone = "none"
+	// Sensitive values are kept in a local file encrypted with a passphrase
+	BackendVault = "vault"
+	// Sensitive values are kept in the keyring of the OS
+	BackendKeyring = "keyring"
+)
+
+// RefPrefix starts the values of namespace files that reference a secret of the store
+const RefPrefix = "secret://"
+
+// Store keeps sensitive values outside of the namespace files
+type Store interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+// Backends returns the supported backends
+func Backends() []string {
+	return []string{BackendNone, BackendVault, BackendKeyring}
+}
+
+// New returns the store of a backend, nil for BackendNone. The vault file is only read once a secret is
+// accessed.
+func New(backend, vaultFile string) (Store, error) {
+	switch backend {
+	case "", BackendNone:
+		return nil, nil
+	case BackendVault:
+		return newVault(vaultFile), nil
+	case BackendKeyring:
+		return newKeyring()
+	}
+	return nil, util.NewInputError(fmt.Sprintf("Unsupported secret store %s, supported stores are %s", backend, strings.Join(Backends(), ", ")))
+}
+
+// Ref returns the reference stored in namespace files in place of the secret of a key
+func Ref(key string) string {
+	return RefPrefix + key
+}
+
+// ParseRef returns the key of a reference, found is false for plain values
+func ParseRef(value string) (key string, found bool) {
+	if !strings.HasPrefix(value, RefPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(value, RefPrefix), true
+}
+
+// Keys of sensitive values, at any depth of namespace files
+var sensitiveKeys = map[string]bool{
+	"password":         true,
+	"accessToken":      true,
+	"refreshToken":     true,
+	"controllerSecret": true,
+	"clientSecret":     true,
+	"accessKey":        true,
+	"subscriptionKey":  true,
+	"tlsKey":           true,
+	"privateKey":       true,
+	"token":            true,
+	"credentials":      true,
+}
+
+// IsSensitiveKey returns true if values of the key are secrets, which are kept out of namespace files and exports
+func IsSensitiveKey(key string) bool {
+	return sensitiveKeys[key]
+}
+
+// Extract replaces the sensitive values of a generic YAML document with references to keys under the prefix,
+// and returns the values by key. Empty values are kept in place.
+func Extract(doc interface{}, prefix string) (interface{}, map[string]string) {
+	secrets := make(map[string]string)
+	return extract(doc, prefix, secrets), secrets
+}
+
+func extract(value interface{}, path string, secrets map[string]string) interface{} {
+	switch typed := value.(type) {
+	case yaml.MapSlice:
+		extracted := make(yaml.MapSlice, len(typed))
+		for idx, item := range typed {
+			extracted[idx] = yaml.MapItem{Key: item.Key, Value: extractValue(item.Key, item.Value, path, secrets)}
+		}
+		return extracted
+	case map[interface{}]interface{}:
+		extracted := make(map[interface{}]interface{}, len(typed))
+		for key, child := range typed {
+			extracted[key] = extractValue(key, child, path, secrets)
+		}
+		return extracted
+	case []interface{}:
+		extracted := make([]interface{}, len(typed))
+		for idx := range typed {
+			extracted[idx] = extract(typed[idx], fmt.Sprintf("%s/%d", path, idx), secrets)
+		}
+		return extracted
+	}
+	return value
+}
+
+func extractValue(key, value interface{}, path string, secrets map[string]string) interface{} {
+	name := fmt.Sprint(key)
+	childPath := path + "/" + name
+	if secret, ok := value.(string); ok && IsSensitiveKey(name) && secret != "" {
+		if _, isRef := ParseRef(secret); !isRef {
+			secrets[childPath] = secret
+			return Ref(childPath)
+		}
+	}
+	return extract(value, childPath, secrets)
+}
+
+// Resolve replaces the references of a generic YAML document with the secrets of the store, and returns the
+// keys referenced
+func Resolve(doc interface{}, store Store) (interface{}, map[string]string, error) {
+	secrets := make(map[string]string)
+	resolved, err := resolve(doc, store, secrets)
+	return resolved, secrets, err
+}
+
+func resolve(value interface{}, store Store, secrets map[string]string) (interface{}, error) {
+	switch typed := value.(type) {
+	case string:
+		key, isRef := ParseRef(typed)
+		if !isRef {
+			return value, nil
+		}
+		if store == nil {
+			return nil, util.NewInputError(fmt.Sprintf("Secret %s is referenced but no secret store is configured", key))
+		}
+		secret, err := store.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		secrets[key] = secret
+		return secret, nil
+	case yaml.MapSlice:
+		resolved := make(yaml.MapSlice, len(typed))
+		for idx, item := range typed {
+			resolvedChild, err := resolve(item.Value, store, secrets)
+			if err != nil {
+				return nil, err
+			}
+			resolved[idx] = yaml.MapItem{Key: item.Key, Value: resolvedChild}
+		}
+		return resolved, nil
+	case map[interface{}]interface{}:
+		resolved := make(map[interface{}]interface{}, len(typed))
+		for key, child := range typed {
+			resolvedChild, err := resolve(child, store, secrets)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = resolvedChild
+		}
+		return resolved, nil
+	case []interface{}:
+		resolved := make([]interface{}, len(typed))
+		for idx := range typed {
+			resolvedChild, err := resolve(typed[idx], store, secrets)
+			if err != nil {
+				return nil, err
+			}
+			resolved[idx] = resolvedChild
+		}
+		return resolved, nil
+	}
+	return value, nil
+}
+
+// Sync stores the secrets that changed since they were loaded and deletes the secrets no longer referenced
+func Sync(store Store, loaded, secrets map[string]string) error {
+	keys := make([]string, 0, len(secrets))
+	for key := range secrets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if previous, found := loaded[key]; found && previous == secrets[key] {
+			continue
+		}
+		if err := store.Set(key, secrets[key]); err != nil {
+			return err
+		}
+	}
+	for key := range loaded {
+		if _, found := secrets[key]; !found {
+			if err := store.Delete(key); err != nil && !util.IsNotFoundError(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package secretstore
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+type memoryStore map[string]string
+
+func (store memoryStore) Get(key string) (string, error) { return store[key], nil }
+func (store memoryStore) Set(key, value string) error    { store[key] = value; return nil }
+func (store memoryStore) Delete(key string) error        { delete(store, key); return nil }
+
+func TestExtractResolve(t *testing.T) {
+	doc := map[interface{}]interface{}{
+		"name": "default",
+		"remoteControlPlane": map[interface{}]interface{}{
+			"iofogUser": map[interface{}]interface{}{"email": "user@domain.com", "password": "pass", "accessToken": ""},
+			"database":  map[interface{}]interface{}{"password": "db"},
+		},
+	}
+	extracted, secrets := Extract(doc, "default")
+	expected := map[string]string{
+		"default/remoteControlPlane/iofogUser/password": "pass",
+		"default/remoteControlPlane/database/password":  "db",
+	}
+	if !reflect.DeepEqual(secrets, expected) {
+		t.Errorf("Unexpected secrets %v", secrets)
+	}
+	user := extracted.(map[interface{}]interface{})["remoteControlPlane"].(map[interface{}]interface{})["iofogUser"].(map[interface{}]interface{})
+	if user["password"] != Ref("default/remoteControlPlane/iofogUser/password") || user["accessToken"] != "" {
+		t.Errorf("Unexpected extracted user %v", user)
+	}
+
+	store := memoryStore{}
+	if err := Sync(store, map[string]string{"default/stale": "old"}, secrets); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(map[string]string(store), expected) {
+		t.Errorf("Unexpected store %v", store)
+	}
+	resolved, loaded, err := Resolve(extracted, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resolved, doc) || !reflect.DeepEqual(loaded, expected) {
+		t.Errorf("Unexpected resolved document %v", resolved)
+	}
+	ordered := yaml.MapSlice{{Key: "name", Value: "default"}, {Key: "refreshToken", Value: "token"}}
+	extractedOrdered, orderedSecrets := Extract(ordered, "default")
+	if extractedOrdered.(yaml.MapSlice)[1].Value != Ref("default/refreshToken") || orderedSecrets["default/refreshToken"] != "token" {
+		t.Errorf("Unexpected extracted document %v", extractedOrdered)
+	}
+
+	if _, _, err := Resolve(extracted, nil); err == nil {
+		t.Errorf("Resolved references without store")
+	}
+}
+
+func TestExtractResolveNamespace(t *testing.T) {
+	file := `name: default
+remoteControlPlane:
+  iofogUser:
+    email: user@domain.com
+    password: cGFzcw==
+    subscriptionKey: subscription
+    accessToken: access
+    refreshToken: refresh
+  controllers:
+  - name: ctrl
+    host: 10.0.0.1
+    https:
+      caCert: Y2E=
+      tlsCert: Y2VydA==
+      tlsKey: a2V5
+    siteCA:
+      tlsCert: c2l0ZQ==
+      tlsKey: c2l0ZWtleQ==
+  vault:
+    provider: hashicorp
+    hashicorp:
+      address: https://vault:8200
+      token: hvs.token
+`
+	doc := yaml.MapSlice{}
+	if err := yaml.Unmarshal([]byte(file), &doc); err != nil {
+		t.Fatal(err)
+	}
+	extracted, secrets := Extract(doc, "default")
+	out, err := yaml.Marshal(extracted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"cGFzcw==", "subscription", "access", "refresh", "a2V5", "c2l0ZWtleQ==", "hvs.token"} {
+		if strings.Contains(string(out), ": "+secret+"\n") {
+			t.Errorf("Expected %s to be extracted from the namespace file:\n%s", secret, out)
+		}
+	}
+	if len(secrets) != 7 {
+		t.Errorf("Unexpected secrets %v", secrets)
+	}
+	if !strings.Contains(string(out), "tlsCert: Y2VydA==") {
+		t.Errorf("Expected certificates to stay in the namespace file:\n%s", out)
+	}
+
+	// Reading the file back restores the namespace
+	reread := yaml.MapSlice{}
+	if err := yaml.Unmarshal(out, &reread); err != nil {
+		t.Fatal(err)
+	}
+	store := memoryStore{}
+	if err := Sync(store, nil, secrets); err != nil {
+		t.Fatal(err)
+	}
+	resolved, _, err := Resolve(reread, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolvedOut, err := yaml.Marshal(resolved); err != nil || string(resolvedOut) != file {
+		t.Errorf("Unexpected resolved namespace file:\n%s", resolvedOut)
+	}
+
+	for key := range sensitiveKeys {
+		if _, secrets := Extract(map[interface{}]interface{}{key: "value"}, "default"); len(secrets) != 1 {
+			t.Errorf("Expected %s to be extracted", key)
+		}
+	}
+}
+
+func TestKeyringSetDarwin(t *testing.T) {
+	stored := map[string]string{}
+	k := &keyring{goos: "darwin", run: func(stdin string, name string, args ...string) (string, error) {
+		if strings.Contains(strings.Join(args, " "), "secret") {
+			t.Errorf("Secret passed in the arguments of %s %v", name, args)
+		}
+		switch {
+		case len(args) == 1 && args[0] == "-i":
+			fields := strings.Fields(stdin)
+			value, err := hex.DecodeString(fields[len(fields)-1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			stored[strings.Trim(fields[len(fields)-3], `"`)] = string(value)
+		case args[0] == "find-generic-password":
+			return stored[args[4]] + "\n", nil
+		}
+		return "", nil
+	}}
+	if err := k.Set("default/password", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := k.Get("default/password"); err != nil || value != "secret" {
+		t.Errorf("Unexpected secret %s: %v", value, err)
+	}
+}
+
+func TestVault(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "vault")
+	passphrase := func() ([]byte, error) { return []byte("passphrase"), nil }
+	v := &vault{file: file, passphrase: passphrase}
+	if err := v.Set("default/password", "secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened := &vault{file: file, passphrase: passphrase}
+	if secret, err := reopened.Get("default/password"); err != nil || secret != "secret" {
+		t.Errorf("Unexpected secret %s: %v", secret, err)
+	}
+	if err := reopened.Delete("default/password"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reopened.Get("default/password"); err == nil {
+		t.Errorf("Got deleted secret")
+	}
+
+	locked := &vault{file: file, passphrase: func() ([]byte, error) { return []byte("wrong"), nil }}
+	if _, err := locked.Get("default/password"); err == nil {
+		t.Errorf("Unlocked vault with the wrong passphrase")
+	}
+}
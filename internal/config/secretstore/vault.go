@@ -0,0 +1,241 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package secretstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/datasance/potctl/pkg/util"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+// VaultPassphraseEnv is the environment variable holding the passphrase of the vault
+const VaultPassphraseEnv = "POTCTL_VAULT_PASSPHRASE"
+
+const (
+	vaultVersion = 1
+	saltSize     = 16
+	keySize      = 32
+	// scrypt parameters recommended for interactive logins
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// vaultFile is the encrypted content of the vault file
+type vaultFile struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// vault keeps secrets in a single file encrypted with AES-256-GCM, with a key derived from a passphrase with scrypt
+type vault struct {
+	file       string
+	passphrase func() ([]byte, error)
+	mux        sync.Mutex
+	unlocked   []byte // Passphrase, read once
+	loaded     bool
+	salt       []byte
+	key        []byte
+	secrets    map[string]string
+}
+
+func newVault(file string) *vault {
+	return &vault{
+		file:       file,
+		passphrase: getVaultPassphrase,
+	}
+}
+
+func (v *vault) Get(key string) (string, error) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	if err := v.load(); err != nil {
+		return "", err
+	}
+	secret, found := v.secrets[key]
+	if !found {
+		return "", util.NewNotFoundError(fmt.Sprintf("Secret %s in vault %s", key, v.file))
+	}
+	return secret, nil
+}
+
+func (v *vault) Set(key, value string) error {
+	return v.update(func() error {
+		v.secrets[key] = value
+		return nil
+	})
+}
+
+func (v *vault) Delete(key string) error {
+	return v.update(func() error {
+		if _, found := v.secrets[key]; !found {
+			return util.NewNotFoundError(fmt.Sprintf("Secret %s in vault %s", key, v.file))
+		}
+		delete(v.secrets, key)
+		return nil
+	})
+}
+
+// update reloads the vault file while it is locked against other potctl processes, so that their changes are
+// kept, and saves the vault once modified
+func (v *vault) update(modify func() error) error {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	unlock, err := util.LockFile(v.file + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	v.loaded = false
+	if err := v.load(); err != nil {
+		return err
+	}
+	if err := modify(); err != nil {
+		return err
+	}
+	return v.save()
+}
+
+// load decrypts the vault file once, a missing file is an empty vault
+func (v *vault) load() error {
+	if v.loaded {
+		return nil
+	}
+	if v.unlocked == nil {
+		passphrase, err := v.passphrase()
+		if err != nil {
+			return err
+		}
+		v.unlocked = passphrase
+	}
+	passphrase := v.unlocked
+	v.secrets = make(map[string]string)
+	content, err := os.ReadFile(v.file)
+	if os.IsNotExist(err) {
+		v.salt = make([]byte, saltSize)
+		if _, err := rand.Read(v.salt); err != nil {
+			return err
+		}
+		if v.key, err = deriveKey(passphrase, v.salt); err != nil {
+			return err
+		}
+		v.loaded = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	encrypted := vaultFile{}
+	if err := json.Unmarshal(content, &encrypted); err != nil {
+		return util.NewError(fmt.Sprintf("Failed to decode vault %s: %s", v.file, err.Error()))
+	}
+	if encrypted.Version != vaultVersion {
+		return util.NewError(fmt.Sprintf("Unsupported version %d of vault %s", encrypted.Version, v.file))
+	}
+	key, err := deriveKey(passphrase, encrypted.Salt)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	data, err := gcm.Open(nil, encrypted.Nonce, encrypted.Data, nil)
+	if err != nil {
+		return util.NewInputError(fmt.Sprintf("Failed to unlock vault %s, the passphrase is wrong or the file was modified", v.file))
+	}
+	if err := json.Unmarshal(data, &v.secrets); err != nil {
+		return util.NewError(fmt.Sprintf("Failed to decode vault %s: %s", v.file, err.Error()))
+	}
+	v.salt = encrypted.Salt
+	v.key = key
+	v.loaded = true
+	return nil
+}
+
+// save encrypts the secrets with a new nonce
+func (v *vault) save() error {
+	data, err := json.Marshal(v.secrets)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(v.key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	content, err := json.Marshal(vaultFile{
+		Version: vaultVersion,
+		Salt:    v.salt,
+		Nonce:   nonce,
+		Data:    gcm.Seal(nil, nonce, data, nil),
+	})
+	if err != nil {
+		return err
+	}
+	return util.WriteFileAtomic(v.file, content, 0600)
+}
+
+func deriveKey(passphrase, salt []byte) ([]byte, error) {
+	return scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, keySize)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// getVaultPassphrase reads the passphrase from the environment or prompts for it
+func getVaultPassphrase() ([]byte, error) {
+	if passphrase := os.Getenv(VaultPassphraseEnv); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+	stdin := int(os.Stdin.Fd())
+	if !term.IsTerminal(stdin) {
+		return nil, util.NewInputError(fmt.Sprintf("The secret store is locked. Set %s or run potctl from a terminal to enter the passphrase", VaultPassphraseEnv))
+	}
+	wasRunning := util.SpinPause()
+	defer func() {
+		if wasRunning {
+			util.SpinUnpause()
+		}
+	}()
+	fmt.Fprint(os.Stderr, "Enter passphrase of the potctl secret store: ")
+	passphrase, err := term.ReadPassword(stdin)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	if len(passphrase) == 0 {
+		return nil, util.NewInputError("The passphrase of the secret store cannot be empty")
+	}
+	return passphrase, nil
+}
@@ -52,6 +52,7 @@ type Header struct {
 // Configuration contains the unmarshalled configuration file
 type configuration struct {
 	DefaultNamespace string `yaml:"defaultNamespace"`
+	SecretStore      string `yaml:"secretStore,omitempty"` // Backend keeping the sensitive values of namespaces
 }
 
 type potctlConfig struct {
@@ -60,6 +61,8 @@ type potctlConfig struct {
 
 type potctlNamespace struct {
 	Header `yaml:",inline"`
+	// Incremented each time the namespace file is written
+	Revision int `yaml:"revision,omitempty"`
 }
 
 // HeaderMetadata contains k8s metadata
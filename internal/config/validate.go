@@ -0,0 +1,138 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/datasance/potctl/internal/config/secretstore"
+	rsc "github.com/datasance/potctl/internal/resource"
+	"github.com/datasance/potctl/pkg/util"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// NamespaceFile describes a namespace file as stored on disk
+type NamespaceFile struct {
+	Name       string
+	APIVersion string
+	Revision   int
+	Modified   time.Time
+	Error      error // Set by ValidateNamespaceFiles if the file is invalid
+}
+
+// GetConfigDir returns the directory of the config and namespace files
+func GetConfigDir() string {
+	return configFolder
+}
+
+// GetConfigHeader returns the config file as it is stored, with the config directory and namespaces in status
+func GetConfigHeader() (header Header, err error) {
+	confHeader := potctlConfig{}
+	if err = util.UnmarshalYAML(configFilename, &confHeader); err != nil {
+		return
+	}
+	header = confHeader.Header
+	header.Status = map[string]interface{}{
+		"directory":  configFolder,
+		"namespaces": GetNamespaces(),
+	}
+	return
+}
+
+// GetNamespaceFiles returns the namespace files, including the namespace of detached resources
+func GetNamespaceFiles() ([]NamespaceFile, error) {
+	files := []NamespaceFile{}
+	for _, name := range append(GetNamespaces(), detachedNamespace) {
+		file := NamespaceFile{Name: name}
+		info, err := os.Stat(getNamespaceFile(name))
+		if err != nil {
+			return nil, err
+		}
+		file.Modified = info.ModTime()
+		header := potctlNamespace{}
+		if err := util.UnmarshalYAML(getNamespaceFile(name), &header); err != nil {
+			return nil, err
+		}
+		file.APIVersion = header.APIVersion
+		file.Revision = header.Revision
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// ValidateNamespaceFiles checks that the config file and every namespace file is of the current version and
+// matches the current namespace types
+func ValidateNamespaceFiles() (files []NamespaceFile, err error) {
+	confHeader := potctlConfig{}
+	if err = util.UnmarshalYAML(configFilename, &confHeader); err != nil {
+		return
+	}
+	if err = validateVersion(&confHeader.Header); err != nil {
+		return nil, util.NewInputError(fmt.Sprintf("Invalid config file %s: %s", configFilename, err.Error()))
+	}
+	if _, err = os.Stat(getNamespaceFile(conf.DefaultNamespace)); err != nil {
+		return nil, util.NewInputError(fmt.Sprintf("Invalid config file %s: the default namespace %s does not exist", configFilename, conf.DefaultNamespace))
+	}
+
+	if files, err = GetNamespaceFiles(); err != nil {
+		return
+	}
+	for idx := range files {
+		files[idx].Error = validateNamespaceFile(files[idx].Name)
+	}
+	return files, nil
+}
+
+func validateVersion(header *Header) error {
+	if header.APIVersion == CurrentConfigVersion {
+		return nil
+	}
+	if findMigration(header.APIVersion) >= 0 {
+		return util.NewInputError(fmt.Sprintf("version %s is outdated, run potctl config migrate", header.APIVersion))
+	}
+	return util.NewInputError(fmt.Sprintf("unsupported version %s", header.APIVersion))
+}
+
+func validateNamespaceFile(name string) error {
+	header := potctlNamespace{}
+	if err := util.UnmarshalYAML(getNamespaceFile(name), &header); err != nil {
+		return err
+	}
+	if header.Kind != potctlNamespaceKind {
+		return util.NewInputError(fmt.Sprintf("unexpected kind %s", header.Kind))
+	}
+	if err := validateVersion(&header.Header); err != nil {
+		return err
+	}
+	// Secrets are only resolved against the store when loading namespaces, so as not to unlock it here
+	if secretStore == nil {
+		if _, _, err := secretstore.Resolve(header.Spec, nil); err != nil {
+			return err
+		}
+	}
+	bytes, err := yaml.Marshal(header.Spec)
+	if err != nil {
+		return err
+	}
+	ns := new(rsc.Namespace)
+	if err := yaml.UnmarshalStrict(bytes, ns); err != nil {
+		return err
+	}
+	if ns.Name != name {
+		return util.NewInputError(fmt.Sprintf("name %s does not match the file name", ns.Name))
+	}
+	return nil
+}
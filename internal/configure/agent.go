@@ -21,22 +21,30 @@ import (
 )
 
 type agentExecutor struct {
-	namespace   string
-	name        string
-	keyFile     string
-	user        string
-	port        int
-	useDetached bool
+	namespace     string
+	name          string
+	keyFile       string
+	certFile      string
+	useAgent      *bool
+	proxyJump     *string
+	user          string
+	port          int
+	useDetached   bool
+	acceptHostKey bool
 }
 
 func newAgentExecutor(opt *Options) *agentExecutor {
 	return &agentExecutor{
-		namespace:   opt.Namespace,
-		name:        opt.Name,
-		keyFile:     opt.KeyFile,
-		user:        opt.User,
-		port:        opt.Port,
-		useDetached: opt.UseDetached,
+		namespace:     opt.Namespace,
+		name:          opt.Name,
+		keyFile:       opt.KeyFile,
+		certFile:      opt.CertFile,
+		useAgent:      opt.UseAgent,
+		proxyJump:     opt.ProxyJump,
+		user:          opt.User,
+		port:          opt.Port,
+		useDetached:   opt.UseDetached,
+		acceptHostKey: opt.AcceptHostKey,
 	}
 }
 
@@ -81,9 +89,28 @@ func (exe *agentExecutor) Execute() error {
 				return err
 			}
 		}
+		if exe.certFile != "" {
+			agent.SSH.CertFile, err = util.FormatPath(exe.certFile)
+			if err != nil {
+				return err
+			}
+		}
+		if exe.useAgent != nil {
+			agent.SSH.UseAgent = *exe.useAgent
+		}
+		if exe.proxyJump != nil {
+			if agent.SSH.ProxyJump, err = parseProxyJump(*exe.proxyJump); err != nil {
+				return err
+			}
+		}
 		if err := agent.Sanitize(); err != nil {
 			return err
 		}
+		if exe.acceptHostKey {
+			if err := acceptHostKey(agent.Host, &agent.SSH); err != nil {
+				return err
+			}
+		}
 
 		// Save config
 		if exe.useDetached {
@@ -20,9 +20,13 @@ import (
 )
 
 type remoteConfig struct {
-	keyFile string
-	user    string
-	port    int
+	keyFile       string
+	certFile      string
+	useAgent      *bool
+	proxyJump     *string
+	user          string
+	port          int
+	acceptHostKey bool
 }
 
 type controllerExecutor struct {
@@ -37,9 +41,13 @@ func newControllerExecutor(opt *Options) *controllerExecutor {
 		namespace: opt.Namespace,
 		name:      opt.Name,
 		remoteConfig: remoteConfig{
-			keyFile: opt.KeyFile,
-			user:    opt.User,
-			port:    opt.Port,
+			keyFile:       opt.KeyFile,
+			certFile:      opt.CertFile,
+			useAgent:      opt.UseAgent,
+			proxyJump:     opt.ProxyJump,
+			user:          opt.User,
+			port:          opt.Port,
+			acceptHostKey: opt.AcceptHostKey,
 		},
 	}
 }
@@ -96,6 +104,20 @@ func (exe *controllerExecutor) remoteConfigure(controlPlane *rsc.RemoteControlPl
 			return err
 		}
 	}
+	if exe.remoteConfig.certFile != "" {
+		controller.SSH.CertFile, err = util.FormatPath(exe.remoteConfig.certFile)
+		if err != nil {
+			return err
+		}
+	}
+	if exe.remoteConfig.useAgent != nil {
+		controller.SSH.UseAgent = *exe.remoteConfig.useAgent
+	}
+	if exe.remoteConfig.proxyJump != nil {
+		if controller.SSH.ProxyJump, err = parseProxyJump(*exe.remoteConfig.proxyJump); err != nil {
+			return err
+		}
+	}
 	if exe.remoteConfig.user != "" {
 		controller.SSH.User = exe.remoteConfig.user
 	}
@@ -108,6 +130,12 @@ func (exe *controllerExecutor) remoteConfigure(controlPlane *rsc.RemoteControlPl
 		controller.SSH.Port = 22
 	}
 
+	if exe.remoteConfig.acceptHostKey {
+		if err := acceptHostKey(controller.Host, &controller.SSH); err != nil {
+			return err
+		}
+	}
+
 	if err := controlPlane.UpdateController(controller); err != nil {
 		return err
 	}
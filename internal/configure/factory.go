@@ -19,14 +19,18 @@ import (
 )
 
 type Options struct {
-	ResourceType string
-	Namespace    string
-	Name         string
-	KubeConfig   string
-	KeyFile      string
-	User         string
-	Port         int
-	UseDetached  bool
+	ResourceType  string
+	Namespace     string
+	Name          string
+	KubeConfig    string
+	KeyFile       string
+	CertFile      string
+	UseAgent      *bool   // Nil if not specified
+	ProxyJump     *string // Nil if not specified, empty to remove jump hosts
+	User          string
+	Port          int
+	UseDetached   bool
+	AcceptHostKey bool
 }
 
 var multipleResources = map[string]bool{
@@ -40,6 +44,8 @@ func NewExecutor(opt *Options) (execute.Executor, error) {
 		return newDefaultNamespaceExecutor(opt), nil
 	case "default-namespace":
 		return newDefaultNamespaceExecutor(opt), nil
+	case "secret-store":
+		return newSecretStoreExecutor(opt), nil
 	case "controlplane":
 		return newControlPlaneExecutor(opt), nil
 	case "controller":
@@ -0,0 +1,48 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package configure
+
+import (
+	"fmt"
+
+	rsc "github.com/datasance/potctl/internal/resource"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// acceptHostKey trusts the key currently presented by the host and pins it in the SSH config
+func acceptHostKey(host string, ssh *rsc.SSH) error {
+	if host == "" {
+		return util.NewInputError("Cannot accept the host key of a resource without host")
+	}
+	client, err := util.NewSecureShellClient(ssh.User, host, ssh.KeyFile)
+	if err != nil {
+		return err
+	}
+	if ssh.Port != 0 {
+		client.SetPort(ssh.Port)
+	}
+	// Reach the host through its jump hosts
+	client.SetOptions(ssh.Options())
+	fingerprint, err := client.AcceptHostKey()
+	if err != nil {
+		return err
+	}
+	if ssh.HostKeyFingerprint != "" && ssh.HostKeyFingerprint != fingerprint {
+		util.PrintNotify(fmt.Sprintf("Replacing host key %s of %s", ssh.HostKeyFingerprint, host))
+	}
+	ssh.HostKeyFingerprint = fingerprint
+	ssh.HostKeyType = client.HostKeyType()
+	util.PrintInfo(fmt.Sprintf("Trusted host key %s of %s", fingerprint, host))
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package configure
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	rsc "github.com/datasance/potctl/internal/resource"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// parseProxyJump parses jump hosts in the format of ssh -J, i.e. [user@]host[:port],...
+func parseProxyJump(spec string) ([]rsc.SSHJumpHost, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	hops := []rsc.SSHJumpHost{}
+	for _, hopSpec := range strings.Split(spec, ",") {
+		hopSpec = strings.TrimSpace(hopSpec)
+		hop := rsc.SSHJumpHost{}
+		if idx := strings.LastIndex(hopSpec, "@"); idx != -1 {
+			hop.User = hopSpec[:idx]
+			hopSpec = hopSpec[idx+1:]
+		}
+		hop.Host = hopSpec
+		if host, port, err := net.SplitHostPort(hopSpec); err == nil {
+			hop.Host = host
+			if hop.Port, err = strconv.Atoi(port); err != nil {
+				return nil, util.NewInputError(fmt.Sprintf("Invalid port in jump host %s", hopSpec))
+			}
+		}
+		if hop.Host == "" {
+			return nil, util.NewInputError(fmt.Sprintf("Invalid jump host %s, expected [user@]host[:port]", hopSpec))
+		}
+		hops = append(hops, hop)
+	}
+	return hops, nil
+}
@@ -0,0 +1,44 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package configure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/config/secretstore"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+type secretStoreExecutor struct {
+	backend string
+}
+
+func newSecretStoreExecutor(opt *Options) *secretStoreExecutor {
+	return &secretStoreExecutor{
+		backend: opt.Name,
+	}
+}
+
+func (exe *secretStoreExecutor) GetName() string {
+	return exe.backend
+}
+
+func (exe *secretStoreExecutor) Execute() error {
+	if exe.backend == "" {
+		return util.NewInputError(fmt.Sprintf("Must specify the secret store, one of %s", strings.Join(secretstore.Backends(), ", ")))
+	}
+	return config.SetSecretStore(exe.backend)
+}
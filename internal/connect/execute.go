@@ -123,13 +123,13 @@ func manualExecute(opt *Options) (err error) {
 }
 
 func executeWithYAML(yamlFile, namespace string) error {
-	executorsMap, err := execute.GetExecutorsFromYAML(yamlFile, namespace, kindHandlers)
+	executorsMap, err := execute.GetExecutorsFromYAML([]string{yamlFile}, false, namespace, kindHandlers)
 	if err != nil {
 		return err
 	}
 
 	for idx := range kindOrder {
-		if errs := execute.RunExecutors(executorsMap[kindOrder[idx]], fmt.Sprintf("connect %s", kindOrder[idx])); len(errs) > 0 {
+		if errs := execute.RunKindExecutors(executorsMap[kindOrder[idx]], kindOrder[idx], fmt.Sprintf("connect %s", kindOrder[idx])); len(errs) > 0 {
 			return execute.CoalesceErrors(errs)
 		}
 	}
@@ -0,0 +1,202 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/datasance/potctl/internal/exec"
+	"github.com/datasance/potctl/internal/execute"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+type Options struct {
+	Namespace   string
+	Source      string
+	Destination string
+	// Agent makes the remote name refer to an Agent instead of a Microservice
+	Agent bool
+}
+
+type executor struct {
+	namespace string
+	resource  string
+	src       location
+	dst       location
+}
+
+func NewExecutor(opt *Options) (execute.Executor, error) {
+	exe := &executor{
+		namespace: opt.Namespace,
+		resource:  "microservice",
+		src:       parseLocation(opt.Source),
+		dst:       parseLocation(opt.Destination),
+	}
+	if opt.Agent {
+		exe.resource = "agent"
+	}
+	if exe.src.isRemote() == exe.dst.isRemote() {
+		return nil, util.NewInputError("Exactly one of the source and destination must be in the form NAME:PATH")
+	}
+	remote := exe.remote()
+	if remote.path == "" {
+		return nil, util.NewInputError(fmt.Sprintf("No path specified in %s", remote))
+	}
+	if !opt.Agent && !strings.Contains(remote.name, "/") {
+		return nil, util.NewInputError(fmt.Sprintf("Microservice name %s must be in the form AppName/MsvcName, use --agent to copy from or to an Agent", remote.name))
+	}
+	return exe, nil
+}
+
+func (exe *executor) remote() location {
+	if exe.src.isRemote() {
+		return exe.src
+	}
+	return exe.dst
+}
+
+func (exe *executor) GetName() string {
+	return exe.remote().name
+}
+
+func (exe *executor) Execute() error {
+	if exe.src.isRemote() {
+		return exe.download()
+	}
+	return exe.upload()
+}
+
+// download archives the remote path with tar and extracts the stream locally
+func (exe *executor) download() error {
+	remotePath := path.Clean(exe.src.path)
+	dir, base := path.Dir(remotePath), path.Base(remotePath)
+
+	// Copy into existing directories, otherwise copy as the destination
+	dest := exe.dst.path
+	if info, err := os.Stat(dest); err == nil && info.IsDir() {
+		dest = filepath.Join(dest, base)
+	}
+
+	progress := newProgress(fmt.Sprintf("Copying %s", exe.src), 0)
+	reader, writer := io.Pipe()
+	extractErr := make(chan error, 1)
+	go func() {
+		err := extractTar(reader, base, dest, nil)
+		if err == nil {
+			// Drain the padding following the end of the archive
+			_, err = io.Copy(io.Discard, reader)
+		}
+		reader.CloseWithError(err)
+		extractErr <- err
+	}()
+
+	var stderr bytes.Buffer
+	err := exec.RunCommand(&exec.Options{
+		Resource:  exe.resource,
+		Name:      exe.src.name,
+		Namespace: exe.namespace,
+		Command:   []string{"tar", "cf", "-", "-C", dir, base},
+	}, &exec.Streams{
+		Stdout: &countingWriter{writer: writer, progress: progress},
+		Stderr: &stderr,
+	})
+	writer.CloseWithError(err)
+	localErr := <-extractErr
+	progress.Done()
+
+	if _, ok := err.(*exec.ExitError); ok {
+		return commandError(exe.src, err, &stderr)
+	}
+	if localErr != nil {
+		return localErr
+	}
+	return err
+}
+
+// upload streams a tar archive of the local path to tar running in the container
+func (exe *executor) upload() error {
+	src := exe.src.path
+	if _, err := os.Stat(src); err != nil {
+		return util.NewInputError(fmt.Sprintf("Could not read %s: %s", src, err.Error()))
+	}
+
+	// A trailing slash copies into the remote directory, otherwise the copy is named after the destination
+	remotePath := exe.dst.path
+	dir, name := path.Dir(path.Clean(remotePath)), path.Base(remotePath)
+	if strings.HasSuffix(remotePath, "/") {
+		dir, name = path.Clean(remotePath), filepath.Base(src)
+	}
+
+	total, err := localSize(src)
+	if err != nil {
+		return err
+	}
+	progress := newProgress(fmt.Sprintf("Copying %s", src), total)
+	reader, writer := io.Pipe()
+	archiveErr := make(chan error, 1)
+	go func() {
+		err := writeTar(writer, src, name, progress)
+		writer.CloseWithError(err)
+		archiveErr <- err
+	}()
+
+	var stderr bytes.Buffer
+	err = exec.RunCommand(&exec.Options{
+		Resource:  exe.resource,
+		Name:      exe.dst.name,
+		Namespace: exe.namespace,
+		Command:   []string{"tar", "xf", "-", "-C", dir},
+	}, &exec.Streams{
+		Stdin:  reader,
+		Stdout: io.Discard,
+		Stderr: &stderr,
+	})
+	reader.Close()
+	localErr := <-archiveErr
+	progress.Done()
+
+	if localErr != nil && localErr != io.ErrClosedPipe {
+		return localErr
+	}
+	if _, ok := err.(*exec.ExitError); ok {
+		return commandError(exe.dst, err, &stderr)
+	}
+	return err
+}
+
+// countingWriter reports the bytes written to the progress
+type countingWriter struct {
+	writer   io.Writer
+	progress *progress
+}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	n, err := w.writer.Write(b)
+	w.progress.Add(int64(n))
+	return n, err
+}
+
+func commandError(loc location, err error, stderr *bytes.Buffer) error {
+	msg := strings.TrimSpace(stderr.String())
+	if msg == "" {
+		msg = err.Error()
+	}
+	return util.NewError(fmt.Sprintf("Failed to copy %s: %s", loc, msg))
+}
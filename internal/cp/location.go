@@ -0,0 +1,45 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cp
+
+import (
+	"strings"
+)
+
+// location is either a local path or a path inside the container of a Microservice or Agent
+type location struct {
+	name string
+	path string
+}
+
+func (loc location) isRemote() bool {
+	return loc.name != ""
+}
+
+func (loc location) String() string {
+	if loc.isRemote() {
+		return loc.name + ":" + loc.path
+	}
+	return loc.path
+}
+
+// parseLocation splits NAME:PATH specs, anything else is a local path. Windows drive letters and
+// relative or absolute local paths containing a colon are not mistaken for remote locations.
+func parseLocation(spec string) location {
+	idx := strings.Index(spec, ":")
+	if idx <= 1 || strings.HasPrefix(spec, ".") || strings.HasPrefix(spec, "/") || strings.Contains(spec[:idx], `\`) {
+		return location{path: spec}
+	}
+	return location{name: spec[:idx], path: spec[idx+1:]}
+}
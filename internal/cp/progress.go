@@ -0,0 +1,96 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cp
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// Print progress of transfers of unknown size every 256 KB
+const progressByteStep = 256 * 1024
+
+// progress prints the number of bytes copied, as a percentage if the total is known
+type progress struct {
+	mutex   sync.Mutex
+	label   string
+	total   int64
+	copied  int64
+	printed int64
+	percent int
+	done    bool
+}
+
+func newProgress(label string, total int64) *progress {
+	p := &progress{label: label, total: total, percent: -1}
+	p.print(false)
+	return p
+}
+
+// Add records n more bytes copied
+func (p *progress) Add(n int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.copied += n
+	p.print(false)
+}
+
+// Done prints the final progress
+func (p *progress) Done() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.done {
+		return
+	}
+	p.done = true
+	p.print(true)
+}
+
+func (p *progress) print(done bool) {
+	if p.total > 0 {
+		percent := int(p.copied * 100 / p.total)
+		if done {
+			percent = 100
+		}
+		if percent == p.percent && !done {
+			return
+		}
+		p.percent = percent
+		util.PrintProgress(p.label, percent, done)
+		return
+	}
+	if !done && p.printed > 0 && p.copied-p.printed < progressByteStep {
+		return
+	}
+	p.printed = p.copied
+	fmt.Printf("\r%s%s: %s%s", util.CSkyblue, p.label, formatBytes(p.copied), util.NoFormat)
+	if done {
+		fmt.Print("\n")
+	}
+}
+
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,160 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cp
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// countingReader reports the bytes read to the progress
+type countingReader struct {
+	reader   io.Reader
+	progress *progress
+}
+
+func (r *countingReader) Read(b []byte) (int, error) {
+	n, err := r.reader.Read(b)
+	if r.progress != nil {
+		r.progress.Add(int64(n))
+	}
+	return n, err
+}
+
+// localSize returns the total size of the regular files of src
+func localSize(src string) (size int64, err error) {
+	err = filepath.Walk(src, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return
+}
+
+// writeTar archives the file or directory src into w under the name name
+func writeTar(w io.Writer, src, name string, p *progress) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(src, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(name, filepath.ToSlash(rel))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, &countingReader{reader: f, progress: p})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// extractTar extracts the entry named prefix, and the entries under it, into dest
+func extractTar(r io.Reader, prefix, dest string, p *progress) error {
+	tr := tar.NewReader(r)
+	extracted := false
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		// Cleaning first ensures that entries escaping prefix with .. are skipped
+		name := path.Clean(header.Name)
+		rel := ""
+		if name != prefix {
+			if !strings.HasPrefix(name, prefix+"/") {
+				continue
+			}
+			rel = strings.TrimPrefix(name, prefix+"/")
+		}
+		target := filepath.Join(dest, filepath.FromSlash(rel))
+		mode := header.FileInfo().Mode().Perm()
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, mode|0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := writeFile(target, mode, &countingReader{reader: tr, progress: p}); err != nil {
+				return err
+			}
+		default:
+			util.PrintNotify(fmt.Sprintf("Skipping %s, only regular files and directories are copied", header.Name))
+			continue
+		}
+		extracted = true
+	}
+	if !extracted {
+		return util.NewError("No files were copied")
+	}
+	return nil
+}
+
+func writeFile(filename string, mode os.FileMode, r io.Reader) error {
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
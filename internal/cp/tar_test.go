@@ -0,0 +1,104 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cp
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTarRoundTrip(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "config")
+	if err := os.MkdirAll(filepath.Join(src, "nested"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"app.json":           `{"debug": true}`,
+		"nested/secrets.env": "TOKEN=abc",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(src, filepath.FromSlash(name)), []byte(content), 0640); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var archive bytes.Buffer
+	if err := writeTar(&archive, src, "renamed", nil); err != nil {
+		t.Fatal(err)
+	}
+	dest := filepath.Join(t.TempDir(), "copy")
+	if err := extractTar(&archive, "renamed", dest, nil); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		data, err := os.ReadFile(filepath.Join(dest, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != content {
+			t.Errorf("%s: expected %q, got %q", name, content, string(data))
+		}
+	}
+}
+
+func TestExtractTarSkipsEscapingEntries(t *testing.T) {
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	for _, name := range []string{"file", "file/../../escaped", "other"} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "dest", "file")
+	if err := extractTar(&archive, "file", dest, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"escaped", filepath.Join("dest", "escaped"), "other"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			t.Errorf("Expected %s not to be extracted", name)
+		}
+	}
+	if _, err := os.Stat(dest); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		spec string
+		name string
+		path string
+	}{
+		{"app/msvc:/var/log/app.log", "app/msvc", "/var/log/app.log"},
+		{"agent-1:/tmp", "agent-1", "/tmp"},
+		{"./local:file", "", "./local:file"},
+		{"/abs/path", "", "/abs/path"},
+		{`C:\Users\config.json`, "", `C:\Users\config.json`},
+		{"relative", "", "relative"},
+	}
+	for _, test := range tests {
+		loc := parseLocation(test.spec)
+		if loc.name != test.name || loc.path != test.path {
+			t.Errorf("%s: expected %q %q, got %q %q", test.spec, test.name, test.path, loc.name, loc.path)
+		}
+	}
+}
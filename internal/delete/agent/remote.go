@@ -35,6 +35,7 @@ func (exe executor) deleteRemoteAgent(agent *rsc.RemoteAgent) error {
 		if err != nil {
 			return err
 		}
+		sshAgent.SetSSHOptions(agent.SSH.Options())
 		if err := sshAgent.Uninstall(); err != nil {
 			util.PrintNotify(fmt.Sprintf("Failed to stop daemon on Agent %s. %s", agent.Name, err.Error()))
 		}
@@ -74,6 +74,7 @@ func (exe *RemoteExecutor) Execute() error {
 		Host:            ctrl.Host,
 		Port:            ctrl.SSH.Port,
 		PrivKeyFilename: ctrl.SSH.KeyFile,
+		SSH:             ctrl.SSH.Options(),
 	}
 	installer, err := install.NewController(controllerOptions)
 	if err != nil {
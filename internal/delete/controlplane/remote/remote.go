@@ -71,7 +71,7 @@ func (exe *Executor) Execute() (err error) {
 }
 
 func runExecutors(executors []execute.Executor) error {
-	if errs, _ := execute.ForParallel(executors); len(errs) > 0 {
+	if errs, _ := execute.ForParallelKind(executors, config.RemoteControllerKind); len(errs) > 0 {
 		return execute.CoalesceErrors(errs)
 	}
 	return nil
@@ -42,9 +42,10 @@ import (
 )
 
 type Options struct {
-	Namespace string
-	InputFile string
-	Soft      bool
+	Namespace  string
+	InputFiles []string
+	Recursive  bool
+	Soft       bool
 }
 
 var kindOrder = []config.Kind{
@@ -146,14 +147,48 @@ var kindHandlers = map[config.Kind]func(*execute.KindHandlerOpt) (execute.Execut
 }
 
 func Execute(opt *Options) error {
-	executorsMap, err := execute.GetExecutorsFromYAML(opt.InputFile, opt.Namespace, kindHandlers)
+	executorsMap, err := execute.GetExecutorsFromYAML(opt.InputFiles, opt.Recursive, opt.Namespace, kindHandlers)
 	if err != nil {
 		return err
 	}
 
+	return runExecutors(executorsMap)
+}
+
+// CanDelete returns true if resources of the kind can be deleted by name
+func CanDelete(kind config.Kind) bool {
+	_, found := kindHandlers[kind]
+	return found
+}
+
+// ExecuteResources deletes the named resources of each kind, in the same order as Execute
+func ExecuteResources(namespace string, names map[config.Kind][]string) error {
+	executorsMap := make(map[config.Kind][]execute.Executor)
+	for kind, kindNames := range names {
+		createExecutorFunc, found := kindHandlers[kind]
+		if !found {
+			return util.NewInputError(fmt.Sprintf("Cannot delete resources of kind %s", kind))
+		}
+		for _, name := range kindNames {
+			exe, err := createExecutorFunc(&execute.KindHandlerOpt{
+				Kind:      kind,
+				Namespace: namespace,
+				Name:      name,
+			})
+			if err != nil {
+				return err
+			}
+			executorsMap[kind] = append(executorsMap[kind], exe)
+		}
+	}
+
+	return runExecutors(executorsMap)
+}
+
+func runExecutors(executorsMap map[config.Kind][]execute.Executor) error {
 	// Microservice, Application, Agent, Controller, ControlPlane
 	for idx := range kindOrder {
-		if errs := execute.RunExecutors(executorsMap[kindOrder[idx]], fmt.Sprintf("delete %s", kindOrder[idx])); len(errs) > 0 {
+		if errs := execute.RunKindExecutors(executorsMap[kindOrder[idx]], kindOrder[idx], fmt.Sprintf("delete %s", kindOrder[idx])); len(errs) > 0 {
 			for _, err := range errs {
 				if _, ok := err.(*util.NotFoundError); !ok {
 					return execute.CoalesceErrors(errs)
@@ -32,6 +32,10 @@ func deleteRemote(agent *rsc.RemoteAgent, volume *rsc.Volume) error {
 	if err != nil {
 		return err
 	}
+	if agent.SSH.Port != 0 {
+		ssh.SetPort(agent.SSH.Port)
+	}
+	ssh.SetOptions(agent.SSH.Options())
 	if err := ssh.Connect(); err != nil {
 		return err
 	}
@@ -86,6 +86,7 @@ func (exe *remoteExecutor) ProvisionAgent() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	agent.SetSSHOptions(exe.agent.SSH.Options())
 
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
@@ -150,6 +151,11 @@ func (exe *remoteExecutor) Execute() (err error) {
 		util.PrintError("You must deploy a Controller to a namespace before deploying any Agents")
 		return
 	}
+	if previous, err := ns.GetAgent(exe.agent.Name); err == nil {
+		if previousAgent, ok := previous.(*rsc.RemoteAgent); ok {
+			exe.agent.SSH.KeepHostKey(exe.agent.Host, &previousAgent.SSH, previousAgent.Host)
+		}
+	}
 
 	var agent *install.RemoteAgent
 	// If DeploymentType is nil, default to "container"
@@ -198,6 +204,7 @@ func (exe *remoteExecutor) Execute() (err error) {
 	if err != nil {
 		return err
 	}
+	agent.SetSSHOptions(exe.agent.SSH.Options())
 
 	// Set custom scripts
 	if exe.agent.Scripts != nil {
@@ -289,6 +296,11 @@ func (exe *remoteExecutor) Execute() (err error) {
 	if err != nil {
 		return
 	}
+	// Pin the host key trusted on first use
+	if fingerprint := agent.HostKeyFingerprint(); fingerprint != "" {
+		exe.agent.SSH.HostKeyFingerprint = fingerprint
+		exe.agent.SSH.HostKeyType = agent.HostKeyType()
+	}
 
 	uuid, err := exe.ProvisionAgent()
 	if err != nil {
@@ -308,8 +320,8 @@ func ValidateRemoteAgent(agent *rsc.RemoteAgent) error {
 	if agent.Name == iofog.VanillaRouterAgentName {
 		return util.NewInputError(fmt.Sprintf("%s is a reserved name and cannot be used for an Agent", iofog.VanillaRouterAgentName))
 	}
-	if (agent.Host != "localhost" && agent.Host != "127.0.0.1") && (agent.Host == "" || agent.SSH.User == "" || agent.SSH.KeyFile == "") {
-		return util.NewInputError("For Agents you must specify non-empty values for host, user, and keyfile")
+	if (agent.Host != "localhost" && agent.Host != "127.0.0.1") && (agent.Host == "" || agent.SSH.User == "" || !agent.SSH.HasCredentials()) {
+		return util.NewInputError("For Agents you must specify non-empty values for host, user, and keyfile or useAgent")
 	}
 	return nil
 }
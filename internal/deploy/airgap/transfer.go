@@ -51,7 +51,7 @@ func TransferAirgapImages(ctx context.Context, namespace string, host string, ss
 	if host == "" {
 		return util.NewInputError("host is required for airgap image transfer")
 	}
-	if ssh == nil || ssh.User == "" || ssh.KeyFile == "" {
+	if ssh == nil || ssh.User == "" || !ssh.HasCredentials() {
 		return util.NewInputError("SSH configuration is required for airgap image transfer")
 	}
 	if platform == "" {
@@ -310,6 +310,7 @@ func transferAndLoadImage(plan transferPlan, artifact *imageArtifact) error {
 		return err
 	}
 	ssh.SetPort(plan.ssh.Port)
+	ssh.SetOptions(plan.ssh.Options())
 	if err := ssh.Connect(); err != nil {
 		return err
 	}
@@ -100,6 +100,26 @@ func NewExecutorWithoutParsing(namespace string, controlPlane *rsc.RemoteControl
 	return newExecutor(namespace, controlPlane, controller), nil
 }
 
+// keepHostKey keeps the host key pinned when the Controller was deployed before, the Control Plane being
+// read from the deploy file may not carry it
+func (exe *remoteExecutor) keepHostKey() {
+	ns, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return
+	}
+	controlPlane, err := ns.GetControlPlane()
+	if err != nil {
+		return
+	}
+	previous, err := controlPlane.GetController(exe.controller.Name)
+	if err != nil {
+		return
+	}
+	if previousCtrl, ok := previous.(*rsc.RemoteController); ok {
+		exe.controller.SSH.KeepHostKey(exe.controller.Host, &previousCtrl.SSH, previousCtrl.Host)
+	}
+}
+
 func (exe *remoteExecutor) Execute() (err error) {
 	if err = exe.controller.ValidateSSH(); err != nil {
 		return
@@ -107,6 +127,7 @@ func (exe *remoteExecutor) Execute() (err error) {
 	if exe.controller.LogLevel == "" {
 		exe.controller.LogLevel = "info"
 	}
+	exe.keepHostKey()
 	// Instantiate deployer
 	controllerOptions := &install.ControllerOptions{
 		Namespace:           exe.namespace,
@@ -114,6 +135,7 @@ func (exe *remoteExecutor) Execute() (err error) {
 		Host:                exe.controller.Host,
 		Port:                exe.controller.SSH.Port,
 		PrivKeyFilename:     exe.controller.SSH.KeyFile,
+		SSH:                 exe.controller.SSH.Options(),
 		PidBaseDir:          exe.controller.PidBaseDir,
 		EcnViewerPort:       exe.controller.EcnViewerPort,
 		EcnViewerURL:        exe.controller.EcnViewerURL,
@@ -198,6 +220,11 @@ func (exe *remoteExecutor) Execute() (err error) {
 	if err = deployer.Install(); err != nil {
 		return
 	}
+	// Pin the host key trusted on first use
+	if fingerprint := deployer.HostKeyFingerprint(); fingerprint != "" {
+		exe.controller.SSH.HostKeyFingerprint = fingerprint
+		exe.controller.SSH.HostKeyType = deployer.HostKeyType()
+	}
 	// Update controller
 	useHTTPS := false
 	if exe.controller.Https != nil && exe.controller.Https.Enabled != nil && *exe.controller.Https.Enabled {
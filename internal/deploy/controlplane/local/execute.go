@@ -232,7 +232,7 @@ func NewExecutor(opt Options) (exe execute.Executor, err error) {
 }
 
 func runExecutors(executors []execute.Executor) error {
-	if errs, _ := execute.ForParallel(executors); len(errs) > 0 {
+	if errs, _ := execute.ForParallelKind(executors, config.LocalControllerKind); len(errs) > 0 {
 		return execute.CoalesceErrors(errs)
 	}
 	return nil
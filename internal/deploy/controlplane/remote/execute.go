@@ -440,6 +440,10 @@ func tagControllerImage(ctrl *rsc.RemoteController, image string) (err error) {
 	if err != nil {
 		return err
 	}
+	if ctrl.SSH.Port != 0 {
+		ssh.SetPort(ctrl.SSH.Port)
+	}
+	ssh.SetOptions(ctrl.SSH.Options())
 	if err := ssh.Connect(); err != nil {
 		return err
 	}
@@ -927,7 +931,7 @@ func NewExecutor(opt Options) (exe execute.Executor, err error) {
 }
 
 func runExecutors(executors []execute.Executor) error {
-	if errs, _ := execute.ForParallel(executors); len(errs) > 0 {
+	if errs, _ := execute.ForParallelKind(executors, config.RemoteControllerKind); len(errs) > 0 {
 		return execute.CoalesceErrors(errs)
 	}
 	return nil
@@ -0,0 +1,402 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package deploy
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
+	"github.com/datasance/potctl/internal/execute"
+	rsc "github.com/datasance/potctl/internal/resource"
+	clientutil "github.com/datasance/potctl/internal/util/client"
+	"github.com/datasance/potctl/internal/util/diff"
+	"github.com/datasance/potctl/internal/util/printer"
+	"github.com/datasance/potctl/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	DiffCreate      = "create"
+	DiffUpdate      = "update"
+	DiffUnchanged   = "unchanged"
+	DiffNotCompared = "not compared"
+)
+
+// describeResources maps the kinds whose live state is read through describe
+var describeResources = map[config.Kind]string{
+	config.ApplicationKind:            "application",
+	config.ApplicationTemplateKind:    "application-template",
+	config.MicroserviceKind:           "microservice",
+	config.EdgeResourceKind:           "edge-resource",
+	config.VolumeKind:                 "volume",
+	config.SecretKind:                 "secret",
+	config.ConfigMapKind:              "configmap",
+	config.ServiceKind:                "service",
+	config.VolumeMountKind:            "volume-mount",
+	config.CertificateKind:            "certificate",
+	config.CertificateAuthorityKind:   "certificate",
+	config.RoleKind:                   "role",
+	config.RoleBindingKind:            "rolebinding",
+	config.ServiceAccountKind:         "serviceaccount",
+	config.AgentConfigKind:            "agent-config",
+	config.RemoteControllerKind:       "controller",
+	config.LocalControllerKind:        "controller",
+	config.KubernetesControlPlaneKind: "controlplane",
+	config.RemoteControlPlaneKind:     "controlplane",
+	config.LocalControlPlaneKind:      "controlplane",
+}
+
+// typedSpecs returns the resource deploy decodes the spec of each kind into. Both sides of a diff are decoded into it
+// and encoded again, so that defaults, zero values and field order of describe documents match those of files.
+// Kinds deploy forwards to the Controller as is are compared in their generic form.
+var typedSpecs = map[config.Kind]func() interface{}{
+	config.EdgeResourceKind:           func() interface{} { return &rsc.EdgeResource{} },
+	config.VolumeKind:                 func() interface{} { return &rsc.Volume{} },
+	config.SecretKind:                 func() interface{} { return &rsc.Secret{} },
+	config.ConfigMapKind:              func() interface{} { return &rsc.ConfigMap{} },
+	config.ServiceKind:                func() interface{} { return &rsc.ClusterService{} },
+	config.VolumeMountKind:            func() interface{} { return &rsc.VolumeMount{} },
+	config.CertificateKind:            func() interface{} { return &rsc.CertificateCreateRequest{} },
+	config.CertificateAuthorityKind:   func() interface{} { return &rsc.CACreateRequest{} },
+	config.RoleKind:                   func() interface{} { return &rsc.Role{} },
+	config.RoleBindingKind:            func() interface{} { return &rsc.RoleBinding{} },
+	config.ServiceAccountKind:         func() interface{} { return &rsc.ServiceAccount{} },
+	config.AgentConfigKind:            func() interface{} { return &rsc.AgentConfiguration{} },
+	config.RemoteControllerKind:       func() interface{} { return &rsc.RemoteController{} },
+	config.LocalControllerKind:        func() interface{} { return &rsc.LocalController{} },
+	config.KubernetesControlPlaneKind: func() interface{} { return &rsc.KubernetesControlPlane{} },
+	config.RemoteControlPlaneKind:     func() interface{} { return &rsc.RemoteControlPlane{} },
+	config.LocalControlPlaneKind:      func() interface{} { return &rsc.LocalControlPlane{} },
+	config.RemoteAgentKind:            func() interface{} { return &rsc.RemoteAgent{} },
+	config.LocalAgentKind:             func() interface{} { return &rsc.LocalAgent{} },
+}
+
+// DiffResult is the outcome of comparing one document against the live state
+type DiffResult struct {
+	Kind   config.Kind
+	Name   string
+	Action string
+	Diff   string
+}
+
+// Diff compares each document of the input file with the current state of the Controller and Namespace
+// and writes a unified diff of every change followed by a summary. Nothing is deployed.
+func Diff(opt *Options, writer io.Writer) ([]DiffResult, error) {
+	headers, err := execute.GetHeadersFromYAML(opt.InputFiles, opt.Recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	kindHandlers := buildKindHandlers(opt.NoCache, opt.TransferPool)
+	results := []DiffResult{}
+	for idx := range headers {
+		header := &headers[idx]
+		// Resolve the document the same way deploy does so invalid documents fail here too
+		exe, err := execute.GenerateExecutor(header, opt.Namespace, kindHandlers)
+		if err != nil {
+			return nil, err
+		}
+		if exe == nil {
+			continue
+		}
+		result, err := diffHeader(opt.Namespace, header)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		return nil, util.NewInputError("Could not decode any valid resources from input YAML file")
+	}
+
+	if err := printDiffResults(writer, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func diffHeader(namespace string, header *config.Header) (DiffResult, error) {
+	result := DiffResult{
+		Kind: header.Kind,
+		Name: header.Metadata.Name,
+	}
+
+	live, found, err := getLiveHeader(namespace, header)
+	if err != nil {
+		return result, err
+	}
+	if live == nil {
+		result.Action = DiffNotCompared
+		return result, nil
+	}
+
+	desired, err := comparableDocument(header.Kind, header)
+	if err != nil {
+		return result, err
+	}
+	current := map[interface{}]interface{}{}
+	if found {
+		if current, err = comparableDocument(header.Kind, live); err != nil {
+			return result, err
+		}
+		// Fields left out of the file are not managed by it
+		current = prune(current, desired).(map[interface{}]interface{})
+	}
+
+	desiredYAML, err := yaml.Marshal(desired)
+	if err != nil {
+		return result, err
+	}
+	currentYAML := []byte{}
+	if found {
+		if currentYAML, err = yaml.Marshal(current); err != nil {
+			return result, err
+		}
+	}
+
+	path := fmt.Sprintf("%s/%s", header.Kind, header.Metadata.Name)
+	result.Diff = diff.Unified("live/"+path, "file/"+path, string(currentYAML), string(desiredYAML), diff.DefaultContext)
+	switch {
+	case !found:
+		result.Action = DiffCreate
+	case result.Diff == "":
+		result.Action = DiffUnchanged
+	default:
+		result.Action = DiffUpdate
+	}
+	return result, nil
+}
+
+// getLiveHeader returns the deployed document, found is false if it does not exist yet.
+// A nil header means the live state of this kind cannot be retrieved, e.g. Catalog Items, Registries and Offline Images.
+func getLiveHeader(namespace string, header *config.Header) (live *config.Header, found bool, err error) {
+	name := header.Metadata.Name
+	switch header.Kind {
+	case config.RemoteAgentKind, config.LocalAgentKind:
+		// Agents are compared with the Namespace file, which is what deploy provisions from
+		ns, err := config.GetNamespace(namespace)
+		if err != nil {
+			return nil, false, err
+		}
+		agent, err := ns.GetAgent(name)
+		if err != nil {
+			if isNotFound(err) {
+				return &config.Header{}, false, nil
+			}
+			return nil, false, err
+		}
+		return &config.Header{Kind: header.Kind, Spec: agent}, true, nil
+	case config.NatsAccountRuleKind, config.NatsUserRuleKind:
+		return getLiveNatsRule(namespace, header)
+	}
+
+	resource, ok := describeResources[header.Kind]
+	if !ok {
+		return nil, false, nil
+	}
+	opt := &describe.Options{
+		Resource:  resource,
+		Namespace: namespace,
+		Name:      name,
+	}
+	switch header.Kind {
+	case config.MicroserviceKind:
+		opt.Name = microserviceFQName(header)
+	case config.ServiceAccountKind:
+		if header.Metadata.ApplicationName != "" {
+			opt.Name = header.Metadata.ApplicationName + "/" + name
+		}
+	case config.EdgeResourceKind:
+		if spec, ok := header.Spec.(map[interface{}]interface{}); ok && spec["version"] != nil {
+			opt.Version = fmt.Sprintf("%v", spec["version"])
+		}
+	}
+	liveHeader, err := describe.GetHeader(opt)
+	if err != nil {
+		if isNotFound(err) {
+			return &config.Header{}, false, nil
+		}
+		return nil, false, err
+	}
+	return &liveHeader, true, nil
+}
+
+func getLiveNatsRule(namespace string, header *config.Header) (*config.Header, bool, error) {
+	clt, err := clientutil.NewControllerClient(namespace)
+	if err != nil {
+		return nil, false, err
+	}
+	var rules []client.NatsRuleInfo
+	if header.Kind == config.NatsAccountRuleKind {
+		response, err := clt.ListNatsAccountRules()
+		if err != nil {
+			return nil, false, err
+		}
+		rules = response.Rules
+	} else {
+		response, err := clt.ListNatsUserRules()
+		if err != nil {
+			return nil, false, err
+		}
+		rules = response.Rules
+	}
+	for idx := range rules {
+		if rules[idx].Name != header.Metadata.Name {
+			continue
+		}
+		spec, err := describe.NatsSpec(rules[idx], describe.NatsRuleIdentityFields...)
+		if err != nil {
+			return nil, false, err
+		}
+		return &config.Header{Kind: header.Kind, Spec: spec}, true, nil
+	}
+	return &config.Header{}, false, nil
+}
+
+// ResourceName returns the name that deletes the resource of a document, qualified by its Application when
+// the resource is scoped to one
+func ResourceName(header *config.Header) string {
+	switch header.Kind {
+	case config.MicroserviceKind:
+		return microserviceFQName(header)
+	case config.ServiceAccountKind:
+		if app := header.Metadata.ApplicationName; app != "" && !strings.Contains(header.Metadata.Name, "/") {
+			return app + "/" + header.Metadata.Name
+		}
+	}
+	return header.Metadata.Name
+}
+
+func microserviceFQName(header *config.Header) string {
+	name := header.Metadata.Name
+	if strings.Contains(name, "/") {
+		return name
+	}
+	if spec, ok := header.Spec.(map[interface{}]interface{}); ok {
+		if app, ok := spec["application"].(string); ok && app != "" {
+			return app + "/" + name
+		}
+	}
+	return name
+}
+
+func isNotFound(err error) bool {
+	// Resources of a Namespace without Control Plane are all yet to be created
+	if rsc.IsNoControlPlaneError(err) {
+		return true
+	}
+	switch e := err.(type) {
+	case *util.NotFoundError, *client.NotFoundError:
+		return true
+	case *client.HTTPError:
+		return e.Code == 404
+	}
+	return false
+}
+
+// comparableDocument keeps the parts of a document that deploy applies, in a generic form so that
+// documents from files and from typed resources marshal the same way
+func comparableDocument(kind config.Kind, header *config.Header) (map[interface{}]interface{}, error) {
+	doc := map[string]interface{}{}
+	if header.Spec != nil {
+		spec, err := normalizeSpec(kind, header.Spec)
+		if err != nil {
+			return nil, err
+		}
+		doc["spec"] = spec
+	}
+	if header.Data != nil {
+		doc["data"] = header.Data
+	}
+	b, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	generic := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(b, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// normalizeSpec round-trips the spec through the typed resource of the kind, if any. Fields the resource does not
+// know are dropped on both sides, as deploy ignores them too.
+func normalizeSpec(kind config.Kind, spec interface{}) (interface{}, error) {
+	newTyped, ok := typedSpecs[kind]
+	if !ok {
+		return spec, nil
+	}
+	b, err := yaml.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	typed := newTyped()
+	if err := yaml.Unmarshal(b, typed); err != nil {
+		return nil, err
+	}
+	return typed, nil
+}
+
+// prune removes from live every map key that is absent from desired
+func prune(live, desired interface{}) interface{} {
+	switch desiredValue := desired.(type) {
+	case map[interface{}]interface{}:
+		liveMap, ok := live.(map[interface{}]interface{})
+		if !ok {
+			return live
+		}
+		pruned := map[interface{}]interface{}{}
+		for key, value := range desiredValue {
+			if liveValue, exists := liveMap[key]; exists {
+				pruned[key] = prune(liveValue, value)
+			}
+		}
+		return pruned
+	case []interface{}:
+		liveSlice, ok := live.([]interface{})
+		if !ok || len(liveSlice) != len(desiredValue) {
+			return live
+		}
+		pruned := make([]interface{}, len(liveSlice))
+		for idx := range liveSlice {
+			pruned[idx] = prune(liveSlice[idx], desiredValue[idx])
+		}
+		return pruned
+	}
+	return live
+}
+
+func printDiffResults(writer io.Writer, results []DiffResult) error {
+	counts := map[string]int{}
+	table := [][]string{{"KIND", "NAME", "ACTION"}}
+	for _, result := range results {
+		if result.Diff != "" {
+			if _, err := fmt.Fprintln(writer, result.Diff); err != nil {
+				return err
+			}
+		}
+		counts[result.Action]++
+		table = append(table, []string{string(result.Kind), result.Name, result.Action})
+	}
+	if err := printer.PrintTable(writer, table); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, "%d to create, %d to update, %d unchanged, %d not compared\n",
+		counts[DiffCreate], counts[DiffUpdate], counts[DiffUnchanged], counts[DiffNotCompared])
+	return err
+}
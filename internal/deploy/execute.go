@@ -15,6 +15,7 @@ package deploy
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
 	"github.com/datasance/potctl/internal/config"
@@ -75,11 +76,18 @@ var kindOrder = []config.Kind{
 	config.ServiceKind,
 }
 
+// KindOrder returns the kinds in the order they are deployed, after the Control Plane and Agent configurations
+func KindOrder() []config.Kind {
+	return append([]config.Kind{}, kindOrder...)
+}
+
 type Options struct {
 	Namespace    string
-	InputFile    string
+	InputFiles   []string
+	Recursive    bool
 	NoCache      bool
 	TransferPool int
+	DryRun       bool
 }
 
 func deployEdgeResource(opt *execute.KindHandlerOpt) (exe execute.Executor, err error) {
@@ -194,8 +202,13 @@ func deployNatsUserRule(opt *execute.KindHandlerOpt) (exe execute.Executor, err
 
 // Execute deploy from yaml file
 func Execute(opt *Options) (err error) {
+	if opt.DryRun {
+		_, err = Diff(opt, os.Stdout)
+		return err
+	}
+
 	kindHandlers := buildKindHandlers(opt.NoCache, opt.TransferPool)
-	executorsMap, err := execute.GetExecutorsFromYAML(opt.InputFile, opt.Namespace, kindHandlers)
+	executorsMap, err := execute.GetExecutorsFromYAML(opt.InputFiles, opt.Recursive, opt.Namespace, kindHandlers)
 	if err != nil {
 		return err
 	}
@@ -314,7 +327,7 @@ func Execute(opt *Options) (err error) {
 	cpCount := 0
 	errMsg := "Specified multiple Control Planes in a single Namespace"
 	if exe, exists := executorsMap[config.KubernetesControlPlaneKind]; exists {
-		if errs := execute.RunExecutors(exe, "deploy Kubernetes Control Plane"); len(errs) > 0 {
+		if errs := execute.RunKindExecutors(exe, config.KubernetesControlPlaneKind, "deploy Kubernetes Control Plane"); len(errs) > 0 {
 			return execute.CoalesceErrors(errs)
 		}
 		cpCount++
@@ -323,7 +336,7 @@ func Execute(opt *Options) (err error) {
 		if cpCount > 0 {
 			err = util.NewInputError(errMsg)
 		}
-		if errs := execute.RunExecutors(exe, "deploy Remote Control Plane"); len(errs) > 0 {
+		if errs := execute.RunKindExecutors(exe, config.RemoteControlPlaneKind, "deploy Remote Control Plane"); len(errs) > 0 {
 			return execute.CoalesceErrors(errs)
 		}
 		cpCount++
@@ -332,13 +345,13 @@ func Execute(opt *Options) (err error) {
 		if cpCount > 0 {
 			err = util.NewInputError(errMsg)
 		}
-		if errs := execute.RunExecutors(exe, "deploy Local Control Plane"); len(errs) > 0 {
+		if errs := execute.RunKindExecutors(exe, config.LocalControlPlaneKind, "deploy Local Control Plane"); len(errs) > 0 {
 			return execute.CoalesceErrors(errs)
 		}
 	}
 
 	// Controllers
-	if errs := execute.RunExecutors(executorsMap[config.LocalControllerKind], "deploy local controller"); len(errs) > 0 {
+	if errs := execute.RunKindExecutors(executorsMap[config.LocalControllerKind], config.LocalControllerKind, "deploy local controller"); len(errs) > 0 {
 		return execute.CoalesceErrors(errs)
 	}
 
@@ -350,7 +363,7 @@ func Execute(opt *Options) (err error) {
 	// Execute in parallel by priority order
 	// Edge Resources, Agents, Volumes, CatalogItem, Application, Microservice, Route
 	for idx := range kindOrder {
-		if errs := execute.RunExecutors(executorsMap[kindOrder[idx]], fmt.Sprintf("deploy %s", kindOrder[idx])); len(errs) > 0 {
+		if errs := execute.RunKindExecutors(executorsMap[kindOrder[idx]], kindOrder[idx], fmt.Sprintf("deploy %s", kindOrder[idx])); len(errs) > 0 {
 			return execute.CoalesceErrors(errs)
 		}
 	}
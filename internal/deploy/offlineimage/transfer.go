@@ -16,6 +16,7 @@ func transferArtifact(plan agentPlan, artifact *imageArtifact) error {
 		return err
 	}
 	ssh.SetPort(plan.agent.SSH.Port)
+	ssh.SetOptions(plan.agent.SSH.Options())
 	if err := ssh.Connect(); err != nil {
 		return err
 	}
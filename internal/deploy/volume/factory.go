@@ -87,7 +87,7 @@ func (exe *executor) Execute() error {
 			ns:     ns,
 		})
 	}
-	if errs := execute.RunExecutors(executors, exe.GetName()); len(errs) > 0 {
+	if errs := execute.RunKindExecutors(executors, config.VolumeKind, exe.GetName()); len(errs) > 0 {
 		return execute.CoalesceErrors(errs)
 	}
 	return nil
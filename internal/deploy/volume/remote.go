@@ -59,6 +59,10 @@ func (exe *remoteExecutor) execute(agentIdx int, ch chan error) {
 		ch <- fmt.Errorf(msg, agent.Name, err.Error())
 		return
 	}
+	if agent.SSH.Port != 0 {
+		ssh.SetPort(agent.SSH.Port)
+	}
+	ssh.SetOptions(agent.SSH.Options())
 	if err := ssh.Connect(); err != nil {
 		msg := "failed to Connect to Agent %s.\n%s"
 		ch <- fmt.Errorf(msg, agent.Name, err.Error())
@@ -18,7 +18,6 @@ import (
 
 	"github.com/datasance/potctl/internal/config"
 	rsc "github.com/datasance/potctl/internal/resource"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type agentExecutor struct {
@@ -41,25 +40,34 @@ func (exe *agentExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *agentExecutor) Execute() (err error) {
+func (exe *agentExecutor) Execute() error {
+	header, err := exe.getHeader()
+	if err != nil {
+		return err
+	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *agentExecutor) getHeader() (config.Header, error) {
+	var err error
 	var agent rsc.Agent
 	if exe.useDetached {
 		agent, err = config.GetDetachedAgent(exe.name)
 		if err != nil {
-			return err
+			return config.Header{}, err
 		}
 	} else {
 		ns, err := config.GetNamespace(exe.namespace)
 		if err != nil {
-			return err
+			return config.Header{}, err
 		}
 		// Update local cache based on Controller
 		if err := clientutil.SyncAgentInfo(exe.namespace); err != nil {
-			return err
+			return config.Header{}, err
 		}
 		agent, err = ns.GetAgent(exe.name)
 		if err != nil {
-			return err
+			return config.Header{}, err
 		}
 	}
 
@@ -71,7 +79,7 @@ func (exe *agentExecutor) Execute() (err error) {
 		// Get Agent configuration
 		agentConfig, tags, agentStatus, err = clientutil.GetAgentConfig(exe.name, exe.namespace)
 		if err != nil {
-			return err
+			return config.Header{}, err
 		}
 		agent.SetConfig(&agentConfig)
 	}
@@ -99,14 +107,5 @@ func (exe *agentExecutor) Execute() (err error) {
 		Status: formattedStatus,
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
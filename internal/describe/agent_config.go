@@ -17,7 +17,6 @@ import (
 	clientutil "github.com/datasance/potctl/internal/util/client"
 
 	"github.com/datasance/potctl/internal/config"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type agentConfigExecutor struct {
@@ -39,10 +38,18 @@ func (exe *agentConfigExecutor) GetName() string {
 }
 
 func (exe *agentConfigExecutor) Execute() error {
-	agentConfig, tags, agentStatus, err := clientutil.GetAgentConfig(exe.name, exe.namespace)
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *agentConfigExecutor) getHeader() (config.Header, error) {
+	agentConfig, tags, agentStatus, err := clientutil.GetAgentConfig(exe.name, exe.namespace)
+	if err != nil {
+		return config.Header{}, err
+	}
 
 	// Format agent status for human-readable output
 	formattedStatus := FormatAgentStatus(agentStatus)
@@ -59,14 +66,5 @@ func (exe *agentConfigExecutor) Execute() error {
 		Status: formattedStatus,
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
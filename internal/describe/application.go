@@ -91,9 +91,17 @@ func (exe *applicationExecutor) GetName() string {
 }
 
 func (exe *applicationExecutor) Execute() error {
+	header, err := exe.getHeader()
+	if err != nil {
+		return err
+	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *applicationExecutor) getHeader() (config.Header, error) {
 	// Fetch data
 	if err := exe.init(); err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	yamlMsvcs := []rsc.Microservice{}
@@ -101,7 +109,7 @@ func (exe *applicationExecutor) Execute() error {
 	for idx := range exe.msvcs {
 		yamlMsvc, _, _, err := MapClientMicroserviceToDeployMicroservice(exe.msvcs[idx], exe.client)
 		if err != nil {
-			return err
+			return config.Header{}, err
 		}
 		// Remove fields
 		yamlMsvc.Flow = nil
@@ -131,14 +139,5 @@ func (exe *applicationExecutor) Execute() error {
 		Spec: application,
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
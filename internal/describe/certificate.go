@@ -17,7 +17,6 @@ import (
 	"github.com/datasance/potctl/internal/config"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type certificateExecutor struct {
@@ -39,16 +38,24 @@ func (exe *certificateExecutor) GetName() string {
 }
 
 func (exe *certificateExecutor) Execute() error {
+	header, err := exe.getHeader()
+	if err != nil {
+		return err
+	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *certificateExecutor) getHeader() (config.Header, error) {
 	// Init remote resources
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	// Get secret from Controller
 	certificate, err := clt.GetCertificate(exe.name)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	var headerKind string
@@ -103,14 +110,5 @@ func (exe *certificateExecutor) Execute() error {
 		Data: headerData,
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
@@ -105,16 +105,39 @@ func printConfigMapWithLiteralStrings(header config.Header, writer io.Writer) er
 }
 
 func (exe *configMapExecutor) Execute() error {
+	header, err := exe.getHeader()
+	if err != nil {
+		return err
+	}
+
+	if exe.filename == "" {
+		if err := printConfigMapWithLiteralStrings(header, os.Stdout); err != nil {
+			return err
+		}
+	} else {
+		f, err := os.Create(exe.filename)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := printConfigMapWithLiteralStrings(header, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (exe *configMapExecutor) getHeader() (config.Header, error) {
 	// Init remote resources
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	// Get secret from Controller
 	configMap, err := clt.GetConfigMap(exe.name)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	header := config.Header{
@@ -130,19 +153,5 @@ func (exe *configMapExecutor) Execute() error {
 		Data: configMap.Data,
 	}
 
-	if exe.filename == "" {
-		if err := printConfigMapWithLiteralStrings(header, os.Stdout); err != nil {
-			return err
-		}
-	} else {
-		f, err := os.Create(exe.filename)
-		if err != nil {
-			return err
-		}
-		defer f.Close()
-		if err := printConfigMapWithLiteralStrings(header, f); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
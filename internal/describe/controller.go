@@ -38,17 +38,25 @@ func (exe *controllerExecutor) GetName() string {
 }
 
 func (exe *controllerExecutor) Execute() error {
-	ns, err := config.GetNamespace(exe.namespace)
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *controllerExecutor) getHeader() (config.Header, error) {
+	ns, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return config.Header{}, err
+	}
 	controlPlane, err := ns.GetControlPlane()
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 	baseController, err := controlPlane.GetController(exe.name)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	// Generate header
@@ -61,19 +69,10 @@ func (exe *controllerExecutor) Execute() error {
 	case *rsc.LocalController:
 		header = exe.generateControllerHeader(config.LocalControllerKind, controller)
 	default:
-		return util.NewInternalError("Could not convert Control Plane to dynamic type")
+		return config.Header{}, util.NewInternalError("Could not convert Control Plane to dynamic type")
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
 
 func (exe *controllerExecutor) generateControllerHeader(kind config.Kind, controller rsc.Controller) config.Header {
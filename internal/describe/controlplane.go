@@ -36,13 +36,21 @@ func (exe *controlPlaneExecutor) GetName() string {
 }
 
 func (exe *controlPlaneExecutor) Execute() error {
-	ns, err := config.GetNamespace(exe.namespace)
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *controlPlaneExecutor) getHeader() (config.Header, error) {
+	ns, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return config.Header{}, err
+	}
 	baseControlPlane, err := ns.GetControlPlane()
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	// Generate header
@@ -55,19 +63,10 @@ func (exe *controlPlaneExecutor) Execute() error {
 	case *rsc.LocalControlPlane:
 		header = exe.generateControlPlaneHeader(config.LocalControlPlaneKind, controlPlane)
 	default:
-		return util.NewInternalError("Could not convert Control Plane to dynamic type")
+		return config.Header{}, util.NewInternalError("Could not convert Control Plane to dynamic type")
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
 
 func (exe *controlPlaneExecutor) generateControlPlaneHeader(kind config.Kind, controlPlane rsc.ControlPlane) config.Header {
@@ -19,7 +19,6 @@ import (
 	"github.com/datasance/potctl/internal/config"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type edgeResourceExecutor struct {
@@ -43,21 +42,29 @@ func (exe *edgeResourceExecutor) GetName() string {
 }
 
 func (exe *edgeResourceExecutor) Execute() error {
-	_, err := config.GetNamespace(exe.namespace)
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *edgeResourceExecutor) getHeader() (config.Header, error) {
+	_, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return config.Header{}, err
+	}
 
 	// Connect to Controller
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	// Get Edge Resource
 	edge, err := clt.GetHTTPEdgeResourceByName(exe.name, exe.version)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	// Convert to YAML
@@ -80,14 +87,5 @@ func (exe *edgeResourceExecutor) Execute() error {
 		},
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
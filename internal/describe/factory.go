@@ -16,7 +16,9 @@ package describe
 import (
 	"fmt"
 
+	"github.com/datasance/potctl/internal/config"
 	"github.com/datasance/potctl/internal/execute"
+	"github.com/datasance/potctl/internal/util/watch"
 	"github.com/datasance/potctl/pkg/util"
 )
 
@@ -27,9 +29,26 @@ type Options struct {
 	Filename   string
 	IsDetached bool
 	Version    string
+	// Watch reprints the resource whenever it changes when set
+	Watch *watch.Options
 }
 
 func NewExecutor(opt *Options) (execute.Executor, error) {
+	exe, err := newExecutor(opt)
+	if err != nil || opt.Watch == nil {
+		return exe, err
+	}
+	if opt.Filename != "" {
+		return nil, util.NewInputError("Cannot watch a resource while writing it to an output file")
+	}
+	headerExe, ok := exe.(headerExecutor)
+	if !ok {
+		return nil, util.NewInputError(fmt.Sprintf("Resource %s does not support --watch", opt.Resource))
+	}
+	return newWatchExecutor(opt, headerExe), nil
+}
+
+func newExecutor(opt *Options) (execute.Executor, error) {
 	switch opt.Resource {
 	case "namespace":
 		return newNamespaceExecutor(opt.Namespace, opt.Filename), nil
@@ -75,3 +94,28 @@ func NewExecutor(opt *Options) (execute.Executor, error) {
 		return nil, util.NewInputError(fmt.Sprintf("Unknown resources: %s", opt.Resource))
 	}
 }
+
+// headerExecutor is implemented by executors that describe a resource as a single YAML document
+type headerExecutor interface {
+	getHeader() (config.Header, error)
+}
+
+// GetHeader returns the document that describe would print for the resource, without printing it
+func GetHeader(opt *Options) (config.Header, error) {
+	exe, err := newExecutor(opt)
+	if err != nil {
+		return config.Header{}, err
+	}
+	headerExe, ok := exe.(headerExecutor)
+	if !ok {
+		return config.Header{}, util.NewInputError(fmt.Sprintf("Cannot describe %s as a YAML document", opt.Resource))
+	}
+	return headerExe.getHeader()
+}
+
+func printHeader(header config.Header, filename string) error {
+	if filename == "" {
+		return util.Print(header)
+	}
+	return util.FPrint(header, filename)
+}
@@ -56,42 +56,21 @@ func (exe *microserviceExecutor) GetName() string {
 }
 
 func (exe *microserviceExecutor) Execute() error {
-	// Fetch data
-	if err := exe.init(); err != nil {
+	header, err := exe.getHeader()
+	if err != nil {
 		return err
 	}
-
 	if util.IsSystemMsvc(exe.msvc) {
 		return nil
 	}
+	return printHeader(header, exe.filename)
+}
 
-	yamlMsvc, status, execStatus, err := MapClientMicroserviceToDeployMicroservice(exe.msvc, exe.client)
-	if err != nil {
-		return err
-	}
-
-	header := config.Header{
-		APIVersion: config.LatestAPIVersion,
-		Kind:       config.MicroserviceKind,
-		Metadata: config.HeaderMetadata{
-			Namespace: exe.namespace,
-			Name:      exe.name,
-		},
-		Spec: yamlMsvc,
-		Status: map[string]interface{}{
-			"status":     FormatMicroserviceStatus(status),
-			"execStatus": FormatMicroserviceExecStatus(execStatus),
-		},
+func (exe *microserviceExecutor) getHeader() (config.Header, error) {
+	// Fetch data
+	if err := exe.init(); err != nil {
+		return config.Header{}, err
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return MicroserviceHeader(exe.namespace, exe.name, exe.msvc, exe.client)
 }
@@ -0,0 +1,39 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package describe
+
+import (
+	"encoding/json"
+)
+
+// NatsRuleIdentityFields are the NATS rule fields carried by metadata rather than spec
+var NatsRuleIdentityFields = []string{"id", "name", "isSystem"}
+
+// NatsSpec converts a NATS object returned by the Controller into a spec keyed by its JSON field names.
+// Fields listed in omit are dropped, e.g. identity fields that belong in metadata.
+func NatsSpec(obj interface{}, omit ...string) (map[string]interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := map[string]interface{}{}
+	if err := json.Unmarshal(b, &spec); err != nil {
+		return nil, err
+	}
+	for _, key := range omit {
+		delete(spec, key)
+	}
+	return spec, nil
+}
@@ -20,7 +20,6 @@ import (
 	clientutil "github.com/datasance/potctl/internal/util/client"
 
 	"github.com/datasance/potctl/internal/config"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type registryExecutor struct {
@@ -46,15 +45,23 @@ func (exe *registryExecutor) GetName() string {
 }
 
 func (exe *registryExecutor) Execute() error {
+	header, err := exe.getHeader()
+	if err != nil {
+		return err
+	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *registryExecutor) getHeader() (config.Header, error) {
 	// Connect to controller
 	ctrl, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	r, err := ctrl.GetRegistry(exe.id)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	private := !r.IsPublic
@@ -76,14 +83,5 @@ func (exe *registryExecutor) Execute() error {
 		Spec: registry,
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
@@ -18,7 +18,6 @@ import (
 	"github.com/datasance/potctl/internal/config"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type roleExecutor struct {
@@ -40,42 +39,42 @@ func (exe *roleExecutor) GetName() string {
 }
 
 func (exe *roleExecutor) Execute() error {
-	clt, err := clientutil.NewControllerClient(exe.namespace)
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
 
-	role, err := clt.GetRole(exe.name)
+func (exe *roleExecutor) getHeader() (config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
-	spec := rsc.Role{
-		Name:  role.Name,
-		Kind:  role.Kind,
-		Rules: convertRules(role.Rules),
+	role, err := clt.GetRole(exe.name)
+	if err != nil {
+		return config.Header{}, err
 	}
 
-	header := config.Header{
+	return RoleHeader(exe.namespace, role.Name, role.Kind, role.Rules), nil
+}
+
+// RoleHeader builds the Role document from the fields the Controller returns
+func RoleHeader(namespace, name, kind string, rules []client.RBACRule) config.Header {
+	return config.Header{
 		APIVersion: config.LatestAPIVersion,
 		Kind:       config.RoleKind,
 		Metadata: config.HeaderMetadata{
-			Namespace: exe.namespace,
-			Name:      exe.name,
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: rsc.Role{
+			Name:  name,
+			Kind:  kind,
+			Rules: convertRules(rules),
 		},
-		Spec: spec,
-	}
-
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
 	}
-	return nil
 }
 
 func convertRules(rules []client.RBACRule) []rsc.RBACRule {
@@ -18,7 +18,6 @@ import (
 	"github.com/datasance/potctl/internal/config"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type roleBindingExecutor struct {
@@ -40,43 +39,43 @@ func (exe *roleBindingExecutor) GetName() string {
 }
 
 func (exe *roleBindingExecutor) Execute() error {
-	clt, err := clientutil.NewControllerClient(exe.namespace)
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
 
-	binding, err := clt.GetRoleBinding(exe.name)
+func (exe *roleBindingExecutor) getHeader() (config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
-	spec := rsc.RoleBinding{
-		Name:     binding.Name,
-		Kind:     binding.Kind,
-		RoleRef:  convertRoleRef(binding.RoleRef),
-		Subjects: convertSubjects(binding.Subjects),
+	binding, err := clt.GetRoleBinding(exe.name)
+	if err != nil {
+		return config.Header{}, err
 	}
 
-	header := config.Header{
+	return RoleBindingHeader(exe.namespace, binding.Name, binding.Kind, binding.RoleRef, binding.Subjects), nil
+}
+
+// RoleBindingHeader builds the RoleBinding document from the fields the Controller returns
+func RoleBindingHeader(namespace, name, kind string, roleRef client.RoleRef, subjects []client.Subject) config.Header {
+	return config.Header{
 		APIVersion: config.LatestAPIVersion,
 		Kind:       config.RoleBindingKind,
 		Metadata: config.HeaderMetadata{
-			Namespace: exe.namespace,
-			Name:      exe.name,
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: rsc.RoleBinding{
+			Name:     name,
+			Kind:     kind,
+			RoleRef:  convertRoleRef(roleRef),
+			Subjects: convertSubjects(subjects),
 		},
-		Spec: spec,
-	}
-
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
 	}
-	return nil
 }
 
 func convertRoleRef(ref client.RoleRef) rsc.RoleRef {
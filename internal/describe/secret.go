@@ -17,7 +17,6 @@ import (
 	"github.com/datasance/potctl/internal/config"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type secretExecutor struct {
@@ -39,16 +38,24 @@ func (exe *secretExecutor) GetName() string {
 }
 
 func (exe *secretExecutor) Execute() error {
+	header, err := exe.getHeader()
+	if err != nil {
+		return err
+	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *secretExecutor) getHeader() (config.Header, error) {
 	// Init remote resources
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	// Get secret from Controller
 	secret, err := clt.GetSecret(exe.name)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	header := config.Header{
@@ -64,14 +71,5 @@ func (exe *secretExecutor) Execute() error {
 		Data: secret.Data,
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
@@ -17,7 +17,6 @@ import (
 	"github.com/datasance/potctl/internal/config"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type serviceExecutor struct {
@@ -39,52 +38,53 @@ func (exe *serviceExecutor) GetName() string {
 }
 
 func (exe *serviceExecutor) Execute() error {
+	header, err := exe.getHeader()
+	if err != nil {
+		return err
+	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *serviceExecutor) getHeader() (config.Header, error) {
 	// Init remote resources
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	// Get service from Controller
 	service, err := clt.GetService(exe.name)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
-	// Convert tags to pointer
-	var tags *[]string
-	if len(service.Tags) > 0 {
-		tags = &service.Tags
+	return ServiceHeader(exe.namespace, exe.name, service.Tags, rsc.ClusterService{
+		Type:            service.Type,
+		Resource:        service.Resource,
+		TargetPort:      service.TargetPort,
+		BridgePort:      service.BridgePort,
+		DefaultBridge:   service.DefaultBridge,
+		K8sType:         service.K8sType,
+		ServiceEndpoint: service.ServiceEndpoint,
+		ServicePort:     service.ServicePort,
+	}), nil
+}
+
+// ServiceHeader builds the Service document, omitting tags when there are none
+func ServiceHeader(namespace, name string, tags []string, spec rsc.ClusterService) config.Header {
+	var metaTags *[]string
+	if len(tags) > 0 {
+		metaTags = &tags
 	}
 
-	header := config.Header{
+	return config.Header{
 		APIVersion: config.LatestAPIVersion,
 		Kind:       config.ServiceKind,
 		Metadata: config.HeaderMetadata{
-			Namespace: exe.namespace,
-			Name:      exe.name,
-			Tags:      tags,
+			Namespace: namespace,
+			Name:      name,
+			Tags:      metaTags,
 		},
-		Spec: rsc.ClusterService{
-			Type:            service.Type,
-			Resource:        service.Resource,
-			TargetPort:      service.TargetPort,
-			BridgePort:      service.BridgePort,
-			DefaultBridge:   service.DefaultBridge,
-			K8sType:         service.K8sType,
-			ServiceEndpoint: service.ServiceEndpoint,
-			ServicePort:     service.ServicePort,
-		},
-	}
-
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
+		Spec: spec,
 	}
-	return nil
 }
@@ -16,6 +16,7 @@ package describe
 import (
 	"strings"
 
+	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
 	"github.com/datasance/potctl/internal/config"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
@@ -55,45 +56,45 @@ func (exe *serviceAccountExecutor) GetName() string {
 }
 
 func (exe *serviceAccountExecutor) Execute() error {
-	clt, err := clientutil.NewControllerClient(exe.namespace)
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *serviceAccountExecutor) getHeader() (config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return config.Header{}, err
+	}
 
 	if exe.appName == "" {
-		return util.NewInputError("ServiceAccount is application-scoped: use APPLICATION_NAME/SERVICE_ACCOUNT_NAME (e.g. myapp/my-sa)")
+		return config.Header{}, util.NewInputError("ServiceAccount is application-scoped: use APPLICATION_NAME/SERVICE_ACCOUNT_NAME (e.g. myapp/my-sa)")
 	}
 
 	sa, err := clt.GetServiceAccount(exe.appName, exe.name)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
-	spec := rsc.ServiceAccount{
-		Name:            sa.Name,
-		ApplicationName: sa.ApplicationName,
-		RoleRef:         convertRoleRef(sa.RoleRef),
-	}
+	return ServiceAccountHeader(exe.namespace, sa.Name, sa.ApplicationName, sa.RoleRef), nil
+}
 
-	header := config.Header{
+// ServiceAccountHeader builds the ServiceAccount document from the fields the Controller returns
+func ServiceAccountHeader(namespace, name, appName string, roleRef client.RoleRef) config.Header {
+	return config.Header{
 		APIVersion: config.LatestAPIVersion,
 		Kind:       config.ServiceAccountKind,
 		Metadata: config.HeaderMetadata{
-			Namespace:       exe.namespace,
-			Name:            exe.name,
-			ApplicationName: sa.ApplicationName,
+			Namespace:       namespace,
+			Name:            name,
+			ApplicationName: appName,
+		},
+		Spec: rsc.ServiceAccount{
+			Name:            name,
+			ApplicationName: appName,
+			RoleRef:         convertRoleRef(roleRef),
 		},
-		Spec: spec,
-	}
-
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
 	}
-	return nil
 }
@@ -17,7 +17,6 @@ import (
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
 	"github.com/datasance/potctl/internal/config"
 	clientutil "github.com/datasance/potctl/internal/util/client"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type systemMicroserviceExecutor struct {
@@ -56,42 +55,18 @@ func (exe *systemMicroserviceExecutor) GetName() string {
 }
 
 func (exe *systemMicroserviceExecutor) Execute() error {
-	// Fetch data
-	if err := exe.init(); err != nil {
-		return err
-	}
-
-	// if util.IsSystemMsvc(exe.msvc) == false {
-	// 	return nil
-	// }
-
-	yamlMsvc, status, execStatus, err := MapClientMicroserviceToDeployMicroservice(exe.msvc, exe.client)
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
 
-	header := config.Header{
-		APIVersion: config.LatestAPIVersion,
-		Kind:       config.MicroserviceKind,
-		Metadata: config.HeaderMetadata{
-			Namespace: exe.namespace,
-			Name:      exe.name,
-		},
-		Spec: yamlMsvc,
-		Status: map[string]interface{}{
-			"status":     FormatMicroserviceStatus(status),
-			"execStatus": FormatMicroserviceExecStatus(execStatus),
-		},
+func (exe *systemMicroserviceExecutor) getHeader() (config.Header, error) {
+	// Fetch data
+	if err := exe.init(); err != nil {
+		return config.Header{}, err
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return MicroserviceHeader(exe.namespace, exe.name, exe.msvc, exe.client)
 }
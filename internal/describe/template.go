@@ -16,7 +16,6 @@ package describe
 import (
 	"github.com/datasance/potctl/internal/config"
 	clientutil "github.com/datasance/potctl/internal/util/client"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type applicationTemplateExecutor struct {
@@ -38,34 +37,36 @@ func (exe *applicationTemplateExecutor) GetName() string {
 }
 
 func (exe *applicationTemplateExecutor) Execute() error {
-	clt, err := clientutil.NewControllerClient(exe.namespace)
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *applicationTemplateExecutor) getHeader() (config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return config.Header{}, err
+	}
 
 	template, err := clt.GetApplicationTemplate(exe.name)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
-	header := config.Header{
+	return ApplicationTemplateHeader(exe.namespace, exe.name, template), nil
+}
+
+// ApplicationTemplateHeader builds the document for an application template as the Controller returns it
+func ApplicationTemplateHeader(namespace, name string, template interface{}) config.Header {
+	return config.Header{
 		APIVersion: config.LatestAPIVersion,
 		Kind:       config.ApplicationKind,
 		Metadata: config.HeaderMetadata{
-			Namespace: exe.namespace,
-			Name:      exe.name,
+			Namespace: namespace,
+			Name:      name,
 		},
 		Spec: template,
 	}
-
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
 }
@@ -19,6 +19,7 @@ import (
 
 	apps "github.com/datasance/iofog-go-sdk/v3/pkg/apps"
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
 	rsc "github.com/datasance/potctl/internal/resource"
 	"github.com/datasance/potctl/pkg/util"
 	// "github.com/datasance/potctl/pkg/iofog"
@@ -419,3 +420,46 @@ func formatNumber(num int64) string {
 	}
 	return fmt.Sprintf("%.1fB", float64(num)/1000000000)
 }
+
+// GetStatus returns the status of the Agent or Microservice described by the header, along with the
+// accompanying error or warning message. The status is empty for other resources.
+func GetStatus(header config.Header) (status, message string) {
+	fields, ok := header.Status.(map[string]interface{})
+	if !ok {
+		return "", ""
+	}
+	if daemonStatus, ok := fields["daemonStatus"].(string); ok {
+		message, _ = fields["warningMessage"].(string)
+		return daemonStatus, message
+	}
+	switch value := fields["status"].(type) {
+	case string:
+		return value, ""
+	case map[string]interface{}:
+		status, _ = value["status"].(string)
+		message, _ = value["errorMessage"].(string)
+	}
+	return status, message
+}
+
+// MicroserviceHeader builds the Microservice document for msvc, which is named by its fully qualified name
+func MicroserviceHeader(namespace, name string, msvc *client.MicroserviceInfo, clt *client.Client) (config.Header, error) {
+	yamlMsvc, status, execStatus, err := MapClientMicroserviceToDeployMicroservice(msvc, clt)
+	if err != nil {
+		return config.Header{}, err
+	}
+
+	return config.Header{
+		APIVersion: config.LatestAPIVersion,
+		Kind:       config.MicroserviceKind,
+		Metadata: config.HeaderMetadata{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: yamlMsvc,
+		Status: map[string]interface{}{
+			"status":     FormatMicroserviceStatus(status),
+			"execStatus": FormatMicroserviceExecStatus(execStatus),
+		},
+	}, nil
+}
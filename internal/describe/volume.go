@@ -15,7 +15,6 @@ package describe
 
 import (
 	"github.com/datasance/potctl/internal/config"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type volumeExecutor struct {
@@ -36,14 +35,22 @@ func (exe *volumeExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *volumeExecutor) Execute() (err error) {
-	ns, err := config.GetNamespace(exe.namespace)
+func (exe *volumeExecutor) Execute() error {
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *volumeExecutor) getHeader() (config.Header, error) {
+	ns, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return config.Header{}, err
+	}
 	volume, err := ns.GetVolume(exe.name)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	header := config.Header{
@@ -56,14 +63,5 @@ func (exe *volumeExecutor) Execute() (err error) {
 		Spec: volume,
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
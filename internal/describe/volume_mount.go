@@ -17,7 +17,6 @@ import (
 	"github.com/datasance/potctl/internal/config"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type volumeMountExecutor struct {
@@ -39,42 +38,44 @@ func (exe *volumeMountExecutor) GetName() string {
 }
 
 func (exe *volumeMountExecutor) Execute() error {
+	header, err := exe.getHeader()
+	if err != nil {
+		return err
+	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *volumeMountExecutor) getHeader() (config.Header, error) {
 	// Init remote resources
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
 	// Get Volume Mount from Controller
 	volumeMount, err := clt.GetVolumeMount(exe.name)
 	if err != nil {
-		return err
+		return config.Header{}, err
 	}
 
-	header := config.Header{
+	return VolumeMountHeader(exe.namespace, rsc.VolumeMount{
+		Name:          volumeMount.Name,
+		UUID:          volumeMount.UUID,
+		ConfigMapName: volumeMount.ConfigMapName,
+		SecretName:    volumeMount.SecretName,
+		Version:       volumeMount.Version,
+	}), nil
+}
+
+// VolumeMountHeader builds the VolumeMount document for spec
+func VolumeMountHeader(namespace string, spec rsc.VolumeMount) config.Header {
+	return config.Header{
 		APIVersion: config.LatestAPIVersion,
 		Kind:       config.VolumeMountKind,
 		Metadata: config.HeaderMetadata{
-			Namespace: exe.namespace,
-			Name:      exe.name,
+			Namespace: namespace,
+			Name:      spec.Name,
 		},
-		Spec: rsc.VolumeMount{
-			Name:          volumeMount.Name,
-			UUID:          volumeMount.UUID,
-			ConfigMapName: volumeMount.ConfigMapName,
-			SecretName:    volumeMount.SecretName,
-			Version:       volumeMount.Version,
-		},
-	}
-
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
+		Spec: spec,
 	}
-	return nil
 }
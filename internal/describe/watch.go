@@ -0,0 +1,95 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package describe
+
+import (
+	"fmt"
+
+	clientutil "github.com/datasance/potctl/internal/util/client"
+	"github.com/datasance/potctl/internal/util/watch"
+	"github.com/datasance/potctl/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// watchExecutor reprints the document of a resource whenever its spec or status changes
+type watchExecutor struct {
+	resource string
+	name     string
+	exe      headerExecutor
+	opt      *watch.Options
+	// Spec, metadata and status of the last poll
+	last   string
+	status string
+	first  bool
+}
+
+func newWatchExecutor(opt *Options, exe headerExecutor) *watchExecutor {
+	return &watchExecutor{
+		resource: opt.Resource,
+		name:     opt.Name,
+		exe:      exe,
+		opt:      opt.Watch,
+		first:    true,
+	}
+}
+
+func (exe *watchExecutor) GetName() string {
+	return exe.name
+}
+
+func (exe *watchExecutor) Execute() error {
+	return watch.Poll(exe.opt, exe.poll)
+}
+
+func (exe *watchExecutor) poll() error {
+	// Agents are cached for the lifetime of the command, refresh them on every poll
+	clientutil.InvalidateAgentCache()
+	header, err := exe.exe.getHeader()
+	if err != nil {
+		return err
+	}
+	status, _ := GetStatus(header)
+	first := exe.first
+	exe.first = false
+
+	if exe.opt.Events {
+		if first && status == "" {
+			return util.NewInputError(fmt.Sprintf("Resource %s has no status to watch for events", exe.resource))
+		}
+		if status != exe.status {
+			fmt.Println(watch.FormatEvent(exe.resource, exe.name, exe.status, status))
+		}
+		exe.status = status
+		return nil
+	}
+
+	// Status metrics such as uptime and memory usage change on every poll, only compare the status itself
+	current, err := yaml.Marshal(map[string]interface{}{
+		"metadata": header.Metadata,
+		"spec":     header.Spec,
+		"data":     header.Data,
+		"status":   status,
+	})
+	if err != nil {
+		return err
+	}
+	if !first && string(current) == exe.last {
+		return nil
+	}
+	exe.last = string(current)
+	if !first {
+		fmt.Println("---")
+	}
+	return util.Print(header)
+}
@@ -35,6 +35,7 @@ func (exe executor) remoteDeprovision(agent *rsc.RemoteAgent) error {
 		if err != nil {
 			return err
 		}
+		sshAgent.SetSSHOptions(agent.SSH.Options())
 		if err := sshAgent.Deprovision(); err != nil {
 			util.PrintNotify(fmt.Sprintf("Failed to deprovision daemon on Agent %s. %s", agent.Name, err.Error()))
 		}
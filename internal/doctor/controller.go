@@ -0,0 +1,159 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package doctor
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
+	rsc "github.com/datasance/potctl/internal/resource"
+	clientutil "github.com/datasance/potctl/internal/util/client"
+	ws "github.com/datasance/potctl/internal/util/websocket"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+var versionRegex = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// getEndpoints returns the Controller endpoints of the targets, those of Controllers not deployed yet are derived from their host
+func getEndpoints(tgts *targets) (endpoints []string) {
+	seen := map[string]bool{}
+	add := func(endpoint string) {
+		if endpoint != "" && !seen[endpoint] {
+			seen[endpoint] = true
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	if tgts.controlPlane != nil {
+		if endpoint, err := tgts.controlPlane.GetEndpoint(); err == nil {
+			add(endpoint)
+		}
+	}
+	for _, ctrl := range tgts.controllers {
+		endpoint := ctrl.GetEndpoint()
+		if remoteCtrl, ok := ctrl.(*rsc.RemoteController); ok && endpoint == "" && remoteCtrl.Host != "" {
+			https := remoteCtrl.Https != nil && remoteCtrl.Https.Enabled != nil && *remoteCtrl.Https.Enabled
+			endpoint, _ = util.GetControllerEndpoint(remoteCtrl.Host, https)
+		}
+		add(endpoint)
+	}
+	return
+}
+
+// checkEndpoint verifies that the Controller API answers and that https endpoints serve a valid certificate
+func checkEndpoint(endpoint string) []Check {
+	target := "endpoint/" + endpoint
+	baseURL, err := util.GetBaseURL(endpoint)
+	if err != nil {
+		return []Check{fail(target, nameAPI, err.Error())}
+	}
+	checks := []Check{}
+	if baseURL.Scheme == "https" {
+		checks = append(checks, checkEndpointTLS(target, baseURL))
+	}
+	status, err := client.New(client.Options{BaseURL: baseURL}).GetStatus()
+	if err != nil {
+		return append(checks, fail(target, nameAPI, fmt.Sprintf("Controller API is not reachable: %s", err.Error())))
+	}
+	return append(checks, pass(target, nameAPI, fmt.Sprintf("Controller %s is %s", status.Versions.Controller, status.Status)))
+}
+
+// checkLive authenticates with the Control Plane deployed in the Namespace, then verifies websocket
+// reachability and the versions of the provisioned Agents
+func checkLive(namespace string, fromFile bool) []Check {
+	target := "namespace/" + namespace
+	ns, err := config.GetNamespace(namespace)
+	if err == nil {
+		_, err = ns.GetControlPlane()
+	}
+	if err != nil {
+		// The Control Plane of the input files may not be deployed yet
+		if fromFile {
+			return []Check{warn(target, nameLogin, "No Control Plane is deployed in the Namespace, skipped login, websocket and version checks")}
+		}
+		return []Check{fail(target, nameLogin, "No Control Plane is deployed in the Namespace")}
+	}
+
+	clt, err := clientutil.NewControllerClient(namespace)
+	if err != nil {
+		return []Check{fail(target, nameLogin, fmt.Sprintf("Failed to log in to the Controller: %s", err.Error()))}
+	}
+	checks := []Check{pass(target, nameLogin, fmt.Sprintf("Logged in to %s", clt.GetBaseURL()))}
+	status, err := clt.GetStatus()
+	if err != nil {
+		return append(checks, fail(target, nameAPI, err.Error()))
+	}
+	agents, err := clientutil.GetBackendAgents(namespace)
+	if err != nil {
+		return append(checks, fail(target, nameAPI, fmt.Sprintf("Failed to list Agents: %s", err.Error())))
+	}
+
+	checks = append(checks, checkWebsocket(target, clt, agents))
+	for idx := range agents {
+		agent := &agents[idx]
+		// Agents not provisioned yet have no version
+		if agent.UUID == "" {
+			continue
+		}
+		checks = append(checks, checkVersionSkew("agent/"+agent.Name, status.Versions.Controller, agent.Version))
+	}
+	return checks
+}
+
+// checkWebsocket opens and closes the log stream of a running Agent, the same websocket logs and exec use
+func checkWebsocket(target string, clt *client.Client, agents []client.AgentInfo) Check {
+	var agent *client.AgentInfo
+	for idx := range agents {
+		if agents[idx].UUID != "" && strings.EqualFold(agents[idx].DaemonStatus, "RUNNING") {
+			agent = &agents[idx]
+			break
+		}
+	}
+	if agent == nil {
+		return warn(target, nameWebsocket, "No running Agent to open a websocket to")
+	}
+
+	wsURL := strings.Replace(clt.GetBaseURL(), "http://", "ws://", 1)
+	wsURL = strings.Replace(wsURL, "https://", "wss://", 1)
+	wsURL = fmt.Sprintf("%s/iofog/%s/logs?tail=1&follow=false", wsURL, agent.UUID)
+	headers := http.Header{}
+	headers.Set("Authorization", fmt.Sprintf("Bearer %s", clt.GetAccessToken()))
+
+	wsClient := ws.NewClient(agent.UUID)
+	if err := wsClient.Connect(wsURL, headers); err != nil {
+		return fail(target, nameWebsocket, err.Error())
+	}
+	util.Log(wsClient.Close)
+	return pass(target, nameWebsocket, fmt.Sprintf("Opened the log stream of Agent %s", agent.Name))
+}
+
+// checkVersionSkew fails when the Agent and Controller major versions differ and warns when the minor versions do
+func checkVersionSkew(target, controllerVersion, agentVersion string) Check {
+	ctrlMatch := versionRegex.FindStringSubmatch(controllerVersion)
+	agentMatch := versionRegex.FindStringSubmatch(agentVersion)
+	if ctrlMatch == nil || agentMatch == nil {
+		return warn(target, nameVersionSkew, fmt.Sprintf("Cannot compare Agent version '%s' with Controller version '%s'", agentVersion, controllerVersion))
+	}
+	msg := fmt.Sprintf("Agent %s, Controller %s", agentVersion, controllerVersion)
+	switch {
+	case ctrlMatch[1] != agentMatch[1]:
+		return fail(target, nameVersionSkew, msg)
+	case ctrlMatch[2] != agentMatch[2]:
+		return warn(target, nameVersionSkew, msg)
+	}
+	return pass(target, nameVersionSkew, msg)
+}
@@ -0,0 +1,289 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/execute"
+	rsc "github.com/datasance/potctl/internal/resource"
+	"github.com/datasance/potctl/internal/util/printer"
+	"github.com/datasance/potctl/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+type Status string
+
+// Names of the checks
+const (
+	nameSSH              = "ssh"
+	nameSudo             = "sudo"
+	namePrereqs          = "prerequisites"
+	nameContainerEngine  = "container engine"
+	nameTLS              = "tls"
+	nameAPI              = "api"
+	nameLogin            = "login"
+	nameWebsocket        = "websocket"
+	nameVersionSkew      = "version skew"
+	nameKubeConfig       = "kubeconfig"
+	nameKubernetesAccess = "kubernetes access"
+)
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// Check is the outcome of one diagnostic against one host or Controller
+type Check struct {
+	Target  string `yaml:"target" json:"target"`
+	Name    string `yaml:"check" json:"check"`
+	Status  Status `yaml:"status" json:"status"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+type Summary struct {
+	Pass int `yaml:"pass" json:"pass"`
+	Warn int `yaml:"warn" json:"warn"`
+	Fail int `yaml:"fail" json:"fail"`
+}
+
+type Report struct {
+	Namespace string  `yaml:"namespace" json:"namespace"`
+	Checks    []Check `yaml:"checks" json:"checks"`
+	Summary   Summary `yaml:"summary" json:"summary"`
+}
+
+type Options struct {
+	Namespace  string
+	InputFiles []string
+	Recursive  bool
+}
+
+// targets are the resources diagnosed, read from the Namespace or from the input files
+type targets struct {
+	controlPlane rsc.ControlPlane
+	controllers  []rsc.Controller
+	agents       []rsc.Agent
+}
+
+// Failed returns true if any check failed
+func (report *Report) Failed() bool {
+	return report.Summary.Fail > 0
+}
+
+func (report *Report) add(checks ...Check) {
+	for _, check := range checks {
+		switch check.Status {
+		case StatusPass:
+			report.Summary.Pass++
+		case StatusWarn:
+			report.Summary.Warn++
+		default:
+			report.Summary.Fail++
+		}
+		report.Checks = append(report.Checks, check)
+	}
+}
+
+// Run diagnoses the hosts, certificates, clusters and Controllers of the Namespace, or of the input files if any.
+// Nothing is installed or modified.
+func Run(opt *Options) (*Report, error) {
+	fromFile := len(opt.InputFiles) > 0
+	var tgts targets
+	var err error
+	if fromFile {
+		tgts, err = getFileTargets(opt.InputFiles, opt.Recursive)
+	} else {
+		tgts, err = getNamespaceTargets(opt.Namespace)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Namespace: opt.Namespace}
+	report.add(checkHosts(&tgts)...)
+	for _, ctrl := range tgts.controllers {
+		if remoteCtrl, ok := ctrl.(*rsc.RemoteController); ok {
+			report.add(checkControllerCertificates(remoteCtrl)...)
+		}
+	}
+	if k8sControlPlane, ok := tgts.controlPlane.(*rsc.KubernetesControlPlane); ok {
+		report.add(checkKubeConfig(k8sControlPlane, opt.Namespace)...)
+	}
+	for _, endpoint := range getEndpoints(&tgts) {
+		report.add(checkEndpoint(endpoint)...)
+	}
+	report.add(checkLive(opt.Namespace, fromFile)...)
+
+	return report, nil
+}
+
+func getNamespaceTargets(namespace string) (tgts targets, err error) {
+	ns, err := config.GetNamespace(namespace)
+	if err != nil {
+		return
+	}
+	if controlPlane, cpErr := ns.GetControlPlane(); cpErr == nil {
+		tgts.controlPlane = controlPlane
+		tgts.controllers = controlPlane.GetControllers()
+	}
+	tgts.agents = ns.GetAgents()
+	if tgts.controlPlane == nil && len(tgts.agents) == 0 {
+		err = util.NewInputError(fmt.Sprintf("Namespace %s has no Control Plane or Agents to diagnose, pass the YAML file to deploy with -f", namespace))
+	}
+	return
+}
+
+func getFileTargets(inputFiles []string, recursive bool) (tgts targets, err error) {
+	headers, err := execute.GetHeadersFromYAML(inputFiles, recursive)
+	if err != nil {
+		return
+	}
+	for idx := range headers {
+		header := &headers[idx]
+		var spec []byte
+		if spec, err = yaml.Marshal(header.Spec); err != nil {
+			return
+		}
+		switch header.Kind {
+		case config.RemoteControlPlaneKind:
+			var controlPlane rsc.RemoteControlPlane
+			if controlPlane, err = rsc.UnmarshallRemoteControlPlane(spec); err != nil {
+				return
+			}
+			tgts.controlPlane = &controlPlane
+			tgts.controllers = append(tgts.controllers, controlPlane.GetControllers()...)
+		case config.KubernetesControlPlaneKind:
+			var controlPlane rsc.KubernetesControlPlane
+			if controlPlane, err = rsc.UnmarshallKubernetesControlPlane(spec); err != nil {
+				return
+			}
+			tgts.controlPlane = &controlPlane
+		case config.RemoteControllerKind:
+			var controller rsc.RemoteController
+			if controller, err = rsc.UnmarshallRemoteController(spec); err != nil {
+				return
+			}
+			controller.Name = header.Metadata.Name
+			tgts.controllers = append(tgts.controllers, &controller)
+		case config.RemoteAgentKind:
+			var agent rsc.RemoteAgent
+			if agent, err = rsc.UnmarshallRemoteAgent(spec); err != nil {
+				return
+			}
+			agent.Name = header.Metadata.Name
+			tgts.agents = append(tgts.agents, &agent)
+		}
+	}
+	if tgts.controlPlane == nil && len(tgts.controllers) == 0 && len(tgts.agents) == 0 {
+		err = util.NewInputError("Could not find any Control Plane, Controller or Agent to diagnose in the input files")
+	}
+	return
+}
+
+// hostExecutor diagnoses one remote host, the checks are reported rather than returned as errors
+type hostExecutor struct {
+	target string
+	run    func() []Check
+	checks []Check
+}
+
+func (exe *hostExecutor) GetName() string {
+	return exe.target
+}
+
+func (exe *hostExecutor) Execute() error {
+	exe.checks = exe.run()
+	return nil
+}
+
+// checkHosts diagnoses every remote host in parallel, keeping the order of the targets in the report
+func checkHosts(tgts *targets) (checks []Check) {
+	hosts := []*hostExecutor{}
+	for _, ctrl := range tgts.controllers {
+		if remoteCtrl, ok := ctrl.(*rsc.RemoteController); ok {
+			prereqs := controllerPrereqScript
+			if remoteCtrl.Airgap {
+				prereqs = airgapControllerPrereqScript
+			}
+			target := "controller/" + remoteCtrl.Name
+			host, ssh := remoteCtrl.Host, remoteCtrl.SSH
+			hosts = append(hosts, &hostExecutor{target: target, run: func() []Check { return checkHost(target, host, &ssh, prereqs) }})
+		}
+	}
+	for _, agent := range tgts.agents {
+		if remoteAgent, ok := agent.(*rsc.RemoteAgent); ok {
+			prereqs := agentPrereqScript
+			if remoteAgent.Airgap {
+				prereqs = airgapAgentPrereqScript
+			}
+			target := "agent/" + remoteAgent.Name
+			host, ssh := remoteAgent.Host, remoteAgent.SSH
+			hosts = append(hosts, &hostExecutor{target: target, run: func() []Check { return checkHost(target, host, &ssh, prereqs) }})
+		}
+	}
+
+	exes := make([]execute.Executor, len(hosts))
+	for idx := range hosts {
+		exes[idx] = hosts[idx]
+	}
+	execute.ForParallelOnce(exes)
+	for _, host := range hosts {
+		checks = append(checks, host.checks...)
+	}
+	return
+}
+
+// Print writes the report as a table followed by a summary, or as an object in the requested format
+func Print(writer io.Writer, report *Report, outputOpt *printer.Options) error {
+	if !outputOpt.IsTable() {
+		return outputOpt.PrintObject(writer, report)
+	}
+
+	table := [][]string{{"TARGET", "CHECK", "STATUS", "MESSAGE"}}
+	for _, check := range report.Checks {
+		table = append(table, []string{check.Target, check.Name, strings.ToUpper(string(check.Status)), firstLine(check.Message)})
+	}
+	if err := printer.PrintTable(writer, table); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, "%d passed, %d warnings, %d failed\n", report.Summary.Pass, report.Summary.Warn, report.Summary.Fail)
+	return err
+}
+
+func pass(target, name, message string) Check {
+	return Check{Target: target, Name: name, Status: StatusPass, Message: message}
+}
+
+func warn(target, name, message string) Check {
+	return Check{Target: target, Name: name, Status: StatusWarn, Message: message}
+}
+
+func fail(target, name, message string) Check {
+	return Check{Target: target, Name: name, Status: StatusFail, Message: message}
+}
+
+// firstLine keeps table rows on one line, the full messages are in the yaml and json output
+func firstLine(msg string) string {
+	msg = strings.TrimSpace(msg)
+	if idx := strings.Index(msg, "\n"); idx != -1 {
+		return msg[:idx] + " ..."
+	}
+	return msg
+}
@@ -0,0 +1,147 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package doctor
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	// base64 encoded PEM, as in the Https configuration
+	certB64 string
+	keyB64  string
+}
+
+func newTestCert(t *testing.T, name string, notBefore, notAfter time.Time, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  parent == nil,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	issuer, signer := template, key
+	if parent != nil {
+		issuer, signer = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certB64: base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyB64:  base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+}
+
+func TestCheckCertificate(t *testing.T) {
+	now := time.Now()
+	year := 365 * 24 * time.Hour
+	ca := newTestCert(t, "ca", now.Add(-time.Hour), now.Add(year), nil)
+	otherCA := newTestCert(t, "other", now.Add(-time.Hour), now.Add(year), nil)
+	valid := newTestCert(t, "controller", now.Add(-time.Hour), now.Add(year), ca)
+	expiring := newTestCert(t, "controller", now.Add(-time.Hour), now.Add(24*time.Hour), ca)
+	expired := newTestCert(t, "controller", now.Add(-year), now.Add(-time.Hour), ca)
+	future := newTestCert(t, "controller", now.Add(time.Hour), now.Add(year), ca)
+
+	testCases := []struct {
+		name   string
+		cert   string
+		key    string
+		ca     string
+		status Status
+	}{
+		{"valid", valid.certB64, valid.keyB64, ca.certB64, StatusPass},
+		{"without key and CA", valid.certB64, "", "", StatusPass},
+		{"expiring", expiring.certB64, expiring.keyB64, ca.certB64, StatusWarn},
+		{"expired", expired.certB64, expired.keyB64, ca.certB64, StatusFail},
+		{"not yet valid", future.certB64, "", "", StatusFail},
+		{"other CA", valid.certB64, valid.keyB64, otherCA.certB64, StatusFail},
+		{"mismatched key", valid.certB64, expiring.keyB64, "", StatusFail},
+		{"missing", "", "", "", StatusFail},
+		{"not base64", "not base64!", "", "", StatusFail},
+		{"not PEM", base64.StdEncoding.EncodeToString([]byte("certificate")), "", "", StatusFail},
+	}
+	for _, tc := range testCases {
+		check := checkCertificate("controller/test", nameTLS, tc.cert, tc.key, tc.ca, now)
+		if check.Status != tc.status {
+			t.Errorf("%s: expected %s, got %s: %s", tc.name, tc.status, check.Status, check.Message)
+		}
+	}
+}
+
+func TestCheckVersionSkew(t *testing.T) {
+	testCases := []struct {
+		controller string
+		agent      string
+		status     Status
+	}{
+		{"3.5.0", "3.5.2", StatusPass},
+		{"v3.5.0", "3.5.0-beta.1", StatusPass},
+		{"3.5.0", "3.4.9", StatusWarn},
+		{"3.5.0", "2.5.0", StatusFail},
+		{"3.5.0", "", StatusWarn},
+		{"dev", "3.5.0", StatusWarn},
+	}
+	for _, tc := range testCases {
+		check := checkVersionSkew("agent/test", tc.controller, tc.agent)
+		if check.Status != tc.status {
+			t.Errorf("controller %s, agent %s: expected %s, got %s", tc.controller, tc.agent, tc.status, check.Status)
+		}
+	}
+}
+
+func TestReportSummary(t *testing.T) {
+	report := &Report{}
+	report.add(pass("a", nameSSH, ""), warn("a", nameContainerEngine, ""), pass("b", nameSSH, ""))
+	if report.Failed() || report.Summary.Pass != 2 || report.Summary.Warn != 1 {
+		t.Fatalf("unexpected summary %+v", report.Summary)
+	}
+	report.add(fail("b", nameSudo, "sudo: a password is required\nexit status 1"))
+	if !report.Failed() || len(report.Checks) != 4 {
+		t.Fatalf("unexpected summary %+v", report.Summary)
+	}
+	if msg := firstLine(report.Checks[3].Message); msg != "sudo: a password is required ..." {
+		t.Errorf("unexpected table message %q", msg)
+	}
+}
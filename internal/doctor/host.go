@@ -0,0 +1,94 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package doctor
+
+import (
+	"fmt"
+	"strings"
+
+	rsc "github.com/datasance/potctl/internal/resource"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// Bundled prerequisite checks, the same scripts deploy runs before installing
+const (
+	agentPrereqScript            = "agent/check_prereqs.sh"
+	airgapAgentPrereqScript      = "airgap-agent/check_prereqs.sh"
+	controllerPrereqScript       = "container-controller/check_prereqs.sh"
+	airgapControllerPrereqScript = "airgap-controller/check_prereqs.sh"
+)
+
+// Remote directory the prerequisite script is copied to, removed once it ran
+const remoteDir = "/tmp/potctl-doctor"
+
+// checkHost connects to the host and verifies sudo, the prerequisites and the container engine
+func checkHost(target, host string, ssh *rsc.SSH, prereqs string) []Check {
+	if host == "" || ssh.User == "" || (ssh.KeyFile == "" && !ssh.UseAgent) {
+		return []Check{fail(target, nameSSH, "SSH details are not available. Use `potctl configure --help` to find out how to add them")}
+	}
+
+	cl, err := util.NewSecureShellClient(ssh.User, host, ssh.KeyFile)
+	if err != nil {
+		return []Check{fail(target, nameSSH, err.Error())}
+	}
+	if ssh.Port != 0 {
+		cl.SetPort(ssh.Port)
+	}
+	cl.SetOptions(ssh.Options())
+	if err := cl.Connect(); err != nil {
+		return []Check{fail(target, nameSSH, fmt.Sprintf("Failed to connect to %s@%s: %s", ssh.User, host, err.Error()))}
+	}
+	defer util.Log(cl.Disconnect)
+
+	checks := []Check{pass(target, nameSSH, fmt.Sprintf("Connected to %s@%s", ssh.User, host))}
+	if _, err := cl.Run("sudo -n true"); err != nil {
+		checks = append(checks, fail(target, nameSudo, fmt.Sprintf("User %s cannot use sudo without a password: %s", ssh.User, err.Error())))
+	} else {
+		checks = append(checks, pass(target, nameSudo, "Passwordless sudo is enabled"))
+	}
+	checks = append(checks, runPrereqs(target, cl, prereqs))
+	return append(checks, findContainerEngine(target, cl))
+}
+
+// runPrereqs runs the bundled prerequisite script without installing anything
+func runPrereqs(target string, cl *util.SecureShellClient, prereqs string) Check {
+	script, err := util.GetStaticFile(prereqs)
+	if err != nil {
+		return fail(target, namePrereqs, err.Error())
+	}
+	if err := cl.CreateFolder(remoteDir); err != nil {
+		return fail(target, namePrereqs, fmt.Sprintf("Failed to create %s: %s", remoteDir, err.Error()))
+	}
+	defer func() {
+		if _, err := cl.Run("rm -rf " + remoteDir); err != nil {
+			util.PrintNotify(fmt.Sprintf("Failed to remove %s from %s: %s", remoteDir, target, err.Error()))
+		}
+	}()
+	if err := cl.CopyTo(strings.NewReader(script), remoteDir, "check_prereqs.sh", "0775", int64(len(script))); err != nil {
+		return fail(target, namePrereqs, fmt.Sprintf("Failed to copy the prerequisite script: %s", err.Error()))
+	}
+	if _, err := cl.Run(util.JoinAgentPath(remoteDir, "check_prereqs.sh")); err != nil {
+		return fail(target, namePrereqs, err.Error())
+	}
+	return pass(target, namePrereqs, "check_prereqs.sh succeeded")
+}
+
+// findContainerEngine warns when no container engine is installed yet, deploy installs one
+func findContainerEngine(target string, cl *util.SecureShellClient) Check {
+	stdout, err := cl.Run("command -v docker || command -v podman")
+	if err != nil {
+		return warn(target, nameContainerEngine, "Neither docker nor podman is installed, deploy will install one")
+	}
+	return pass(target, nameContainerEngine, fmt.Sprintf("Found %s", strings.TrimSpace(stdout.String())))
+}
@@ -0,0 +1,51 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	rsc "github.com/datasance/potctl/internal/resource"
+	"github.com/datasance/potctl/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// checkKubeConfig verifies that the cluster answers and that pods of the Namespace can be listed with the kubeconfig
+func checkKubeConfig(controlPlane *rsc.KubernetesControlPlane, namespace string) []Check {
+	target := "kubernetes/" + namespace
+	kubeConfig, err := util.FormatPath(controlPlane.KubeConfig)
+	if err != nil {
+		return []Check{fail(target, nameKubeConfig, err.Error())}
+	}
+	conf, err := clientcmd.BuildConfigFromFlags("", kubeConfig)
+	if err != nil {
+		return []Check{fail(target, nameKubeConfig, fmt.Sprintf("Failed to load kubeconfig %s: %s", kubeConfig, err.Error()))}
+	}
+	clientset, err := kubernetes.NewForConfig(conf)
+	if err != nil {
+		return []Check{fail(target, nameKubeConfig, err.Error())}
+	}
+	version, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return []Check{fail(target, nameKubeConfig, fmt.Sprintf("Cluster %s is not reachable: %s", conf.Host, err.Error()))}
+	}
+	checks := []Check{pass(target, nameKubeConfig, fmt.Sprintf("Cluster %s runs Kubernetes %s", conf.Host, version.GitVersion))}
+	if _, err := clientset.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{Limit: 1}); err != nil {
+		return append(checks, fail(target, nameKubernetesAccess, fmt.Sprintf("Cannot list pods in namespace %s: %s", namespace, err.Error())))
+	}
+	return append(checks, pass(target, nameKubernetesAccess, fmt.Sprintf("Pods in namespace %s can be listed", namespace)))
+}
@@ -0,0 +1,146 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package doctor
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	rsc "github.com/datasance/potctl/internal/resource"
+)
+
+// Certificates expiring within this period are reported as warnings
+const expiryWarning = 30 * 24 * time.Hour
+
+const dialTimeout = 10 * time.Second
+
+// checkControllerCertificates verifies the base64 encoded certificates of the Controller configuration
+func checkControllerCertificates(ctrl *rsc.RemoteController) (checks []Check) {
+	target := "controller/" + ctrl.Name
+	now := time.Now()
+	if https := ctrl.Https; https != nil && https.Enabled != nil && *https.Enabled {
+		checks = append(checks, checkCertificate(target, "https certificate", https.TLSCert, https.TLSKey, https.CACert, now))
+	}
+	if ctrl.SiteCA != nil && ctrl.SiteCA.TLSCert != "" {
+		checks = append(checks, checkCertificate(target, "site CA", ctrl.SiteCA.TLSCert, ctrl.SiteCA.TLSKey, "", now))
+	}
+	if ctrl.LocalCA != nil && ctrl.LocalCA.TLSCert != "" {
+		checks = append(checks, checkCertificate(target, "local CA", ctrl.LocalCA.TLSCert, ctrl.LocalCA.TLSKey, "", now))
+	}
+	return
+}
+
+// checkCertificate verifies that the certificate parses, matches its key, chains to the CA if any and is valid at now
+func checkCertificate(target, name, encodedCert, encodedKey, encodedCA string, now time.Time) Check {
+	if encodedCert == "" {
+		return fail(target, name, "No certificate is configured")
+	}
+	certPEM, err := base64.StdEncoding.DecodeString(encodedCert)
+	if err != nil {
+		return fail(target, name, fmt.Sprintf("Certificate is not base64 encoded: %s", err.Error()))
+	}
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return fail(target, name, err.Error())
+	}
+	if encodedKey != "" {
+		keyPEM, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return fail(target, name, fmt.Sprintf("Key is not base64 encoded: %s", err.Error()))
+		}
+		if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+			return fail(target, name, fmt.Sprintf("Key does not match the certificate: %s", err.Error()))
+		}
+	}
+	if encodedCA != "" {
+		caPEM, err := base64.StdEncoding.DecodeString(encodedCA)
+		if err != nil {
+			return fail(target, name, fmt.Sprintf("CA certificate is not base64 encoded: %s", err.Error()))
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(caPEM) {
+			return fail(target, name, "CA certificate is not a PEM encoded certificate")
+		}
+		if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, CurrentTime: now}); err != nil {
+			return fail(target, name, fmt.Sprintf("Certificate is not signed by the CA: %s", err.Error()))
+		}
+	}
+	return checkValidity(target, name, cert, now)
+}
+
+// checkEndpointTLS reports the validity of the certificate served by an https endpoint
+func checkEndpointTLS(target string, endpoint *url.URL) Check {
+	host := endpoint.Host
+	if endpoint.Port() == "" {
+		host = net.JoinHostPort(endpoint.Hostname(), "443")
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	// Verified below so that expired and self-signed certificates are reported rather than failing the handshake
+	conn, err := tls.DialWithDialer(dialer, "tcp", host, &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		return fail(target, nameTLS, fmt.Sprintf("TLS handshake with %s failed: %s", host, err.Error()))
+	}
+	defer conn.Close()
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return fail(target, nameTLS, fmt.Sprintf("%s did not present a certificate", host))
+	}
+	now := time.Now()
+	check := checkValidity(target, nameTLS, certs[0], now)
+	if check.Status != StatusPass {
+		return check
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	opts := x509.VerifyOptions{DNSName: endpoint.Hostname(), Intermediates: intermediates, CurrentTime: now}
+	if _, err := certs[0].Verify(opts); err != nil {
+		return warn(target, nameTLS, fmt.Sprintf("Certificate is not trusted by this host: %s", err.Error()))
+	}
+	return check
+}
+
+func checkValidity(target, name string, cert *x509.Certificate, now time.Time) Check {
+	expiry := cert.NotAfter.UTC().Format(time.RFC3339)
+	switch {
+	case now.Before(cert.NotBefore):
+		return fail(target, name, fmt.Sprintf("Certificate is not valid before %s", cert.NotBefore.UTC().Format(time.RFC3339)))
+	case now.After(cert.NotAfter):
+		return fail(target, name, fmt.Sprintf("Certificate expired on %s", expiry))
+	case cert.NotAfter.Sub(now) < expiryWarning:
+		return warn(target, name, fmt.Sprintf("Certificate expires on %s", expiry))
+	}
+	return pass(target, name, fmt.Sprintf("Certificate of %s is valid until %s", cert.Subject.CommonName, expiry))
+}
+
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("certificate is not PEM encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the certificate: %s", err.Error())
+	}
+	return cert, nil
+}
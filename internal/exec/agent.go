@@ -28,14 +28,16 @@ import (
 type agentExecutor struct {
 	namespace string
 	name      string
+	command   []string
 	client    *client.Client
 	msvc      *client.MicroserviceInfo
 }
 
-func newAgentExecutor(namespace, name string) *agentExecutor {
+func newAgentExecutor(namespace, name string, command []string) *agentExecutor {
 	a := &agentExecutor{}
 	a.namespace = namespace
 	a.name = name
+	a.command = command
 	return a
 }
 
@@ -44,18 +46,54 @@ func (exe *agentExecutor) GetName() string {
 }
 
 func (exe *agentExecutor) Execute() error {
-	util.SpinStart("Connecting Exec Session to Agent")
+	if len(exe.command) == 0 {
+		util.SpinStart("Connecting Exec Session to Agent")
+	}
+
+	wsClient, err := exe.connect()
+	if err != nil {
+		return err
+	}
+
+	if len(exe.command) > 0 {
+		return execCommand(wsClient, exe.command, newStdStreams())
+	}
+
+	// Create and start terminal
+	term := terminal.NewTerminal(wsClient)
+
+	if err := term.Start(); err != nil {
+		util.SpinHandlePromptComplete()
+		formattedErr := formatWebSocketError(err)
+		return util.NewError(formattedErr)
+	}
+
+	// Wait for terminal to finish
+	<-wsClient.GetDone()
+	msg := fmt.Sprintf("Successfully closed Agent %s Exec Session", exe.name)
+	util.PrintSuccess(msg)
+
+	// Check if there was an error
+	if err := wsClient.GetError(); err != nil {
+		formattedErr := formatWebSocketError(err)
+		return util.NewError(formattedErr)
+	}
 
+	return nil
+}
+
+// connect opens the exec session of the debug Microservice of the Agent
+func (exe *agentExecutor) connect() (*websocket.Client, error) {
 	// Init client
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	agent, err := clt.GetAgentByName(exe.name)
 	if err != nil {
 		msg := "%s\nFailed to get Agent by name: %s"
-		return fmt.Errorf(msg, err.Error())
+		return nil, fmt.Errorf(msg, err.Error())
 	}
 
 	appName := fmt.Sprintf("system-%s", agent.Name)
@@ -68,11 +106,11 @@ func (exe *agentExecutor) Execute() error {
 			// Try system application
 			exe.msvc, err = clt.GetSystemMicroserviceByName(appName, msvcName)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		} else {
 			// Return other types of errors
-			return err
+			return nil, err
 		}
 	}
 
@@ -93,35 +131,15 @@ func (exe *agentExecutor) Execute() error {
 	// Connect to WebSocket
 	if err := wsClient.Connect(wsURL, headers); err != nil {
 		util.SpinHandlePromptComplete()
-		return util.NewError(fmt.Sprintf("failed to connect to WebSocket: %v", err))
+		return nil, util.NewError(fmt.Sprintf("failed to connect to WebSocket: %v", err))
 	}
 
-	// Create and start terminal
-	term := terminal.NewTerminal(wsClient)
-
 	// Check for initial connection error
 	if err := wsClient.GetError(); err != nil {
 		util.SpinHandlePromptComplete()
 		formattedErr := formatWebSocketError(err)
-		return util.NewError(formattedErr)
-	}
-
-	if err := term.Start(); err != nil {
-		util.SpinHandlePromptComplete()
-		formattedErr := formatWebSocketError(err)
-		return util.NewError(formattedErr)
+		return nil, util.NewError(formattedErr)
 	}
 
-	// Wait for terminal to finish
-	<-wsClient.GetDone()
-	msg := fmt.Sprintf("Successfully closed Agent %s Exec Session", exe.name)
-	util.PrintSuccess(msg)
-
-	// Check if there was an error
-	if err := wsClient.GetError(); err != nil {
-		formattedErr := formatWebSocketError(err)
-		return util.NewError(formattedErr)
-	}
-
-	return nil
+	return wsClient, nil
 }
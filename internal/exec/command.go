@@ -0,0 +1,217 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package exec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/datasance/potctl/internal/util/websocket"
+	"github.com/datasance/potctl/pkg/util"
+	"golang.org/x/term"
+)
+
+// Size of the stdin chunks, well below the maximum payload
+const stdinChunkSize = 32 * 1024
+
+// ExitError is returned when the remote command exits with a non-zero code
+type ExitError struct {
+	Code int
+}
+
+func (err *ExitError) Error() string {
+	return fmt.Sprintf("command terminated with exit code %d", err.Code)
+}
+
+// errNoExitStatus is returned when the session ends before the Agent reports the exit code of the command
+var errNoExitStatus = errors.New("session closed without exit status")
+
+// errCommandUnsupported is returned when the Agent does not acknowledge the command
+var errCommandUnsupported = errors.New("the Agent does not support running commands without a terminal, upgrade the Agent or run potctl exec without a command")
+
+// How long the Agent has to acknowledge the command once it joined the session
+var commandAckTimeout = 30 * time.Second
+
+// Streams are the local streams attached to a command run without a terminal
+type Streams struct {
+	// Stdin is piped to the command if set, otherwise its stdin is closed
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// newStdStreams attaches the command to the standard streams, stdin is only piped if it is not a terminal
+func newStdStreams() *Streams {
+	streams := &Streams{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		streams.Stdin = os.Stdin
+	}
+	return streams
+}
+
+// execCommand runs the command and formats session errors, the exit status is returned as is
+func execCommand(wsClient *websocket.Client, command []string, streams *Streams) error {
+	err := runCommand(wsClient, command, streams)
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*ExitError); ok {
+		return err
+	}
+	return util.NewError(formatWebSocketError(err))
+}
+
+// runCommand runs the command in the exec session without a terminal. Stdout and stderr are streamed separately.
+// Stdin is only sent once the Agent acknowledged the command, so that it never reaches an interactive shell.
+func runCommand(wsClient *websocket.Client, command []string, streams *Streams) error {
+	control := &websocket.Control{
+		Type:    websocket.ControlExec,
+		Command: command,
+	}
+	if err := sendControl(wsClient, control); err != nil {
+		return err
+	}
+	msgs := readMessages(wsClient)
+	if err := waitForAck(wsClient, msgs, websocket.ControlExec); err != nil {
+		return err
+	}
+
+	if streams.Stdin == nil {
+		if err := sendControl(wsClient, &websocket.Control{Type: websocket.ControlStdinClose}); err != nil {
+			return err
+		}
+	} else {
+		go pipeStdin(wsClient, streams.Stdin)
+	}
+
+	for msg := range msgs {
+		switch msg.Type {
+		case websocket.MessageTypeStdout:
+			if _, err := streams.Stdout.Write(msg.Data); err != nil {
+				wsClient.Close()
+				return err
+			}
+		case websocket.MessageTypeStderr:
+			if _, err := streams.Stderr.Write(msg.Data); err != nil {
+				wsClient.Close()
+				return err
+			}
+		case websocket.MessageTypeClose:
+			wsClient.Close()
+			status, ok := msg.GetExitStatus()
+			if !ok {
+				return errNoExitStatus
+			}
+			if status.ExitCode != 0 {
+				return &ExitError{Code: status.ExitCode}
+			}
+			return nil
+		}
+	}
+	return sessionError(wsClient)
+}
+
+// readMessages reads the messages of the session until it ends or is closed, then closes the channel
+func readMessages(wsClient *websocket.Client) <-chan *websocket.Message {
+	msgs := make(chan *websocket.Message)
+	go func() {
+		defer close(msgs)
+		for {
+			msg, err := wsClient.ReadMessage()
+			if err != nil || msg == nil {
+				return
+			}
+			select {
+			case msgs <- msg:
+			case <-wsClient.GetDone():
+				return
+			}
+		}
+	}()
+	return msgs
+}
+
+// sessionError returns the error that ended the session, the session never ends normally before the exit status
+func sessionError(wsClient *websocket.Client) error {
+	if err := wsClient.GetError(); err != nil {
+		return err
+	}
+	// Never report success for a command whose outcome is unknown
+	return errNoExitStatus
+}
+
+// waitForAck waits for the Agent to acknowledge the control message. Output before the acknowledgement comes from
+// an Agent that ignored the control message and opened a shell.
+func waitForAck(wsClient *websocket.Client, msgs <-chan *websocket.Message, controlType string) error {
+	timeout := time.NewTimer(commandAckTimeout)
+	defer timeout.Stop()
+	for {
+		select {
+		case msg, ok := <-msgs:
+			if !ok {
+				return sessionError(wsClient)
+			}
+			switch {
+			case msg.IsAck(controlType):
+				return nil
+			case msg.IsActivationMessage():
+				// The Agent just joined the session
+				timeout.Reset(commandAckTimeout)
+			case msg.IsStdoutMessage(), msg.IsStderrMessage():
+				wsClient.Close()
+				return errCommandUnsupported
+			case msg.IsCloseMessage():
+				wsClient.Close()
+				return errNoExitStatus
+			}
+		case <-timeout.C:
+			wsClient.Close()
+			return errCommandUnsupported
+		}
+	}
+}
+
+func pipeStdin(wsClient *websocket.Client, stdin io.Reader) {
+	buf := make([]byte, stdinChunkSize)
+	for {
+		n, err := stdin.Read(buf)
+		if n > 0 {
+			data := make([]byte, n)
+			copy(data, buf[:n])
+			msg := websocket.NewMessage(websocket.MessageTypeStdin, data, wsClient.GetMicroserviceUUID(), wsClient.GetExecID())
+			if sendErr := wsClient.SendMessage(msg); sendErr != nil {
+				return
+			}
+		}
+		if err != nil {
+			// Let the command finish on EOF as well as on read errors
+			_ = sendControl(wsClient, &websocket.Control{Type: websocket.ControlStdinClose})
+			return
+		}
+	}
+}
+
+func sendControl(wsClient *websocket.Client, control *websocket.Control) error {
+	msg, err := websocket.NewControlMessage(control, wsClient.GetMicroserviceUUID(), wsClient.GetExecID())
+	if err != nil {
+		return err
+	}
+	return wsClient.SendMessage(msg)
+}
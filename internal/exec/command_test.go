@@ -0,0 +1,167 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package exec
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/datasance/potctl/internal/util/websocket"
+	gorilla "github.com/gorilla/websocket"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// agentFunc plays the Agent side of an exec session, received collects the messages potctl sent
+type agentFunc func(conn *gorilla.Conn, received chan<- *websocket.Message)
+
+// newTestSession connects a client to a session served by agent
+func newTestSession(t *testing.T, agent agentFunc) (*websocket.Client, <-chan *websocket.Message) {
+	received := make(chan *websocket.Message, 16)
+	upgrader := gorilla.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		agent(conn, received)
+	}))
+	t.Cleanup(server.Close)
+
+	wsClient := websocket.NewClient("msvc-uuid")
+	if err := wsClient.Connect("ws"+strings.TrimPrefix(server.URL, "http"), http.Header{}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { wsClient.Close() })
+	return wsClient, received
+}
+
+func readTestMessage(t *testing.T, conn *gorilla.Conn) *websocket.Message {
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		return nil
+	}
+	msg, err := websocket.Decode(data)
+	if err != nil {
+		t.Error(err)
+		return nil
+	}
+	return msg
+}
+
+func writeTestMessage(t *testing.T, conn *gorilla.Conn, msg *websocket.Message) {
+	data, err := msg.Encode()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := conn.WriteMessage(gorilla.BinaryMessage, data); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	wsClient, received := newTestSession(t, func(conn *gorilla.Conn, received chan<- *websocket.Message) {
+		received <- readTestMessage(t, conn)
+		ack, _ := websocket.NewControlMessage(&websocket.Control{Type: websocket.ControlExec}, "", "")
+		writeTestMessage(t, conn, ack)
+		// Echo stdin until it is closed
+		for {
+			msg := readTestMessage(t, conn)
+			if msg == nil {
+				return
+			}
+			received <- msg
+			if msg.IsStdinMessage() {
+				writeTestMessage(t, conn, websocket.NewMessage(websocket.MessageTypeStdout, msg.Data, "", ""))
+			}
+			if control, ok := msg.GetControl(); ok && control.Type == websocket.ControlStdinClose {
+				break
+			}
+		}
+		writeTestMessage(t, conn, websocket.NewMessage(websocket.MessageTypeStderr, []byte("warning"), "", ""))
+		status, _ := msgpack.Marshal(&websocket.ExitStatus{ExitCode: 3})
+		writeTestMessage(t, conn, websocket.NewMessage(websocket.MessageTypeClose, status, "", ""))
+	})
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	err := runCommand(wsClient, []string{"cat"}, &Streams{
+		Stdin:  strings.NewReader("input"),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 3 {
+		t.Fatalf("Expected exit code 3, got %v", err)
+	}
+	if stdout.String() != "input" {
+		t.Errorf("Unexpected stdout %q", stdout.String())
+	}
+	if stderr.String() != "warning" {
+		t.Errorf("Unexpected stderr %q", stderr.String())
+	}
+	control, ok := (<-received).GetControl()
+	if !ok || control.Type != websocket.ControlExec || len(control.Command) != 1 || control.Command[0] != "cat" {
+		t.Errorf("Unexpected exec control %v", control)
+	}
+}
+
+func TestRunCommandUnsupported(t *testing.T) {
+	// Agent ignoring the command and opening a shell
+	wsClient, received := newTestSession(t, func(conn *gorilla.Conn, received chan<- *websocket.Message) {
+		writeTestMessage(t, conn, websocket.NewMessage(websocket.MessageTypeStdout, []byte("$ "), "", ""))
+		for {
+			msg := readTestMessage(t, conn)
+			if msg == nil {
+				return
+			}
+			received <- msg
+		}
+	})
+
+	err := runCommand(wsClient, []string{"cat"}, &Streams{
+		Stdin:  strings.NewReader("input"),
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	})
+	if !errors.Is(err, errCommandUnsupported) {
+		t.Fatalf("Expected unsupported command error, got %v", err)
+	}
+	// Give stdin a chance to be sent, it must not
+	time.Sleep(50 * time.Millisecond)
+	for len(received) > 0 {
+		if msg := <-received; msg.IsStdinMessage() {
+			t.Errorf("Unexpected stdin sent to the shell: %q", string(msg.Data))
+		}
+	}
+}
+
+func TestRunCommandNotAcknowledged(t *testing.T) {
+	defer func(timeout time.Duration) { commandAckTimeout = timeout }(commandAckTimeout)
+	commandAckTimeout = 50 * time.Millisecond
+
+	wsClient, _ := newTestSession(t, func(conn *gorilla.Conn, received chan<- *websocket.Message) {
+		for readTestMessage(t, conn) != nil {
+		}
+	})
+	err := runCommand(wsClient, []string{"true"}, &Streams{Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}})
+	if !errors.Is(err, errCommandUnsupported) {
+		t.Fatalf("Expected unsupported command error, got %v", err)
+	}
+}
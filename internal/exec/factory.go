@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"github.com/datasance/potctl/internal/execute"
+	"github.com/datasance/potctl/internal/util/websocket"
 	"github.com/datasance/potctl/pkg/util"
 )
 
@@ -24,15 +25,43 @@ type Options struct {
 	Resource  string
 	Name      string
 	Namespace string
+	Command   []string
 }
 
 func NewExecutor(opt *Options) (execute.Executor, error) {
 	switch opt.Resource {
 	case "microservice":
-		return newMicroserviceExecutor(opt.Namespace, opt.Name), nil
+		return newMicroserviceExecutor(opt.Namespace, opt.Name, opt.Command), nil
 	case "agent":
-		return newAgentExecutor(opt.Namespace, opt.Name), nil
+		return newAgentExecutor(opt.Namespace, opt.Name, opt.Command), nil
 	default:
 		return nil, util.NewInputError(fmt.Sprintf("Unknown resources: %s", opt.Resource))
 	}
 }
+
+// session is implemented by the executors able to open an exec session
+type session interface {
+	connect() (*websocket.Client, error)
+}
+
+// RunCommand runs opt.Command without a terminal in the exec session of the resource, attached to streams.
+// A non-zero exit code of the command is returned as an *ExitError.
+func RunCommand(opt *Options, streams *Streams) error {
+	if len(opt.Command) == 0 {
+		return util.NewInputError("No command specified")
+	}
+	var exe session
+	switch opt.Resource {
+	case "microservice":
+		exe = newMicroserviceExecutor(opt.Namespace, opt.Name, opt.Command)
+	case "agent":
+		exe = newAgentExecutor(opt.Namespace, opt.Name, opt.Command)
+	default:
+		return util.NewInputError(fmt.Sprintf("Unknown resources: %s", opt.Resource))
+	}
+	wsClient, err := exe.connect()
+	if err != nil {
+		return err
+	}
+	return execCommand(wsClient, opt.Command, streams)
+}
@@ -28,14 +28,16 @@ import (
 type microserviceExecutor struct {
 	namespace string
 	name      string
+	command   []string
 	client    *client.Client
 	msvc      *client.MicroserviceInfo
 }
 
-func newMicroserviceExecutor(namespace, name string) *microserviceExecutor {
+func newMicroserviceExecutor(namespace, name string, command []string) *microserviceExecutor {
 	a := &microserviceExecutor{}
 	a.namespace = namespace
 	a.name = name
+	a.command = command
 	return a
 }
 
@@ -44,17 +46,53 @@ func (exe *microserviceExecutor) GetName() string {
 }
 
 func (exe *microserviceExecutor) Execute() error {
-	util.SpinStart("Connecting Exec Session to Microservice")
+	if len(exe.command) == 0 {
+		util.SpinStart("Connecting Exec Session to Microservice")
+	}
+
+	wsClient, err := exe.connect()
+	if err != nil {
+		return err
+	}
+
+	if len(exe.command) > 0 {
+		return execCommand(wsClient, exe.command, newStdStreams())
+	}
+
+	// Create and start terminal
+	term := terminal.NewTerminal(wsClient)
+
+	if err := term.Start(); err != nil {
+		util.SpinHandlePromptComplete()
+		formattedErr := formatWebSocketError(err)
+		return util.NewError(formattedErr)
+	}
+
+	// Wait for terminal to finish
+	<-wsClient.GetDone()
+	msg := fmt.Sprintf("Successfully closed Microservice %s Exec Session", exe.name)
+	util.PrintSuccess(msg)
+
+	// Check if there was an error
+	if err := wsClient.GetError(); err != nil {
+		formattedErr := formatWebSocketError(err)
+		return util.NewError(formattedErr)
+	}
 
+	return nil
+}
+
+// connect opens the exec session of the Microservice
+func (exe *microserviceExecutor) connect() (*websocket.Client, error) {
 	// Init client
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	appName, msvcName, err := clientutil.ParseFQName(exe.name, "Microservice")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	exe.msvc, err = clt.GetMicroserviceByName(appName, msvcName)
@@ -65,12 +103,12 @@ func (exe *microserviceExecutor) Execute() error {
 			// Try system application
 			exe.msvc, err = clt.GetSystemMicroserviceByName(appName, msvcName)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			isSystem = true
 		} else {
 			// Return other types of errors
-			return err
+			return nil, err
 		}
 	}
 
@@ -96,35 +134,15 @@ func (exe *microserviceExecutor) Execute() error {
 	// Connect to WebSocket
 	if err := wsClient.Connect(wsURL, headers); err != nil {
 		util.SpinHandlePromptComplete()
-		return util.NewError(fmt.Sprintf("failed to connect to WebSocket: %v", err))
+		return nil, util.NewError(fmt.Sprintf("failed to connect to WebSocket: %v", err))
 	}
 
-	// Create and start terminal
-	term := terminal.NewTerminal(wsClient)
-
 	// Check for initial connection error
 	if err := wsClient.GetError(); err != nil {
 		util.SpinHandlePromptComplete()
 		formattedErr := formatWebSocketError(err)
-		return util.NewError(formattedErr)
+		return nil, util.NewError(formattedErr)
 	}
 
-	if err := term.Start(); err != nil {
-		util.SpinHandlePromptComplete()
-		formattedErr := formatWebSocketError(err)
-		return util.NewError(formattedErr)
-	}
-
-	// Wait for terminal to finish
-	<-wsClient.GetDone()
-	msg := fmt.Sprintf("Successfully closed Microservice %s Exec Session", exe.name)
-	util.PrintSuccess(msg)
-
-	// Check if there was an error
-	if err := wsClient.GetError(); err != nil {
-		formattedErr := formatWebSocketError(err)
-		return util.NewError(formattedErr)
-	}
-
-	return nil
+	return wsClient, nil
 }
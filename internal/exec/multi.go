@@ -0,0 +1,155 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package exec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/datasance/potctl/internal/execute"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// multiExecutor runs a command in the exec sessions of several resources, prefixing each line of output
+// with the name of its resource
+type multiExecutor struct {
+	opt   Options
+	names []string
+}
+
+// NewMultiExecutor returns an executor running opt.Command in the exec session of each of the named resources.
+// The exit code of the executor is the highest exit code of the commands.
+func NewMultiExecutor(opt *Options, names []string) (execute.Executor, error) {
+	if len(opt.Command) == 0 {
+		return nil, util.NewInputError("A command must be specified after -- to exec into several resources")
+	}
+	return &multiExecutor{
+		opt:   *opt,
+		names: names,
+	}, nil
+}
+
+func (multi *multiExecutor) GetName() string {
+	return multi.opt.Resource
+}
+
+func (multi *multiExecutor) Execute() error {
+	width := 0
+	for _, name := range multi.names {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	var stdoutMutex, stderrMutex sync.Mutex
+	exes := make([]execute.Executor, len(multi.names))
+	for idx, name := range multi.names {
+		opt := multi.opt
+		opt.Name = name
+		prefix := fmt.Sprintf("[%-*s] ", width, name)
+		exes[idx] = &commandExecutor{
+			opt:    opt,
+			stdout: &prefixWriter{out: os.Stdout, mutex: &stdoutMutex, prefix: prefix},
+			stderr: &prefixWriter{out: os.Stderr, mutex: &stderrMutex, prefix: prefix},
+		}
+	}
+
+	// Commands are not idempotent, don't retry them
+	errs, _ := execute.ForParallelOnce(exes)
+	code := 0
+	failures := []error{}
+	for _, err := range errs {
+		if exitErr, ok := err.(*ExitError); ok {
+			if exitErr.Code > code {
+				code = exitErr.Code
+			}
+			continue
+		}
+		failures = append(failures, err)
+	}
+	if len(failures) > 0 {
+		return execute.CoalesceErrors(failures)
+	}
+	if code != 0 {
+		return &ExitError{Code: code}
+	}
+	return nil
+}
+
+// commandExecutor runs the command in the exec session of a single resource
+type commandExecutor struct {
+	opt    Options
+	stdout *prefixWriter
+	stderr *prefixWriter
+}
+
+func (exe *commandExecutor) GetName() string {
+	return exe.opt.Name
+}
+
+func (exe *commandExecutor) Execute() error {
+	err := RunCommand(&exe.opt, &Streams{
+		Stdout: exe.stdout,
+		Stderr: exe.stderr,
+	})
+	exe.stdout.Flush()
+	exe.stderr.Flush()
+	if exitErr, ok := err.(*ExitError); ok {
+		util.PrintNotify(fmt.Sprintf("Command failed on %s with exit code %d", exe.opt.Name, exitErr.Code))
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", exe.opt.Name, err)
+	}
+	return nil
+}
+
+// prefixWriter writes whole lines prefixed with the prefix, holding partial lines until they are complete
+type prefixWriter struct {
+	out    io.Writer
+	mutex  *sync.Mutex
+	prefix string
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(data []byte) (int, error) {
+	w.buf = append(w.buf, data...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			return len(data), nil
+		}
+		if err := w.writeLine(w.buf[:idx+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[idx+1:]
+	}
+}
+
+// Flush writes the last line if it is not terminated by a newline
+func (w *prefixWriter) Flush() {
+	if len(w.buf) > 0 {
+		_ = w.writeLine(append(w.buf, '\n'))
+		w.buf = nil
+	}
+}
+
+func (w *prefixWriter) writeLine(line []byte) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	_, err := fmt.Fprintf(w.out, "%s%s", w.prefix, line)
+	return err
+}
@@ -0,0 +1,233 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/datasance/potctl/internal/kustomize"
+	"github.com/datasance/potctl/internal/template"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// StdinInput is the input reading documents from stdin
+const StdinInput = "-"
+
+// Timeout of requests for http(s):// inputs
+const urlTimeout = 30 * time.Second
+
+// Extensions of the files read from input directories
+var inputExtensions = map[string]bool{
+	".yaml": true,
+	".yml":  true,
+	".json": true,
+}
+
+// Templating applied to input files, disabled unless set
+var templateOpt *template.Options
+
+// SetTemplate sets the templating applied to all subsequently read input files
+func SetTemplate(opt *template.Options) {
+	templateOpt = opt
+}
+
+// source is the content of a single input file
+type source struct {
+	name    string
+	content []byte
+}
+
+// ReadInputs returns the documents of all the inputs as a single multi-document YAML file
+func ReadInputs(inputFiles []string, recursive bool) ([]byte, error) {
+	sources, err := readSources(inputFiles, recursive)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	for idx := range sources {
+		if idx > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(sources[idx].content)
+		if len(sources[idx].content) > 0 && !bytes.HasSuffix(sources[idx].content, []byte("\n")) {
+			out.WriteString("\n")
+		}
+	}
+	return out.Bytes(), nil
+}
+
+// readSources reads every input, which is a file, a directory, a glob pattern, - for stdin, or a file:// or
+// http(s):// URL. Kustomizations, and directories containing one, are built from their files.
+// All files are templated if templating is enabled.
+func readSources(inputFiles []string, recursive bool) (sources []source, err error) {
+	if len(inputFiles) == 0 {
+		return nil, util.NewInputError("No input file provided via the -f flag")
+	}
+	readStdin := false
+	for _, input := range inputFiles {
+		var inputSources []source
+		switch {
+		case input == "":
+			return nil, util.NewInputError("provided empty value for input file via the -f flag")
+		case input == StdinInput:
+			if readStdin {
+				return nil, util.NewInputError("Can only read stdin once")
+			}
+			readStdin = true
+			inputSources, err = readStdinSource()
+		case strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://"):
+			inputSources, err = readURL(input)
+		case strings.HasPrefix(input, "file://"):
+			fileURL, parseErr := url.Parse(input)
+			if parseErr != nil {
+				return nil, util.NewInputError(fmt.Sprintf("Invalid URL %s: %s", input, parseErr.Error()))
+			}
+			inputSources, err = readPath(fileURL.Path, recursive)
+		case strings.ContainsAny(input, "*?["):
+			inputSources, err = readGlob(input, recursive)
+		default:
+			inputSources, err = readPath(input, recursive)
+		}
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, inputSources...)
+	}
+	return sources, nil
+}
+
+func readGlob(pattern string, recursive bool) (sources []source, err error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, util.NewInputError(fmt.Sprintf("Invalid pattern %s: %s", pattern, err.Error()))
+	}
+	if len(matches) == 0 {
+		return nil, util.NewNotFoundError(fmt.Sprintf("No files match %s", pattern))
+	}
+	for _, match := range matches {
+		matchSources, err := readPath(match, recursive)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, matchSources...)
+	}
+	return sources, nil
+}
+
+// readPath reads a file, a kustomization, or the files of a directory in lexical order
+func readPath(path string, recursive bool) ([]source, error) {
+	if file, found := kustomize.Find(path); found {
+		content, err := kustomize.Build(file, readFile)
+		if err != nil {
+			return nil, err
+		}
+		return []source{{name: file, content: content}}, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		content, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return []source{{name: path, content: content}}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	sources := []source{}
+	for _, entry := range entries {
+		entryPath := filepath.Join(path, entry.Name())
+		if entry.IsDir() {
+			if !recursive {
+				continue
+			}
+			dirSources, err := readPath(entryPath, recursive)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, dirSources...)
+			continue
+		}
+		if !inputExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			continue
+		}
+		content, err := readFile(entryPath)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source{name: entryPath, content: content})
+	}
+	return sources, nil
+}
+
+func readFile(file string) ([]byte, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return template.Render(file, content, templateOpt)
+}
+
+// Content of stdin, kept since commands like apply read their inputs more than once
+var stdinContent []byte
+
+func readStdinSource() ([]source, error) {
+	if stdinContent == nil {
+		content, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		stdinContent = content
+	}
+	content, err := template.Render("stdin", stdinContent, templateOpt)
+	if err != nil {
+		return nil, err
+	}
+	return []source{{name: "stdin", content: content}}, nil
+}
+
+func readURL(inputURL string) ([]source, error) {
+	httpClient := &http.Client{Timeout: urlTimeout}
+	resp, err := httpClient.Get(inputURL)
+	if err != nil {
+		return nil, util.NewError(fmt.Sprintf("Failed to get %s: %s", inputURL, err.Error()))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.NewError(fmt.Sprintf("Failed to get %s: %s", inputURL, resp.Status))
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if content, err = template.Render(inputURL, content, templateOpt); err != nil {
+		return nil, err
+	}
+	return []source{{name: inputURL, content: content}}, nil
+}
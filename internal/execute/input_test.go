@@ -0,0 +1,105 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeInputs(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func sourceNames(sources []source) (names []string) {
+	for idx := range sources {
+		names = append(names, filepath.Base(sources[idx].name))
+	}
+	return
+}
+
+func TestReadSources(t *testing.T) {
+	dir := t.TempDir()
+	writeInputs(t, dir, map[string]string{
+		"b.yaml":        "kind: Secret\n",
+		"a.yml":         "kind: ConfigMap\n",
+		"notes.txt":     "not a document",
+		"apps/app.yaml": "kind: Application\n",
+	})
+
+	cases := []struct {
+		inputs    []string
+		recursive bool
+		expected  []string
+	}{
+		{[]string{dir}, false, []string{"a.yml", "b.yaml"}},
+		{[]string{dir}, true, []string{"a.yml", "app.yaml", "b.yaml"}},
+		{[]string{filepath.Join(dir, "*.yaml"), filepath.Join(dir, "apps")}, false, []string{"b.yaml", "app.yaml"}},
+		{[]string{"file://" + filepath.Join(dir, "a.yml")}, false, []string{"a.yml"}},
+	}
+	for _, c := range cases {
+		sources, err := readSources(c.inputs, c.recursive)
+		if err != nil {
+			t.Fatalf("Failed to read %v: %s", c.inputs, err.Error())
+		}
+		names := sourceNames(sources)
+		if len(names) != len(c.expected) {
+			t.Errorf("Unexpected sources of %v: %v", c.inputs, names)
+			continue
+		}
+		for idx := range names {
+			if names[idx] != c.expected[idx] {
+				t.Errorf("Unexpected sources of %v: %v", c.inputs, names)
+				break
+			}
+		}
+	}
+
+	if _, err := readSources([]string{filepath.Join(dir, "*.json")}, false); err == nil {
+		t.Errorf("Read pattern without matches")
+	}
+}
+
+func TestReadURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("kind: Application"))
+	}))
+	defer server.Close()
+
+	content, err := ReadInputs([]string{server.URL + "/app.yaml", server.URL + "/app.yaml"}, false)
+	if err != nil {
+		t.Fatalf("Failed to read URL: %s", err.Error())
+	}
+	if string(content) != "kind: Application\n---\nkind: Application\n" {
+		t.Errorf("Unexpected content %q", content)
+	}
+	if _, err := readSources([]string{server.URL + "/missing.yaml"}, false); err == nil {
+		t.Errorf("Read missing URL")
+	}
+}
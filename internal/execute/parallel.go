@@ -14,9 +14,14 @@
 package execute
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
+	"time"
+
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/pkg/util"
 )
 
 type jobResult struct {
@@ -43,16 +48,65 @@ func RunExecutors(executors []Executor, execType string) []error {
 	return []error{}
 }
 
+// RunKindExecutors runs executors of a single kind, retrying them according to the retry policy of the kind
+func RunKindExecutors(executors []Executor, kind config.Kind, execType string) []error {
+	if errs, _ := ForParallelKind(executors, kind); len(errs) > 0 {
+		return errs
+	}
+	return []error{}
+}
+
+// ForParallel runs the executors in parallel according to the policy set with SetPolicy
 func ForParallel(exes []Executor) (errs []error, failedExes []Executor) {
-	// Instantiate wait group for parallel tasks
+	return forParallel(exes, getRetry(""))
+}
+
+// ForParallelKind runs executors of a single kind in parallel, retrying them according to the retry policy of the kind
+func ForParallelKind(exes []Executor, kind config.Kind) (errs []error, failedExes []Executor) {
+	return forParallel(exes, getRetry(kind))
+}
+
+// ForParallelOnce runs the executors in parallel without retrying them, for executors whose side effects must not be repeated
+func ForParallelOnce(exes []Executor) (errs []error, failedExes []Executor) {
+	return forParallel(exes, Retry{})
+}
+
+func forParallel(exes []Executor, retry Retry) (errs []error, failedExes []Executor) {
+	if len(exes) == 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Limit the number of executors running at once
+	limit := policy.Parallelism
+	if limit <= 0 || limit > len(exes) {
+		limit = len(exes)
+	}
+	slots := make(chan struct{}, limit)
+
 	var wg sync.WaitGroup
-	// Deploy controllers
 	errChan := make(chan jobResult, len(exes))
+	skipped := 0
 	for idx := range exes {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			// Fail fast, outstanding executors are not started
+			skipped++
+			failedExes = append(failedExes, exes[idx])
+			continue
+		}
 		wg.Add(1)
 		go func(exe Executor) {
 			defer wg.Done()
-			err := exe.Execute()
+			defer func() { <-slots }()
+			err := executeWithRetry(ctx, exe, retry)
+			if err != nil && policy.FailFast {
+				cancel()
+			}
 			errChan <- jobResult{
 				err: err,
 				exe: exe,
@@ -69,6 +123,29 @@ func ForParallel(exes []Executor) (errs []error, failedExes []Executor) {
 			failedExes = append(failedExes, result.exe)
 		}
 	}
+	if skipped > 0 {
+		errs = append(errs, util.NewError(fmt.Sprintf("Skipped %d remaining executors after the first failure", skipped)))
+	}
 
 	return
 }
+
+// executeWithRetry runs the executor, retrying on transient errors with exponential backoff.
+// Running executors are not interrupted, ctx only stops the retries.
+func executeWithRetry(ctx context.Context, exe Executor, retry Retry) error {
+	backoff := retry.Backoff
+	for attempt := 0; ; attempt++ {
+		err := exe.Execute()
+		if err == nil || attempt >= retry.Attempts || !isTransient(err) {
+			return err
+		}
+
+		util.PrintNotify(fmt.Sprintf("%s failed, retrying in %s (%d/%d): %s", exe.GetName(), backoff, attempt+1, retry.Attempts, err.Error()))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// concurrency records the maximum number of executors running at once
+type concurrency struct {
+	mutex   sync.Mutex
+	running int
+	max     int
+}
+
+func (c *concurrency) start() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.running++
+	if c.running > c.max {
+		c.max = c.running
+	}
+}
+
+func (c *concurrency) stop() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.running--
+}
+
+type testExecutor struct {
+	name        string
+	errs        []error
+	calls       int32
+	concurrency *concurrency
+}
+
+func (exe *testExecutor) Execute() error {
+	call := int(atomic.AddInt32(&exe.calls, 1)) - 1
+	if exe.concurrency != nil {
+		exe.concurrency.start()
+		time.Sleep(10 * time.Millisecond)
+		exe.concurrency.stop()
+	}
+	if call < len(exe.errs) {
+		return exe.errs[call]
+	}
+	return nil
+}
+
+func (exe *testExecutor) GetName() string {
+	return exe.name
+}
+
+func TestForParallelLimitsParallelism(t *testing.T) {
+	defer SetPolicy(policy)
+	SetPolicy(Policy{Parallelism: 3, Retries: -1})
+
+	tracker := &concurrency{}
+	exes := []Executor{}
+	for idx := 0; idx < 12; idx++ {
+		exes = append(exes, &testExecutor{name: fmt.Sprintf("exe-%d", idx), concurrency: tracker})
+	}
+
+	if errs, _ := ForParallel(exes); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if tracker.max > 3 {
+		t.Errorf("Expected at most 3 executors at once, got %d", tracker.max)
+	}
+}
+
+func TestForParallelFailFast(t *testing.T) {
+	defer SetPolicy(policy)
+	SetPolicy(Policy{Parallelism: 1, FailFast: true, Retries: 0})
+
+	exes := []Executor{
+		&testExecutor{name: "failing", errs: []error{util.NewInputError("invalid")}},
+		&testExecutor{name: "second"},
+		&testExecutor{name: "third"},
+	}
+	errs, failed := ForParallel(exes)
+	if len(errs) != 2 {
+		t.Fatalf("Expected the failure and the skipped executors to be reported, got %v", errs)
+	}
+	if len(failed) != 3 {
+		t.Errorf("Expected 3 executors not to succeed, got %d", len(failed))
+	}
+	for _, exe := range exes[1:] {
+		if calls := exe.(*testExecutor).calls; calls != 0 {
+			t.Errorf("Expected %s not to be executed, got %d calls", exe.GetName(), calls)
+		}
+	}
+}
+
+func TestExecuteWithRetry(t *testing.T) {
+	tests := []struct {
+		name  string
+		errs  []error
+		calls int32
+		fail  bool
+	}{
+		{"success", nil, 1, false},
+		{"transient then success", []error{errors.New("dial tcp: i/o timeout")}, 2, false},
+		{"transient exhausted", []error{errors.New("connection refused"), errors.New("connection refused"), errors.New("connection refused")}, 3, true},
+		{"input error not retried", []error{util.NewInputError("connection refused by config")}, 1, true},
+		{"server error retried", []error{util.NewHTTPError("unavailable", 503)}, 2, false},
+		{"client error not retried", []error{util.NewHTTPError("bad request", 400)}, 1, true},
+	}
+	retry := Retry{Attempts: 2, Backoff: time.Millisecond}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exe := &testExecutor{name: test.name, errs: test.errs}
+			err := executeWithRetry(t.Context(), exe, retry)
+			if (err != nil) != test.fail {
+				t.Errorf("Expected failure %v, got %v", test.fail, err)
+			}
+			if exe.calls != test.calls {
+				t.Errorf("Expected %d calls, got %d", test.calls, exe.calls)
+			}
+		})
+	}
+}
+
+func TestForParallelDoesNotRetryByDefault(t *testing.T) {
+	defer SetPolicy(policy)
+	SetPolicy(Policy{Parallelism: DefaultParallelism})
+
+	exe := &testExecutor{name: "transient", errs: []error{errors.New("connection refused")}}
+	if errs, _ := ForParallelKind([]Executor{exe}, config.RemoteAgentKind); len(errs) != 1 {
+		t.Fatalf("Expected the transient error to be reported, got %v", errs)
+	}
+	if exe.calls != 1 {
+		t.Errorf("Expected a single call, got %d", exe.calls)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// DefaultParallelism is the default maximum number of executors running at once
+const DefaultParallelism = 10
+
+// Policy controls how executors are run in parallel
+type Policy struct {
+	// Maximum number of executors running at once, unbounded if not positive
+	Parallelism int
+	// Stop starting executors after the first failure, running executors are not interrupted
+	FailFast bool
+	// Number of retries of executors failing with transient errors, none if not positive.
+	// Executors are not idempotent, e.g. a retried deploy can provision twice, so retrying is opt-in.
+	Retries int
+}
+
+// Retry is the policy applied to executors failing with transient errors, only the backoff depends on the kind
+type Retry struct {
+	// Number of retries after the first attempt
+	Attempts int
+	// Delay before the first retry, doubled for each following retry
+	Backoff time.Duration
+}
+
+var policy = Policy{
+	Parallelism: DefaultParallelism,
+}
+
+// Delay before the first retry of kinds without a specific one
+const defaultBackoff = 2 * time.Second
+
+// SSH based kinds give hosts more time to come back
+var kindBackoffs = map[config.Kind]time.Duration{
+	config.RemoteAgentKind:      5 * time.Second,
+	config.RemoteControllerKind: 5 * time.Second,
+	config.VolumeKind:           5 * time.Second,
+	config.OfflineImageKind:     10 * time.Second,
+}
+
+// SetPolicy sets the policy of all subsequent parallel executions
+func SetPolicy(p Policy) {
+	policy = p
+}
+
+func getRetry(kind config.Kind) Retry {
+	if policy.Retries <= 0 {
+		return Retry{}
+	}
+	backoff, found := kindBackoffs[kind]
+	if !found {
+		backoff = defaultBackoff
+	}
+	return Retry{Attempts: policy.Retries, Backoff: backoff}
+}
+
+// Fragments of error messages returned by the SSH and HTTP clients for failures worth retrying
+var transientMessages = []string{
+	"connection refused",
+	"connection reset",
+	"broken pipe",
+	"i/o timeout",
+	"timed out",
+	"timeout",
+	"handshake failed",
+	"no route to host",
+	"unexpected eof",
+	"bad gateway",
+	"service unavailable",
+	"gateway timeout",
+	"too many requests",
+}
+
+// isTransient reports whether err is likely to go away when retried
+func isTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// Errors caused by the input or the state of resources won't change
+	var inputErr *util.InputError
+	var notFoundErr *util.NotFoundError
+	var conflictErr *util.ConflictError
+	var hostKeyErr *util.HostKeyError
+	var unmarshalErr *util.UnmarshalError
+	if errors.As(err, &inputErr) || errors.As(err, &notFoundErr) || errors.As(err, &conflictErr) ||
+		errors.As(err, &hostKeyErr) || errors.As(err, &unmarshalErr) {
+		return false
+	}
+	var httpErr *util.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code == 429 || httpErr.Code >= 500
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range transientMessages {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -17,7 +17,6 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/datasance/potctl/internal/config"
 	"github.com/datasance/potctl/pkg/util"
@@ -56,7 +55,9 @@ func NewEmptyExecutor(name string) Executor {
 	}
 }
 
-func generateExecutor(header *config.Header, namespace string, kindHandlers map[config.Kind]func(*KindHandlerOpt) (Executor, error)) (exe Executor, err error) {
+// GenerateExecutor validates a document and resolves its kind to an executor through kindHandlers.
+// A nil executor is returned for kinds that have no handler.
+func GenerateExecutor(header *config.Header, namespace string, kindHandlers map[config.Kind]func(*KindHandlerOpt) (Executor, error)) (exe Executor, err error) {
 	if len(header.Metadata.Namespace) > 0 && namespace != header.Metadata.Namespace {
 		msg := "The Namespace provided by the %s named '%s' does not match the Namespace '%s'. You must pass '--namespace %s' to perform this command"
 		return nil, util.NewInputError(fmt.Sprintf(msg, header.Kind, header.Metadata.Name, namespace, header.Metadata.Namespace))
@@ -111,40 +112,25 @@ type KindHandlerOpt struct {
 	Tags      *[]string
 }
 
-func GetExecutorsFromYAML(inputFile, namespace string, kindHandlers map[config.Kind]func(*KindHandlerOpt) (Executor, error)) (executorsMap map[config.Kind][]Executor, err error) {
-	yamlFile, err := os.ReadFile(inputFile)
+// GetExecutorsFromYAML generates the executors of the documents of all the input files, mapped by kind
+func GetExecutorsFromYAML(inputFiles []string, recursive bool, namespace string, kindHandlers map[config.Kind]func(*KindHandlerOpt) (Executor, error)) (executorsMap map[config.Kind][]Executor, err error) {
+	headers, err := GetHeadersFromYAML(inputFiles, recursive)
 	if err != nil {
 		return
 	}
 
-	r := bytes.NewReader(yamlFile)
-	dec := yaml.NewDecoder(r)
-	dec.SetStrict(true)
-
-	var h headerDecode
-
 	// Generate all executors
 	empty := true
 	executorsMap = make(map[config.Kind][]Executor)
-	decodeErr := dec.Decode(&h)
-	for decodeErr == nil {
-		header := headerDecodeToHeader(&h)
-		exe, err := generateExecutor(header, namespace, kindHandlers)
+	for idx := range headers {
+		exe, err := GenerateExecutor(&headers[idx], namespace, kindHandlers)
 		if err != nil {
 			return nil, err
 		}
 		if exe != nil {
 			empty = false
-			executorsMap[header.Kind] = append(executorsMap[header.Kind], exe)
+			executorsMap[headers[idx].Kind] = append(executorsMap[headers[idx].Kind], exe)
 		}
-
-		// Reset for next document
-		h = headerDecode{}
-
-		decodeErr = dec.Decode(&h)
-	}
-	if decodeErr != io.EOF {
-		return nil, decodeErr
 	}
 
 	if empty {
@@ -154,6 +140,36 @@ func GetExecutorsFromYAML(inputFile, namespace string, kindHandlers map[config.K
 	return executorsMap, err
 }
 
+// GetHeadersFromYAML decodes every document of the input files
+func GetHeadersFromYAML(inputFiles []string, recursive bool) (headers []config.Header, err error) {
+	sources, err := readSources(inputFiles, recursive)
+	if err != nil {
+		return
+	}
+
+	for idx := range sources {
+		dec := yaml.NewDecoder(bytes.NewReader(sources[idx].content))
+		dec.SetStrict(true)
+
+		var h headerDecode
+
+		decodeErr := dec.Decode(&h)
+		for decodeErr == nil {
+			headers = append(headers, *headerDecodeToHeader(&h))
+
+			// Reset for next document
+			h = headerDecode{}
+
+			decodeErr = dec.Decode(&h)
+		}
+		if decodeErr != io.EOF {
+			return nil, util.NewInputError(fmt.Sprintf("Failed to decode %s: %s", sources[idx].name, decodeErr.Error()))
+		}
+	}
+
+	return headers, nil
+}
+
 // headerDecodeToHeader converts headerDecode to config.Header, building Spec from
 // top-level rules/roleRef/subjects when present (Controller-style RBAC YAML).
 func headerDecodeToHeader(h *headerDecode) *config.Header {
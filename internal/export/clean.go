@@ -0,0 +1,99 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package export
+
+import "github.com/datasance/potctl/internal/config/secretstore"
+
+// RedactedValue replaces sensitive values when redacting
+const RedactedValue = "REDACTED"
+
+// Keys of values generated by the Controller or potctl, stripped at any depth
+var generatedKeys = map[string]bool{
+	"uuid":          true,
+	"created":       true,
+	"createdAt":     true,
+	"updatedAt":     true,
+	"accessToken":   true,
+	"refreshToken":  true,
+	"validFrom":     true,
+	"validTo":       true,
+	"serialNumber":  true,
+	"daysRemaining": true,
+	"isExpired":     true,
+	// Pods discovered from the Kubernetes cluster
+	"controllerPods": true,
+}
+
+// Keys of generated values only stripped at the root of a spec, deeper ones may be references
+var generatedRootKeys = map[string]bool{
+	"id": true,
+}
+
+// strip removes the generated values from a generic YAML document
+func strip(value interface{}, root bool) interface{} {
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		stripped := make(map[interface{}]interface{}, len(typed))
+		for key, child := range typed {
+			name, _ := key.(string)
+			if generatedKeys[name] || (root && generatedRootKeys[name]) {
+				continue
+			}
+			stripped[key] = strip(child, false)
+		}
+		return stripped
+	case []interface{}:
+		stripped := make([]interface{}, len(typed))
+		for idx := range typed {
+			stripped[idx] = strip(typed[idx], false)
+		}
+		return stripped
+	}
+	return value
+}
+
+// redact replaces the sensitive values of a generic YAML document, those the secret stores keep out of namespace
+// files, or all its values when all is set
+func redact(value interface{}, all bool) interface{} {
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		redacted := make(map[interface{}]interface{}, len(typed))
+		for key, child := range typed {
+			name, _ := key.(string)
+			_, isValue := child.(string)
+			switch {
+			case all || (isValue && secretstore.IsSensitiveKey(name)):
+				redacted[key] = redactValue(child)
+			default:
+				redacted[key] = redact(child, false)
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(typed))
+		for idx := range typed {
+			redacted[idx] = redact(typed[idx], all)
+		}
+		return redacted
+	}
+	return value
+}
+
+// redactValue replaces a value, keeping empty values empty so that optional fields stay unset
+func redactValue(value interface{}) interface{} {
+	if value == nil || value == "" {
+		return value
+	}
+	return RedactedValue
+}
@@ -0,0 +1,113 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package export
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStrip(t *testing.T) {
+	spec := map[interface{}]interface{}{
+		"id":   "42",
+		"name": "db",
+		"uuid": "0f1e",
+		"ports": []interface{}{
+			map[interface{}]interface{}{"id": "7", "internal": 5432, "createdAt": "2026-01-01"},
+		},
+	}
+	expected := map[interface{}]interface{}{
+		"name": "db",
+		"ports": []interface{}{
+			map[interface{}]interface{}{"id": "7", "internal": 5432},
+		},
+	}
+	if stripped := strip(spec, true); !reflect.DeepEqual(stripped, expected) {
+		t.Errorf("Unexpected stripped spec %v", stripped)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	spec := map[interface{}]interface{}{
+		"user":     "admin",
+		"password": "secret",
+		"ssh":      map[interface{}]interface{}{"privateKey": "key"},
+		"auth":     map[interface{}]interface{}{"controllerSecret": ""},
+	}
+	expected := map[interface{}]interface{}{
+		"user":     "admin",
+		"password": RedactedValue,
+		"ssh":      map[interface{}]interface{}{"privateKey": RedactedValue},
+		"auth":     map[interface{}]interface{}{"controllerSecret": ""},
+	}
+	if redacted := redact(spec, false); !reflect.DeepEqual(redacted, expected) {
+		t.Errorf("Unexpected redacted spec %v", redacted)
+	}
+
+	// Every value the secret stores keep out of namespace files
+	controlPlane := map[interface{}]interface{}{
+		"controllers": []interface{}{
+			map[interface{}]interface{}{
+				"name": "ctrl",
+				"config": map[interface{}]interface{}{
+					"https":   map[interface{}]interface{}{"tlsCert": "cert", "tlsKey": "key"},
+					"siteCA":  map[interface{}]interface{}{"tlsCert": "cert", "tlsKey": "key"},
+					"localCA": map[interface{}]interface{}{"tlsCert": "cert", "tlsKey": "key"},
+				},
+			},
+		},
+		"vault": map[interface{}]interface{}{
+			"hashicorp": map[interface{}]interface{}{"address": "https://vault", "token": "token"},
+			"aws":       map[interface{}]interface{}{"accessKeyId": "id", "accessKey": "key"},
+			"azure":     map[interface{}]interface{}{"clientId": "id", "clientSecret": "secret"},
+			"google":    map[interface{}]interface{}{"projectId": "project", "credentials": "json"},
+		},
+		"user": map[interface{}]interface{}{"email": "user@domain.com", "subscriptionKey": "key"},
+		"images": map[interface{}]interface{}{
+			"credentials": map[interface{}]interface{}{"user": "admin", "password": "secret"},
+		},
+	}
+	expected = map[interface{}]interface{}{
+		"controllers": []interface{}{
+			map[interface{}]interface{}{
+				"name": "ctrl",
+				"config": map[interface{}]interface{}{
+					"https":   map[interface{}]interface{}{"tlsCert": "cert", "tlsKey": RedactedValue},
+					"siteCA":  map[interface{}]interface{}{"tlsCert": "cert", "tlsKey": RedactedValue},
+					"localCA": map[interface{}]interface{}{"tlsCert": "cert", "tlsKey": RedactedValue},
+				},
+			},
+		},
+		"vault": map[interface{}]interface{}{
+			"hashicorp": map[interface{}]interface{}{"address": "https://vault", "token": RedactedValue},
+			"aws":       map[interface{}]interface{}{"accessKeyId": "id", "accessKey": RedactedValue},
+			"azure":     map[interface{}]interface{}{"clientId": "id", "clientSecret": RedactedValue},
+			"google":    map[interface{}]interface{}{"projectId": "project", "credentials": RedactedValue},
+		},
+		"user": map[interface{}]interface{}{"email": "user@domain.com", "subscriptionKey": RedactedValue},
+		// Objects named after a sensitive key are redacted field by field
+		"images": map[interface{}]interface{}{
+			"credentials": map[interface{}]interface{}{"user": "admin", "password": RedactedValue},
+		},
+	}
+	if redacted := redact(controlPlane, false); !reflect.DeepEqual(redacted, expected) {
+		t.Errorf("Unexpected redacted control plane %v", redacted)
+	}
+
+	data := map[interface{}]interface{}{"token": "abc", "empty": ""}
+	expected = map[interface{}]interface{}{"token": RedactedValue, "empty": ""}
+	if redacted := redact(data, true); !reflect.DeepEqual(redacted, expected) {
+		t.Errorf("Unexpected redacted data %v", redacted)
+	}
+}
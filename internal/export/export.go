@@ -0,0 +1,198 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/deploy"
+	"github.com/datasance/potctl/internal/describe"
+	"github.com/datasance/potctl/internal/execute"
+	"github.com/datasance/potctl/internal/get"
+	rsc "github.com/datasance/potctl/internal/resource"
+	clientutil "github.com/datasance/potctl/internal/util/client"
+	"github.com/datasance/potctl/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+type Options struct {
+	Namespace string
+	// Documents are written to stdout if empty
+	Filename      string
+	RedactSecrets bool
+}
+
+// Resources exported after the Control Plane, as listed by get. Applications include their Microservices
+// and Agents include their configuration. Catalog items, registries and offline images are not exported.
+var resources = []string{
+	"agents",
+	"secrets",
+	"certificates",
+	"configmaps",
+	"roles",
+	"rolebindings",
+	"serviceaccounts",
+	"nats-account-rules",
+	"nats-user-rules",
+	"edge-resources",
+	"application-templates",
+	"volumes",
+	"volume-mounts",
+	"applications",
+	"services",
+}
+
+// Control Planes are deployed before all the kinds of deploy.KindOrder
+var controlPlaneKinds = []config.Kind{
+	config.KubernetesControlPlaneKind,
+	config.RemoteControlPlaneKind,
+	config.LocalControlPlaneKind,
+}
+
+type executor struct {
+	opt Options
+}
+
+func NewExecutor(opt Options) execute.Executor {
+	return &executor{opt: opt}
+}
+
+func (exe *executor) GetName() string {
+	return exe.opt.Namespace
+}
+
+func (exe *executor) Execute() error {
+	headers, err := exe.listHeaders()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for idx := range headers {
+		header, err := cleanHeader(headers[idx], exe.opt.RedactSecrets)
+		if err != nil {
+			return err
+		}
+		doc, err := yaml.Marshal(header)
+		if err != nil {
+			return err
+		}
+		if idx > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(doc)
+	}
+
+	if exe.opt.Filename == "" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	// The documents contain credentials unless redacted
+	if err := os.WriteFile(exe.opt.Filename, buf.Bytes(), 0600); err != nil {
+		return err
+	}
+	util.PrintSuccess(fmt.Sprintf("Exported %d resources of Namespace %s to %s", len(headers), exe.opt.Namespace, exe.opt.Filename))
+	return nil
+}
+
+// listHeaders returns the documents of all the resources of the Namespace, in the order they are deployed
+func (exe *executor) listHeaders() ([]config.Header, error) {
+	namespace := exe.opt.Namespace
+	controlPlane, err := describe.GetHeader(&describe.Options{
+		Resource:  "controlplane",
+		Namespace: namespace,
+	})
+	if err != nil {
+		if rsc.IsNoControlPlaneError(err) {
+			return nil, util.NewInputError(fmt.Sprintf("Namespace %s has no Control Plane to export", namespace))
+		}
+		return nil, err
+	}
+
+	headers := []config.Header{controlPlane}
+	for _, resource := range resources {
+		if resource == "edge-resources" && clientutil.IsEdgeResourceCapable(namespace) != nil {
+			continue
+		}
+		listed, err := get.ListHeaders(resource, namespace)
+		if err != nil {
+			return nil, util.NewError(fmt.Sprintf("Failed to export %s: %s", resource, err.Error()))
+		}
+		headers = append(headers, listed...)
+	}
+	sortHeaders(headers)
+	return headers, nil
+}
+
+// sortHeaders orders the documents as deploy runs them, keeping the order of get within a kind
+func sortHeaders(headers []config.Header) {
+	rank := make(map[config.Kind]int)
+	for _, kind := range append(controlPlaneKinds, deploy.KindOrder()...) {
+		rank[kind] = len(rank)
+	}
+	rankOf := func(kind config.Kind) int {
+		if idx, found := rank[kind]; found {
+			return idx
+		}
+		return len(rank)
+	}
+	sort.SliceStable(headers, func(i, j int) bool {
+		return rankOf(headers[i].Kind) < rankOf(headers[j].Kind)
+	})
+}
+
+// cleanHeader strips the status, Namespace and generated values of a document so that it can be deployed
+// to any Namespace, and redacts its sensitive values if required
+func cleanHeader(header config.Header, redactSecrets bool) (config.Header, error) {
+	header.Status = nil
+	header.Metadata.Namespace = ""
+	spec, err := toGeneric(header.Spec)
+	if err != nil {
+		return header, err
+	}
+	data, err := toGeneric(header.Data)
+	if err != nil {
+		return header, err
+	}
+	spec = strip(spec, true)
+	data = strip(data, true)
+	if redactSecrets {
+		spec = redact(spec, false)
+		// All the data of Secrets is sensitive
+		data = redact(data, header.Kind == config.SecretKind)
+	}
+	header.Spec = spec
+	header.Data = data
+	return header, nil
+}
+
+// toGeneric converts typed resources to generic YAML values
+func toGeneric(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	doc, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := yaml.Unmarshal(doc, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
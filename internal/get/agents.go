@@ -19,20 +19,25 @@ import (
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
 	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
+	"github.com/datasance/potctl/internal/util/selector"
 	"github.com/datasance/potctl/pkg/util"
 )
 
 type agentExecutor struct {
 	namespace    string
 	showDetached bool
+	wide         bool
+	selector     *selector.Selector
 }
 
-func newAgentExecutor(namespace string, showDetached bool) *agentExecutor {
+func newAgentExecutor(namespace string, showDetached, wide bool) *agentExecutor {
 	a := &agentExecutor{}
 	a.namespace = namespace
 	a.showDetached = showDetached
+	a.wide = wide
 	return a
 }
 
@@ -43,14 +48,14 @@ func (exe *agentExecutor) GetName() string {
 func (exe *agentExecutor) Execute() error {
 	if exe.showDetached {
 		printDetached()
-		table, err := generateDetachedAgentOutput()
+		table, err := generateDetachedAgentOutput(exe.wide)
 		if err != nil {
 			return err
 		}
 		return print(table)
 	}
 	printNamespace(exe.namespace)
-	table, err := generateAgentOutput(exe.namespace)
+	table, err := generateAgentOutput(exe.namespace, exe.selector, exe.wide)
 	if err != nil {
 		return err
 	}
@@ -58,7 +63,42 @@ func (exe *agentExecutor) Execute() error {
 	return print(table)
 }
 
-func generateDetachedAgentOutput() (table [][]string, err error) {
+func (exe *agentExecutor) generateTable() ([][]string, error) {
+	if exe.showDetached {
+		return generateDetachedAgentOutput(exe.wide)
+	}
+	return generateAgentOutput(exe.namespace, exe.selector, exe.wide)
+}
+
+func (exe *agentExecutor) listHeaders() ([]config.Header, error) {
+	if exe.showDetached {
+		headers := []config.Header{}
+		for _, agent := range config.GetDetachedAgents() {
+			header, err := describe.GetHeader(&describe.Options{
+				Resource:   "agent",
+				Name:       agent.GetName(),
+				IsDetached: true,
+			})
+			if err != nil {
+				return nil, err
+			}
+			headers = append(headers, header)
+		}
+		return headers, nil
+	}
+
+	agents, err := listSelectedAgents(exe.namespace, exe.selector)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(agents))
+	for idx := range agents {
+		names[idx] = agents[idx].Name
+	}
+	return describeAll("agent", exe.namespace, names)
+}
+
+func generateDetachedAgentOutput(wide bool) (table [][]string, err error) {
 	detachedAgents := config.GetDetachedAgents()
 	// Make an index of agents the client knows about and pre-process any info
 	agentsToPrint := make([]client.AgentInfo, len(detachedAgents))
@@ -68,28 +108,55 @@ func generateDetachedAgentOutput() (table [][]string, err error) {
 			IPAddressExternal: detachedAgents[idx].GetHost(),
 		}
 	}
-	return tabulateAgents(agentsToPrint)
+	return tabulateAgents(agentsToPrint, wide)
 }
 
-func generateAgentOutput(namespace string) (table [][]string, err error) {
-	agents := []client.AgentInfo{}
-	// Update local cache based on Controller
-	if err = clientutil.SyncAgentInfo(namespace); err != nil && !rsc.IsNoControlPlaneError(err) {
+func generateAgentOutput(namespace string, sel *selector.Selector, wide bool) (table [][]string, err error) {
+	agents, err := listSelectedAgents(namespace, sel)
+	if err != nil {
 		return
 	}
+	return tabulateAgents(agents, wide)
+}
 
-	// Get Agents from Controller
-	if err == nil {
-		agents, err = clientutil.GetBackendAgents(namespace)
-		if err != nil {
-			return
+func (exe *agentExecutor) setSelector(sel *selector.Selector) error {
+	if exe.showDetached {
+		return util.NewInputError("Cannot use a selector with detached Agents")
+	}
+	exe.selector = sel
+	return nil
+}
+
+// listSelectedAgents lists the Agents whose tags match the selector, or all Agents without a selector
+func listSelectedAgents(namespace string, sel *selector.Selector) ([]client.AgentInfo, error) {
+	agents, err := listAgents(namespace)
+	if err != nil || sel == nil {
+		return agents, err
+	}
+	selected := []client.AgentInfo{}
+	for idx := range agents {
+		if sel.MatchesTags(agents[idx].Tags) {
+			selected = append(selected, agents[idx])
 		}
 	}
+	return selected, nil
+}
 
-	return tabulateAgents(agents)
+func listAgents(namespace string) (agents []client.AgentInfo, err error) {
+	agents = []client.AgentInfo{}
+	// Update local cache based on Controller
+	if err = clientutil.SyncAgentInfo(namespace); err != nil {
+		if rsc.IsNoControlPlaneError(err) {
+			return agents, nil
+		}
+		return
+	}
+
+	// Get Agents from Controller
+	return clientutil.GetBackendAgents(namespace)
 }
 
-func tabulateAgents(agentInfos []client.AgentInfo) (table [][]string, err error) {
+func tabulateAgents(agentInfos []client.AgentInfo, wide bool) (table [][]string, err error) {
 	// Generate table and headers
 	table = make([][]string, len(agentInfos)+1)
 	headers := []string{
@@ -104,6 +171,9 @@ func tabulateAgents(agentInfos []client.AgentInfo) (table [][]string, err error)
 		"ENGINE",
 		// "VOLUME-MOUNTS",
 	}
+	if wide {
+		headers = append(headers, "UUID", "EXTERNAL ADDR")
+	}
 	table[0] = append(table[0], headers...)
 	// Populate rows
 	for idx := range agentInfos {
@@ -119,6 +189,10 @@ func tabulateAgents(agentInfos []client.AgentInfo) (table [][]string, err error)
 				agent.IPAddressExternal,
 				"-",
 				"-",
+				"-",
+			}
+			if wide {
+				row = append(row, "-", agent.IPAddressExternal)
 			}
 			table[idx+1] = append(table[idx+1], row...)
 		} else {
@@ -145,6 +219,9 @@ func tabulateAgents(agentInfos []client.AgentInfo) (table [][]string, err error)
 				agent.ContainerEngine,
 				// formatVolumeMounts(agent.VolumeMounts),
 			}
+			if wide {
+				row = append(row, agent.UUID, agent.IPAddressExternal)
+			}
 			table[idx+1] = append(table[idx+1], row...)
 		}
 	}
@@ -18,7 +18,7 @@ import (
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
 
-type tableFunc = func(string, tableChannel)
+type tableFunc = func(string, bool, tableChannel)
 
 var (
 	routines = []tableFunc{
@@ -40,11 +40,13 @@ type tableChannel chan tableQuery
 
 type allExecutor struct {
 	namespace string
+	wide      bool
 }
 
-func newAllExecutor(namespace string) *allExecutor {
+func newAllExecutor(namespace string, wide bool) *allExecutor {
 	exe := &allExecutor{}
 	exe.namespace = namespace
+	exe.wide = wide
 	return exe
 }
 
@@ -70,7 +72,7 @@ func (exe *allExecutor) Execute() error {
 		tableChans[idx] = make(tableChannel, 1)
 	}
 	for idx, routine := range routines {
-		go routine(exe.namespace, tableChans[idx])
+		go routine(exe.namespace, exe.wide, tableChans[idx])
 	}
 
 	// Start Printing
@@ -88,7 +90,40 @@ func (exe *allExecutor) Execute() error {
 	return nil
 }
 
-func getControllerTable(namespace string, tableChan tableChannel) {
+func (exe *allExecutor) listHeaders() ([]config.Header, error) {
+	// Check namespace exists
+	_, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	listers := []headerLister{
+		newControllerExecutor(exe.namespace),
+		newAgentExecutor(exe.namespace, false, false),
+	}
+	if err := clientutil.IsEdgeResourceCapable(exe.namespace); err == nil {
+		listers = append(listers, newEdgeResourceExecutor(exe.namespace))
+	}
+	listers = append(listers,
+		newApplicationExecutor(exe.namespace),
+		newSystemApplicationExecutor(exe.namespace),
+		newVolumeExecutor(exe.namespace),
+		newServiceExecutor(exe.namespace),
+		newVolumeMountExecutor(exe.namespace),
+	)
+
+	headers := []config.Header{}
+	for _, lister := range listers {
+		listed, err := lister.listHeaders()
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, listed...)
+	}
+	return headers, nil
+}
+
+func getControllerTable(namespace string, _ bool, tableChan tableChannel) {
 	table, err := generateControllerOutput(namespace)
 	tableChan <- tableQuery{
 		table: table,
@@ -96,15 +131,15 @@ func getControllerTable(namespace string, tableChan tableChannel) {
 	}
 }
 
-func getAgentTable(namespace string, tableChan tableChannel) {
-	table, err := generateAgentOutput(namespace)
+func getAgentTable(namespace string, wide bool, tableChan tableChannel) {
+	table, err := generateAgentOutput(namespace, nil, wide)
 	tableChan <- tableQuery{
 		table: table,
 		err:   err,
 	}
 }
 
-func getApplicationTable(namespace string, tableChan tableChannel) {
+func getApplicationTable(namespace string, _ bool, tableChan tableChannel) {
 	appExe := newApplicationExecutor(namespace)
 	if err := appExe.init(); err != nil {
 		tableChan <- tableQuery{err: err}
@@ -116,7 +151,7 @@ func getApplicationTable(namespace string, tableChan tableChannel) {
 	}
 }
 
-func getSystemApplicationTable(namespace string, tableChan tableChannel) {
+func getSystemApplicationTable(namespace string, _ bool, tableChan tableChannel) {
 	appExe := newSystemApplicationExecutor(namespace)
 	if err := appExe.init(); err != nil {
 		tableChan <- tableQuery{err: err}
@@ -128,7 +163,7 @@ func getSystemApplicationTable(namespace string, tableChan tableChannel) {
 	}
 }
 
-func getVolumeTable(namespace string, tableChan tableChannel) {
+func getVolumeTable(namespace string, _ bool, tableChan tableChannel) {
 	table, err := generateVolumeOutput(namespace)
 	tableChan <- tableQuery{
 		table: table,
@@ -136,7 +171,7 @@ func getVolumeTable(namespace string, tableChan tableChannel) {
 	}
 }
 
-func getEdgeResourceTable(namespace string, tableChan tableChannel) {
+func getEdgeResourceTable(namespace string, _ bool, tableChan tableChannel) {
 	table, err := generateEdgeResourceOutput(namespace)
 	tableChan <- tableQuery{
 		table: table,
@@ -144,7 +179,7 @@ func getEdgeResourceTable(namespace string, tableChan tableChannel) {
 	}
 }
 
-func getServiceTable(namespace string, tableChan tableChannel) {
+func getServiceTable(namespace string, _ bool, tableChan tableChannel) {
 	table, err := generateServicesOutput(namespace)
 	if err != nil {
 		tableChan <- tableQuery{err: err}
@@ -155,7 +190,7 @@ func getServiceTable(namespace string, tableChan tableChannel) {
 	}
 }
 
-func getVolumeMountTable(namespace string, tableChan tableChannel) {
+func getVolumeMountTable(namespace string, _ bool, tableChan tableChannel) {
 	table, err := generateVolumeMountsOutput(namespace)
 	tableChan <- tableQuery{
 		table: table,
@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 	"github.com/datasance/potctl/pkg/util"
@@ -52,6 +53,15 @@ func (exe *applicationExecutor) Execute() error {
 	return print(table)
 }
 
+func (exe *applicationExecutor) generateTable() ([][]string, error) {
+	// Start from empty indexes so that deleted Applications disappear
+	current := newApplicationExecutor(exe.namespace)
+	if err := current.init(); err != nil {
+		return nil, err
+	}
+	return current.generateApplicationOutput(), nil
+}
+
 func (exe *applicationExecutor) init() (err error) {
 	exe.client, err = clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
@@ -150,3 +160,14 @@ func (exe *applicationExecutor) generateApplicationOutput() (table [][]string) {
 
 	return table
 }
+
+func (exe *applicationExecutor) listHeaders() ([]config.Header, error) {
+	if err := exe.init(); err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for idx := range exe.flows {
+		names = append(names, exe.flows[idx].Name)
+	}
+	return describeAll("application", exe.namespace, names)
+}
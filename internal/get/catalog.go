@@ -18,6 +18,7 @@ import (
 
 	apps "github.com/datasance/iofog-go-sdk/v3/pkg/apps"
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
 
@@ -43,7 +44,35 @@ func (exe *catalogExecutor) GetName() string {
 	return ""
 }
 
+func (exe *catalogExecutor) listHeaders() ([]config.Header, error) {
+	items, err := listCatalogItems(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	headers := make([]config.Header, len(items))
+	for idx := range items {
+		headers[idx] = config.Header{
+			APIVersion: config.LatestAPIVersion,
+			Kind:       config.CatalogItemKind,
+			Metadata: config.HeaderMetadata{
+				Namespace: exe.namespace,
+				Name:      items[idx].Name,
+			},
+			Spec: items[idx],
+		}
+	}
+	return headers, nil
+}
+
 func generateCatalogOutput(namespace string) error {
+	items, err := listCatalogItems(namespace)
+	if err != nil {
+		return err
+	}
+	return tabulateCatalogItems(items)
+}
+
+func listCatalogItems(namespace string) ([]apps.CatalogItem, error) {
 	items := []apps.CatalogItem{}
 
 	// Connect to Controller if it is ready
@@ -51,13 +80,13 @@ func generateCatalogOutput(namespace string) error {
 	// Log into Controller
 	ctrlClient, err := clientutil.NewControllerClient(namespace)
 	if err != nil {
-		return tabulateCatalogItems(items)
+		return items, nil
 	}
 
 	// Get catalog from Controller
 	listCatalogResponse, err := ctrlClient.GetCatalog()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	for _, item := range listCatalogResponse.CatalogItems {
 		catalogItem := apps.CatalogItem{
@@ -78,7 +107,7 @@ func generateCatalogOutput(namespace string) error {
 		items = append(items, catalogItem)
 	}
 
-	return tabulateCatalogItems(items)
+	return items, nil
 }
 
 func tabulateCatalogItems(catalogItems []apps.CatalogItem) error {
@@ -16,10 +16,11 @@ package get
 import (
 	"fmt"
 	"strconv"
-	// "strings"
 	"time"
 
+	// "strings"
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
 
@@ -117,3 +118,19 @@ func tabulateCertificates(certificates []client.CertificateInfo) error {
 	// Print table
 	return print(table)
 }
+
+func (exe *certificateExecutor) listHeaders() ([]config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	certificateList, err := clt.ListCertificates()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for idx := range certificateList.Certificates {
+		names = append(names, certificateList.Certificates[idx].Name)
+	}
+	return describeAll("certificate", exe.namespace, names)
+}
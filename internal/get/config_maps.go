@@ -17,6 +17,7 @@ import (
 	"strconv"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
 
@@ -81,3 +82,19 @@ func tabulateConfigmaps(configMaps []client.ConfigMapInfo) error {
 	// Print table
 	return print(table)
 }
+
+func (exe *configmapExecutor) listHeaders() ([]config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	configmapList, err := clt.ListConfigMaps()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for idx := range configmapList.ConfigMaps {
+		names = append(names, configmapList.ConfigMaps[idx].Name)
+	}
+	return describeAll("configmap", exe.namespace, names)
+}
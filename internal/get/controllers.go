@@ -48,6 +48,10 @@ func (exe *controllerExecutor) Execute() error {
 	return print(table)
 }
 
+func (exe *controllerExecutor) generateTable() ([][]string, error) {
+	return generateControllerOutput(exe.namespace)
+}
+
 func generateControllerOutput(namespace string) (table [][]string, err error) {
 	// Get controller config details
 	ns, err := config.GetNamespace(namespace)
@@ -208,3 +212,15 @@ func updateControllerPods(controlPlane *rsc.KubernetesControlPlane, namespace st
 	}
 	return
 }
+
+func (exe *controllerExecutor) listHeaders() ([]config.Header, error) {
+	ns, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, ctrl := range ns.GetControllers() {
+		names = append(names, ctrl.GetName())
+	}
+	return describeAll("controller", exe.namespace, names)
+}
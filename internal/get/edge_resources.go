@@ -18,6 +18,7 @@ import (
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
 	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
@@ -45,7 +46,19 @@ func (exe *edgeResourceExecutor) Execute() error {
 	return print(table)
 }
 
+func (exe *edgeResourceExecutor) generateTable() ([][]string, error) {
+	return generateEdgeResourceOutput(exe.namespace)
+}
+
 func generateEdgeResourceOutput(namespace string) (table [][]string, err error) {
+	edgeResources, err := listEdgeResources(namespace)
+	if err != nil {
+		return
+	}
+	return tabulateEdgeResources(edgeResources)
+}
+
+func listEdgeResources(namespace string) (edgeResources []client.EdgeResourceMetadata, err error) {
 	_, err = config.GetNamespace(namespace)
 	if err != nil {
 		return
@@ -53,21 +66,18 @@ func generateEdgeResourceOutput(namespace string) (table [][]string, err error)
 
 	// Connect to Controller
 	clt, err := clientutil.NewControllerClient(namespace)
-	if err != nil && !rsc.IsNoControlPlaneError(err) {
+	if err != nil {
+		if rsc.IsNoControlPlaneError(err) {
+			return []client.EdgeResourceMetadata{}, nil
+		}
 		return
 	}
 
-	edgeResources := []client.EdgeResourceMetadata{}
-	if err == nil {
-		// Populate table
-		listResponse, err := clt.ListEdgeResources()
-		if err != nil {
-			return table, err
-		}
-		edgeResources = listResponse.EdgeResources
+	listResponse, err := clt.ListEdgeResources()
+	if err != nil {
+		return
 	}
-
-	return tabulateEdgeResources(edgeResources)
+	return listResponse.EdgeResources, nil
 }
 
 func tabulateEdgeResources(edgeResources []client.EdgeResourceMetadata) (table [][]string, err error) {
@@ -105,3 +115,25 @@ func tabulateEdgeResources(edgeResources []client.EdgeResourceMetadata) (table [
 	}
 	return table, err
 }
+
+func (exe *edgeResourceExecutor) listHeaders() ([]config.Header, error) {
+	edgeResources, err := listEdgeResources(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	// Each version of an Edge Resource is a separate document
+	headers := []config.Header{}
+	for idx := range edgeResources {
+		header, err := describe.GetHeader(&describe.Options{
+			Resource:  "edge-resource",
+			Namespace: exe.namespace,
+			Name:      edgeResources[idx].Name,
+			Version:   edgeResources[idx].Version,
+		})
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
@@ -14,24 +14,118 @@
 package get
 
 import (
+	"os"
+
+	"github.com/datasance/potctl/internal/config"
 	"github.com/datasance/potctl/internal/execute"
+	"github.com/datasance/potctl/internal/util/printer"
+	"github.com/datasance/potctl/internal/util/selector"
+	"github.com/datasance/potctl/internal/util/watch"
 	"github.com/datasance/potctl/pkg/util"
 )
 
-func NewExecutor(resourceType, namespace string, showDetached bool) (execute.Executor, error) {
+type Options struct {
+	Resource     string
+	Namespace    string
+	ShowDetached bool
+	Output       *printer.Options
+	// Watch polls for changes after the first print when set
+	Watch *watch.Options
+	// Selector filters Agents by their tags, and Microservices by the tags of their Agent
+	Selector *selector.Selector
+}
+
+// selectable is implemented by executors whose resources can be filtered with a selector
+type selectable interface {
+	setSelector(*selector.Selector) error
+}
+
+// headerLister is implemented by executors that can list their resources as the documents describe prints
+type headerLister interface {
+	listHeaders() ([]config.Header, error)
+}
+
+type structuredExecutor struct {
+	lister headerLister
+	output *printer.Options
+}
+
+func (exe *structuredExecutor) GetName() string {
+	return ""
+}
+
+func (exe *structuredExecutor) Execute() error {
+	headers, err := exe.lister.listHeaders()
+	if err != nil {
+		return err
+	}
+	return exe.output.PrintHeaders(os.Stdout, headers)
+}
+
+func NewExecutor(opt *Options) (execute.Executor, error) {
+	exe, err := newExecutor(opt.Resource, opt.Namespace, opt.ShowDetached, opt.Output.IsWide())
+	if err != nil {
+		return nil, err
+	}
+	if opt.Selector != nil {
+		selectableExe, ok := exe.(selectable)
+		if !ok {
+			return nil, util.NewInputError("Resource " + opt.Resource + " does not support selectors")
+		}
+		if err := selectableExe.setSelector(opt.Selector); err != nil {
+			return nil, err
+		}
+	}
+	if opt.Watch != nil {
+		if !opt.Output.IsTable() {
+			return nil, util.NewInputError("Cannot watch " + opt.Resource + " with structured output")
+		}
+		generator, ok := exe.(tableGenerator)
+		if !ok {
+			return nil, util.NewInputError("Resource " + opt.Resource + " does not support --watch")
+		}
+		return newWatchExecutor(opt.Resource, opt.Namespace, generator, opt.Watch), nil
+	}
+	if opt.Output.IsTable() {
+		return exe, nil
+	}
+	lister, ok := exe.(headerLister)
+	if !ok {
+		return nil, util.NewInputError("Resource " + opt.Resource + " does not support structured output")
+	}
+	return &structuredExecutor{
+		lister: lister,
+		output: opt.Output,
+	}, nil
+}
+
+// ListHeaders returns the documents of all the resources of a type, as printed with structured output
+func ListHeaders(resourceType, namespace string) ([]config.Header, error) {
+	exe, err := newExecutor(resourceType, namespace, false, false)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := exe.(headerLister)
+	if !ok {
+		return nil, util.NewInputError("Resource " + resourceType + " does not support structured output")
+	}
+	return lister.listHeaders()
+}
+
+func newExecutor(resourceType, namespace string, showDetached, wide bool) (execute.Executor, error) {
 	switch resourceType {
 	case "namespaces":
 		return newNamespaceExecutor(), nil
 	case "all":
-		return newAllExecutor(namespace), nil
+		return newAllExecutor(namespace, wide), nil
 	case "controllers":
 		return newControllerExecutor(namespace), nil
 	case "agents":
-		return newAgentExecutor(namespace, showDetached), nil
+		return newAgentExecutor(namespace, showDetached, wide), nil
 	case "microservices":
-		return newMicroserviceExecutor(namespace), nil
+		return newMicroserviceExecutor(namespace, wide), nil
 	case "system-microservices":
-		return newSystemMicroserviceExecutor(namespace), nil
+		return newSystemMicroserviceExecutor(namespace, wide), nil
 	case "application-templates":
 		return newApplicationTemplateExecutor(namespace), nil
 	case "applications":
@@ -16,11 +16,15 @@ package get
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
+	"github.com/datasance/potctl/internal/util/selector"
 	"github.com/datasance/potctl/pkg/util"
 )
 
@@ -29,11 +33,14 @@ type microserviceExecutor struct {
 	client     *client.Client
 	msvcPerID  map[string]*client.MicroserviceInfo
 	agentPerID map[string]*client.AgentInfo
+	wide       bool
+	selector   *selector.Selector
 }
 
-func newMicroserviceExecutor(namespace string) *microserviceExecutor {
+func newMicroserviceExecutor(namespace string, wide bool) *microserviceExecutor {
 	a := &microserviceExecutor{}
 	a.namespace = namespace
+	a.wide = wide
 	a.msvcPerID = make(map[string]*client.MicroserviceInfo)
 	a.agentPerID = make(map[string]*client.AgentInfo)
 	return a
@@ -62,9 +69,23 @@ func (exe *microserviceExecutor) init() (err error) {
 	for i := 0; i < len(listAgents.Agents); i++ {
 		exe.agentPerID[listAgents.Agents[i].UUID] = &listAgents.Agents[i]
 	}
+
+	// Only keep Microservices running on the selected Agents
+	if exe.selector != nil {
+		for uuid, ms := range exe.msvcPerID {
+			if agent, found := exe.agentPerID[ms.AgentUUID]; !found || !exe.selector.MatchesTags(agent.Tags) {
+				delete(exe.msvcPerID, uuid)
+			}
+		}
+	}
 	return
 }
 
+func (exe *microserviceExecutor) setSelector(sel *selector.Selector) error {
+	exe.selector = sel
+	return nil
+}
+
 func (exe *microserviceExecutor) GetName() string {
 	return ""
 }
@@ -79,10 +100,49 @@ func (exe *microserviceExecutor) Execute() error {
 	return print(table)
 }
 
+func (exe *microserviceExecutor) generateTable() ([][]string, error) {
+	// Start from empty indexes so that deleted Microservices disappear
+	current := newMicroserviceExecutor(exe.namespace, exe.wide)
+	current.selector = exe.selector
+	if err := current.init(); err != nil {
+		return nil, err
+	}
+	return current.generateMicroserviceOutput(), nil
+}
+
+func (exe *microserviceExecutor) listHeaders() ([]config.Header, error) {
+	if err := exe.init(); err != nil {
+		return nil, err
+	}
+	msvcPerName := make(map[string]*client.MicroserviceInfo)
+	names := []string{}
+	for _, ms := range exe.msvcPerID {
+		if util.IsSystemMsvc(ms) {
+			continue
+		}
+		name := ms.Application + "/" + ms.Name
+		msvcPerName[name] = ms
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	headers := make([]config.Header, len(names))
+	for idx, name := range names {
+		header, err := describe.MicroserviceHeader(exe.namespace, name, msvcPerName[name], exe.client)
+		if err != nil {
+			return nil, err
+		}
+		headers[idx] = header
+	}
+	return headers, nil
+}
+
 func (exe *microserviceExecutor) generateMicroserviceOutput() (table [][]string) {
 	// Generate table and headers
 	table = make([][]string, len(exe.msvcPerID)+1)
 	headers := []string{"MICROSERVICE", "STATUS", "AGENT", "NATS ACCESS", "VOLUMES", "PORTS"}
+	if exe.wide {
+		headers = append(headers, "APPLICATION", "UUID")
+	}
 	table[0] = append(table[0], headers...)
 
 	// Populate rows
@@ -146,6 +206,9 @@ func (exe *microserviceExecutor) generateMicroserviceOutput() (table [][]string)
 			volumes,
 			ports,
 		}
+		if exe.wide {
+			row = append(row, ms.Application, ms.UUID)
+		}
 		table[count+1] = append(table[count+1], row...)
 		count++
 	}
@@ -19,6 +19,8 @@ import (
 	"github.com/datasance/potctl/pkg/util"
 )
 
+const namespaceKind config.Kind = "Namespace"
+
 type namespaceExecutor struct {
 }
 
@@ -32,14 +34,9 @@ func (exe *namespaceExecutor) GetName() string {
 }
 
 func (exe *namespaceExecutor) Execute() error {
-	namespacesNames := config.GetNamespaces()
-	namespaces := make([]*rsc.Namespace, len(namespacesNames))
-	for idx, n := range namespacesNames {
-		ns, err := config.GetNamespace(n)
-		if err != nil {
-			return err
-		}
-		namespaces[idx] = ns
+	namespaces, err := listNamespaces()
+	if err != nil {
+		return err
 	}
 
 	// Generate table and headers
@@ -70,3 +67,39 @@ func (exe *namespaceExecutor) Execute() error {
 	// Print the table
 	return print(table)
 }
+
+func (exe *namespaceExecutor) listHeaders() ([]config.Header, error) {
+	namespaces, err := listNamespaces()
+	if err != nil {
+		return nil, err
+	}
+	headers := make([]config.Header, len(namespaces))
+	for idx, ns := range namespaces {
+		// Credentials and Control Plane details are left to describe namespace
+		headers[idx] = config.Header{
+			APIVersion: config.LatestAPIVersion,
+			Kind:       namespaceKind,
+			Metadata: config.HeaderMetadata{
+				Name: ns.Name,
+			},
+			Status: map[string]interface{}{
+				"created": ns.Created,
+				"default": ns.Name == config.GetDefaultNamespaceName(),
+			},
+		}
+	}
+	return headers, nil
+}
+
+func listNamespaces() ([]*rsc.Namespace, error) {
+	namespacesNames := config.GetNamespaces()
+	namespaces := make([]*rsc.Namespace, len(namespacesNames))
+	for idx, n := range namespacesNames {
+		ns, err := config.GetNamespace(n)
+		if err != nil {
+			return nil, err
+		}
+		namespaces[idx] = ns
+	}
+	return namespaces, nil
+}
@@ -1,6 +1,10 @@
 package get
 
-import clientutil "github.com/datasance/potctl/internal/util/client"
+import (
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
+	clientutil "github.com/datasance/potctl/internal/util/client"
+)
 
 type natsAccountRuleExecutor struct {
 	namespace string
@@ -39,3 +43,33 @@ func generateNatsAccountRulesOutput(namespace string) ([][]string, error) {
 	}
 	return table, nil
 }
+
+func (exe *natsAccountRuleExecutor) listHeaders() ([]config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := clt.ListNatsAccountRules()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]config.Header, len(response.Rules))
+	for idx := range response.Rules {
+		spec, err := describe.NatsSpec(response.Rules[idx], describe.NatsRuleIdentityFields...)
+		if err != nil {
+			return nil, err
+		}
+		headers[idx] = config.Header{
+			APIVersion: config.LatestAPIVersion,
+			Kind:       config.NatsAccountRuleKind,
+			Metadata: config.HeaderMetadata{
+				Namespace: exe.namespace,
+				Name:      response.Rules[idx].Name,
+			},
+			Spec: spec,
+		}
+	}
+	return headers, nil
+}
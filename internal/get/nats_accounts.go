@@ -3,9 +3,14 @@ package get
 import (
 	"fmt"
 
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
 
+// NATS accounts are created by the Controller, the kind is only used for output
+const natsAccountKind config.Kind = "NatsAccount"
+
 type natsAccountExecutor struct {
 	namespace string
 }
@@ -48,3 +53,33 @@ func generateNatsAccountsOutput(namespace string) ([][]string, error) {
 	}
 	return table, nil
 }
+
+func (exe *natsAccountExecutor) listHeaders() ([]config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := clt.ListNatsAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]config.Header, len(response.Accounts))
+	for idx := range response.Accounts {
+		spec, err := describe.NatsSpec(response.Accounts[idx], "name")
+		if err != nil {
+			return nil, err
+		}
+		headers[idx] = config.Header{
+			APIVersion: config.LatestAPIVersion,
+			Kind:       natsAccountKind,
+			Metadata: config.HeaderMetadata{
+				Namespace: exe.namespace,
+				Name:      response.Accounts[idx].Name,
+			},
+			Spec: spec,
+		}
+	}
+	return headers, nil
+}
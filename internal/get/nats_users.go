@@ -3,9 +3,14 @@ package get
 import (
 	"fmt"
 
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
 
+// NATS users are managed through potctl nats users, the kind is only used for output
+const natsUserKind config.Kind = "NatsUser"
+
 type natsUserExecutor struct {
 	namespace string
 }
@@ -49,3 +54,34 @@ func generateNatsUsersOutput(namespace string) ([][]string, error) {
 	}
 	return table, nil
 }
+
+func (exe *natsUserExecutor) listHeaders() ([]config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := clt.ListNatsUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]config.Header, len(response.Users))
+	for idx := range response.Users {
+		spec, err := describe.NatsSpec(response.Users[idx], "name")
+		if err != nil {
+			return nil, err
+		}
+		headers[idx] = config.Header{
+			APIVersion: config.LatestAPIVersion,
+			Kind:       natsUserKind,
+			Metadata: config.HeaderMetadata{
+				Namespace:       exe.namespace,
+				Name:            response.Users[idx].Name,
+				ApplicationName: response.Users[idx].ApplicationName,
+			},
+			Spec: spec,
+		}
+	}
+	return headers, nil
+}
@@ -16,36 +16,34 @@ package get
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
+
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
+	"github.com/datasance/potctl/internal/util/printer"
 )
 
 func print(table [][]string) error {
-	minWidth := 16
-	tabWidth := 8
-	padding := 1
-	writer := tabwriter.NewWriter(os.Stdout, minWidth, tabWidth, padding, '\t', 0)
-	defer writer.Flush()
-
-	for _, row := range table {
-		for _, col := range row {
-			_, err := fmt.Fprintf(writer, "%s\t", col)
-			if err != nil {
-				return err
-			}
-		}
-		_, err := fmt.Fprintf(writer, "\n")
-		if err != nil {
-			return err
-		}
-	}
-	_, err := fmt.Fprintf(writer, "\n")
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return printer.PrintTable(os.Stdout, table)
 }
 
 func printNamespace(namespace string) {
 	fmt.Printf("NAMESPACE\n%s\n\n", namespace)
 }
+
+// describeAll collects the documents describe would print for each named resource,
+// for the kinds whose list response does not carry the full spec
+func describeAll(resource, namespace string, names []string) ([]config.Header, error) {
+	headers := []config.Header{}
+	for _, name := range names {
+		header, err := describe.GetHeader(&describe.Options{
+			Resource:  resource,
+			Namespace: namespace,
+			Name:      name,
+		})
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
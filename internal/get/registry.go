@@ -17,6 +17,7 @@ import (
 	"strconv"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
 
@@ -85,3 +86,19 @@ func tabulateRegistries(catalogItems []client.RegistryInfo) error {
 	// Print table
 	return print(table)
 }
+
+func (exe *registryExecutor) listHeaders() ([]config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	registryList, err := clt.ListRegistries()
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for idx := range registryList.Registries {
+		ids = append(ids, strconv.Itoa(registryList.Registries[idx].ID))
+	}
+	return describeAll("registry", exe.namespace, ids)
+}
@@ -17,6 +17,8 @@ import (
 	"strconv"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
 
@@ -77,3 +79,20 @@ func tabulateRoles(roles []client.RoleInfo) error {
 
 	return print(table)
 }
+
+func (exe *roleExecutor) listHeaders() ([]config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	list, err := clt.ListRoles()
+	if err != nil {
+		return nil, err
+	}
+	headers := make([]config.Header, len(list.Roles))
+	for idx := range list.Roles {
+		role := &list.Roles[idx]
+		headers[idx] = describe.RoleHeader(exe.namespace, role.Name, role.Kind, role.Rules)
+	}
+	return headers, nil
+}
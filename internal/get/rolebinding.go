@@ -17,6 +17,8 @@ import (
 	"strconv"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
 
@@ -80,3 +82,20 @@ func tabulateRoleBindings(bindings []client.RoleBindingInfo) error {
 
 	return print(table)
 }
+
+func (exe *roleBindingExecutor) listHeaders() ([]config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	list, err := clt.ListRoleBindings()
+	if err != nil {
+		return nil, err
+	}
+	headers := make([]config.Header, len(list.Bindings))
+	for idx := range list.Bindings {
+		binding := &list.Bindings[idx]
+		headers[idx] = describe.RoleBindingHeader(exe.namespace, binding.Name, binding.Kind, binding.RoleRef, binding.Subjects)
+	}
+	return headers, nil
+}
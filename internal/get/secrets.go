@@ -17,6 +17,7 @@ import (
 	"strconv"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
 
@@ -81,3 +82,19 @@ func tabulateSecrets(secrets []client.SecretInfo) error {
 	// Print table
 	return print(table)
 }
+
+func (exe *secretExecutor) listHeaders() ([]config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	secretList, err := clt.ListSecrets()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for idx := range secretList.Secrets {
+		names = append(names, secretList.Secrets[idx].Name)
+	}
+	return describeAll("secret", exe.namespace, names)
+}
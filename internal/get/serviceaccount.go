@@ -15,6 +15,8 @@ package get
 
 import (
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
 
@@ -76,3 +78,20 @@ func tabulateServiceAccounts(accounts []client.ServiceAccountInfo) error {
 
 	return print(table)
 }
+
+func (exe *serviceAccountExecutor) listHeaders() ([]config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	list, err := clt.ListServiceAccounts("")
+	if err != nil {
+		return nil, err
+	}
+	headers := make([]config.Header, len(list.ServiceAccounts))
+	for idx := range list.ServiceAccounts {
+		sa := &list.ServiceAccounts[idx]
+		headers[idx] = describe.ServiceAccountHeader(exe.namespace, sa.Name, sa.ApplicationName, sa.RoleRef)
+	}
+	return headers, nil
+}
@@ -17,6 +17,9 @@ import (
 	"strconv"
 
 	// "github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
+	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
 
@@ -39,6 +42,10 @@ func (exe *serviceExecutor) Execute() error {
 	return print(table)
 }
 
+func (exe *serviceExecutor) generateTable() ([][]string, error) {
+	return generateServicesOutput(exe.namespace)
+}
+
 func (exe *serviceExecutor) GetName() string {
 	return ""
 }
@@ -106,3 +113,29 @@ func generateServicesOutput(namespace string) ([][]string, error) {
 
 	return table, nil
 }
+
+func (exe *serviceExecutor) listHeaders() ([]config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	serviceList, err := clt.ListServices()
+	if err != nil {
+		return nil, err
+	}
+	headers := make([]config.Header, len(serviceList.Services))
+	for idx := range serviceList.Services {
+		service := &serviceList.Services[idx]
+		headers[idx] = describe.ServiceHeader(exe.namespace, service.Name, service.Tags, rsc.ClusterService{
+			Type:            service.Type,
+			Resource:        service.Resource,
+			TargetPort:      service.TargetPort,
+			BridgePort:      service.BridgePort,
+			DefaultBridge:   service.DefaultBridge,
+			K8sType:         service.K8sType,
+			ServiceEndpoint: service.ServiceEndpoint,
+			ServicePort:     service.ServicePort,
+		})
+	}
+	return headers, nil
+}
@@ -17,6 +17,8 @@ import (
 	"fmt"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
@@ -49,6 +51,48 @@ func (exe *systemApplicationExecutor) Execute() error {
 	return print(table)
 }
 
+func (exe *systemApplicationExecutor) generateTable() ([][]string, error) {
+	// Start from empty indexes so that deleted Applications disappear
+	current := newSystemApplicationExecutor(exe.namespace)
+	if err := current.init(); err != nil {
+		return nil, err
+	}
+	return current.generateSystemApplicationOutput(), nil
+}
+
+func (exe *systemApplicationExecutor) listHeaders() ([]config.Header, error) {
+	if err := exe.init(); err != nil {
+		return nil, err
+	}
+	// System Applications cannot be described by name, map them the same way describe does
+	headers := []config.Header{}
+	for _, flow := range exe.flows {
+		yamlMsvcs := []rsc.Microservice{}
+		for _, msvc := range exe.msvcsPerApplication[flow.ID] {
+			yamlMsvc, _, _, err := describe.MapClientMicroserviceToDeployMicroservice(msvc, exe.client)
+			if err != nil {
+				return nil, err
+			}
+			yamlMsvc.Flow = nil
+			yamlMsvcs = append(yamlMsvcs, *yamlMsvc)
+		}
+		headers = append(headers, config.Header{
+			APIVersion: config.LatestAPIVersion,
+			Kind:       config.ApplicationKind,
+			Metadata: config.HeaderMetadata{
+				Namespace: exe.namespace,
+				Name:      flow.Name,
+			},
+			Spec: rsc.Application{
+				Name:          flow.Name,
+				Microservices: yamlMsvcs,
+				ID:            flow.ID,
+			},
+		})
+	}
+	return headers, nil
+}
+
 func (exe *systemApplicationExecutor) init() (err error) {
 	exe.client, err = clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
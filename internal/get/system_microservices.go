@@ -16,9 +16,12 @@ package get
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
@@ -28,11 +31,13 @@ type systemMicroserviceExecutor struct {
 	client     *client.Client
 	msvcPerID  map[string]*client.MicroserviceInfo
 	agentPerID map[string]*client.AgentInfo
+	wide       bool
 }
 
-func newSystemMicroserviceExecutor(namespace string) *systemMicroserviceExecutor {
+func newSystemMicroserviceExecutor(namespace string, wide bool) *systemMicroserviceExecutor {
 	a := &systemMicroserviceExecutor{}
 	a.namespace = namespace
+	a.wide = wide
 	a.msvcPerID = make(map[string]*client.MicroserviceInfo)
 	a.agentPerID = make(map[string]*client.AgentInfo)
 	return a
@@ -78,10 +83,45 @@ func (exe *systemMicroserviceExecutor) Execute() error {
 	return print(table)
 }
 
+func (exe *systemMicroserviceExecutor) generateTable() ([][]string, error) {
+	// Start from empty indexes so that deleted Microservices disappear
+	current := newSystemMicroserviceExecutor(exe.namespace, exe.wide)
+	if err := current.init(); err != nil {
+		return nil, err
+	}
+	return current.generateMicroserviceOutput(), nil
+}
+
+func (exe *systemMicroserviceExecutor) listHeaders() ([]config.Header, error) {
+	if err := exe.init(); err != nil {
+		return nil, err
+	}
+	msvcPerName := make(map[string]*client.MicroserviceInfo)
+	names := []string{}
+	for _, ms := range exe.msvcPerID {
+		name := ms.Application + "/" + ms.Name
+		msvcPerName[name] = ms
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	headers := make([]config.Header, len(names))
+	for idx, name := range names {
+		header, err := describe.MicroserviceHeader(exe.namespace, name, msvcPerName[name], exe.client)
+		if err != nil {
+			return nil, err
+		}
+		headers[idx] = header
+	}
+	return headers, nil
+}
+
 func (exe *systemMicroserviceExecutor) generateMicroserviceOutput() (table [][]string) {
 	// Generate table and headers
 	table = make([][]string, len(exe.msvcPerID)+1)
 	headers := []string{"SYS-MICROSERVICE", "STATUS", "AGENT", "VOLUMES", "PORTS"}
+	if exe.wide {
+		headers = append(headers, "APPLICATION", "UUID")
+	}
 	table[0] = append(table[0], headers...)
 
 	// Populate rows
@@ -140,6 +180,9 @@ func (exe *systemMicroserviceExecutor) generateMicroserviceOutput() (table [][]s
 			volumes,
 			ports,
 		}
+		if exe.wide {
+			row = append(row, ms.Application, ms.UUID)
+		}
 		table[count+1] = append(table[count+1], row...)
 		count++
 	}
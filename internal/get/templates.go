@@ -17,6 +17,8 @@ import (
 	"strconv"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
 	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
@@ -93,3 +95,14 @@ func (exe *applicationTemplateExecutor) generateApplicationTemplateOutput() (tab
 
 	return
 }
+
+func (exe *applicationTemplateExecutor) listHeaders() ([]config.Header, error) {
+	if err := exe.init(); err != nil {
+		return nil, err
+	}
+	headers := make([]config.Header, len(exe.templates))
+	for idx := range exe.templates {
+		headers[idx] = describe.ApplicationTemplateHeader(exe.namespace, exe.templates[idx].Name, &exe.templates[idx])
+	}
+	return headers, nil
+}
@@ -17,6 +17,9 @@ import (
 	"strconv"
 
 	// "github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/describe"
+	rsc "github.com/datasance/potctl/internal/resource"
 	clientutil "github.com/datasance/potctl/internal/util/client"
 )
 
@@ -39,6 +42,10 @@ func (exe *volumeMountExecutor) Execute() error {
 	return print(table)
 }
 
+func (exe *volumeMountExecutor) generateTable() ([][]string, error) {
+	return generateVolumeMountsOutput(exe.namespace)
+}
+
 func (exe *volumeMountExecutor) GetName() string {
 	return ""
 }
@@ -90,3 +97,26 @@ func generateVolumeMountsOutput(namespace string) ([][]string, error) {
 
 	return table, nil
 }
+
+func (exe *volumeMountExecutor) listHeaders() ([]config.Header, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	volumeMountList, err := clt.ListVolumeMounts()
+	if err != nil {
+		return nil, err
+	}
+	headers := make([]config.Header, len(volumeMountList.VolumeMounts))
+	for idx := range volumeMountList.VolumeMounts {
+		volumeMount := &volumeMountList.VolumeMounts[idx]
+		headers[idx] = describe.VolumeMountHeader(exe.namespace, rsc.VolumeMount{
+			Name:          volumeMount.Name,
+			UUID:          volumeMount.UUID,
+			ConfigMapName: volumeMount.ConfigMapName,
+			SecretName:    volumeMount.SecretName,
+			Version:       volumeMount.Version,
+		})
+	}
+	return headers, nil
+}
@@ -40,6 +40,10 @@ func (exe *volumeExecutor) Execute() error {
 	return print(table)
 }
 
+func (exe *volumeExecutor) generateTable() ([][]string, error) {
+	return generateVolumeOutput(exe.namespace)
+}
+
 func generateVolumeOutput(namespace string) (table [][]string, err error) {
 	ns, err := config.GetNamespace(namespace)
 	if err != nil {
@@ -80,3 +84,15 @@ func generateVolumeOutput(namespace string) (table [][]string, err error) {
 
 	return table, err
 }
+
+func (exe *volumeExecutor) listHeaders() ([]config.Header, error) {
+	ns, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, volume := range ns.GetVolumes() {
+		names = append(names, volume.Name)
+	}
+	return describeAll("volume", exe.namespace, names)
+}
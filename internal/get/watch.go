@@ -0,0 +1,204 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package get
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	clientutil "github.com/datasance/potctl/internal/util/client"
+	"github.com/datasance/potctl/internal/util/printer"
+	"github.com/datasance/potctl/internal/util/watch"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// tableGenerator is implemented by executors whose table can be regenerated to watch for changes
+type tableGenerator interface {
+	generateTable() ([][]string, error)
+}
+
+// Columns changing on every poll, ignored when comparing rows
+var volatileColumns = map[string]bool{
+	"AGE":    true,
+	"UPTIME": true,
+}
+
+type watchExecutor struct {
+	namespace string
+	resource  string
+	generator tableGenerator
+	opt       *watch.Options
+	// Rows of the last poll per key, volatile columns excluded
+	rows  map[string]string
+	first bool
+}
+
+func newWatchExecutor(resource, namespace string, generator tableGenerator, opt *watch.Options) *watchExecutor {
+	return &watchExecutor{
+		namespace: namespace,
+		resource:  resource,
+		generator: generator,
+		opt:       opt,
+		first:     true,
+	}
+}
+
+func (exe *watchExecutor) GetName() string {
+	return ""
+}
+
+func (exe *watchExecutor) Execute() error {
+	return watch.Poll(exe.opt, exe.poll)
+}
+
+func (exe *watchExecutor) poll() error {
+	// Agents are cached for the lifetime of the command, refresh them on every poll
+	clientutil.InvalidateAgentCache()
+	table, err := exe.generator.generateTable()
+	if err != nil {
+		return err
+	}
+	table = compactTable(table)
+	if len(table) == 0 {
+		return nil
+	}
+
+	if exe.opt.Events {
+		return exe.printEvents(table)
+	}
+
+	changed, rows := diffRows(exe.rows, table)
+	first := exe.first
+	exe.rows = rows
+	exe.first = false
+	if first {
+		printNamespace(exe.namespace)
+		return print(table)
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	// Render the whole table so that changed rows stay aligned with the first print
+	var buf bytes.Buffer
+	if err := printer.PrintTable(&buf, table); err != nil {
+		return err
+	}
+	lines := strings.Split(buf.String(), "\n")
+	for _, idx := range changed {
+		if idx < len(lines) {
+			fmt.Fprintln(os.Stdout, lines[idx])
+		}
+	}
+	return nil
+}
+
+// printEvents prints one line per Status transition, added and deleted resource since the last poll
+func (exe *watchExecutor) printEvents(table [][]string) error {
+	statusIdx := columnIndex(table[0], "STATUS")
+	if statusIdx < 0 {
+		return util.NewInputError(fmt.Sprintf("Resource %s has no status to watch for events", exe.resource))
+	}
+	kind := strings.TrimSuffix(exe.resource, "s")
+
+	statuses := make(map[string]string)
+	keys := []string{}
+	for _, row := range table[1:] {
+		key := rowKey(table[0], row)
+		statuses[key] = cell(row, statusIdx)
+		keys = append(keys, key)
+	}
+	first := exe.first
+	exe.first = false
+	previous := exe.rows
+	exe.rows = statuses
+	if first {
+		for _, key := range keys {
+			fmt.Println(watch.FormatEvent(kind, key, "", statuses[key]))
+		}
+		return nil
+	}
+
+	for _, key := range keys {
+		if status := statuses[key]; status != previous[key] {
+			fmt.Println(watch.FormatEvent(kind, key, previous[key], status))
+		}
+	}
+	for key := range previous {
+		if _, found := statuses[key]; !found {
+			fmt.Println(watch.FormatEvent(kind, key, previous[key], ""))
+		}
+	}
+	return nil
+}
+
+// diffRows returns the indexes of the rows of the table which are new or changed since previous,
+// along with the rows to compare the next poll against
+func diffRows(previous map[string]string, table [][]string) (changed []int, rows map[string]string) {
+	headers := table[0]
+	rows = make(map[string]string)
+	for idx, row := range table[1:] {
+		key := rowKey(headers, row)
+		values := []string{}
+		for col, value := range row {
+			if col < len(headers) && volatileColumns[headers[col]] {
+				continue
+			}
+			values = append(values, value)
+		}
+		rows[key] = strings.Join(values, "\t")
+		if prev, found := previous[key]; !found || prev != rows[key] {
+			changed = append(changed, idx+1)
+		}
+	}
+	return changed, rows
+}
+
+// rowKey identifies a row by its name, qualified by its Application when the table has one
+func rowKey(headers, row []string) string {
+	key := cell(row, 0)
+	if idx := columnIndex(headers, "APPLICATION"); idx > 0 {
+		key = cell(row, idx) + "/" + key
+	}
+	return key
+}
+
+func columnIndex(headers []string, name string) int {
+	for idx, header := range headers {
+		if header == name {
+			return idx
+		}
+	}
+	return -1
+}
+
+func cell(row []string, idx int) string {
+	if idx < len(row) {
+		return row[idx]
+	}
+	return ""
+}
+
+// compactTable removes the empty rows left by filtered resources
+func compactTable(table [][]string) [][]string {
+	compacted := [][]string{}
+	for _, row := range table {
+		if len(row) > 0 {
+			compacted = append(compacted, row)
+		}
+	}
+	return compacted
+}
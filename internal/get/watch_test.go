@@ -0,0 +1,49 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package get
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffRows(t *testing.T) {
+	headers := []string{"AGENT", "STATUS", "AGE", "UPTIME"}
+	_, rows := diffRows(nil, [][]string{
+		headers,
+		{"agent-1", "RUNNING", "1m", "1m"},
+		{"agent-2", "RUNNING", "1m", "1m"},
+	})
+
+	// Only the status transition and the new Agent are reported, not the ages and uptimes
+	changed, _ := diffRows(rows, [][]string{
+		headers,
+		{"agent-1", "RUNNING", "2m", "2m"},
+		{"agent-2", "UNKNOWN", "2m", "2m"},
+		{"agent-3", "RUNNING", "1s", "1s"},
+	})
+	if expected := []int{2, 3}; !reflect.DeepEqual(changed, expected) {
+		t.Errorf("Expected changed rows %v, got %v", expected, changed)
+	}
+}
+
+func TestRowKey(t *testing.T) {
+	if key := rowKey([]string{"MICROSERVICE", "STATUS"}, []string{"msvc", "RUNNING"}); key != "msvc" {
+		t.Errorf("Expected key msvc, got %s", key)
+	}
+	headers := []string{"MICROSERVICE", "STATUS", "APPLICATION"}
+	if key := rowKey(headers, []string{"msvc", "RUNNING", "app"}); key != "app/msvc" {
+		t.Errorf("Expected key app/msvc, got %s", key)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package kustomize
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/datasance/potctl/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// Filename of kustomizations, input directories containing one are built instead of read
+const Filename = "potctl.kustomization.yaml"
+
+// Kustomization composes base documents with overlays
+type Kustomization struct {
+	// Files of documents, directories containing a kustomization, or kustomization files, relative to the
+	// kustomization
+	Resources []string `yaml:"resources"`
+	// Partial documents merged into the documents of the resources with the same kind and metadata.name
+	Patches []Patch `yaml:"patches,omitempty"`
+	// Prepended to the name of every document
+	NamePrefix string `yaml:"namePrefix,omitempty"`
+	// Appended to the tags of every document
+	CommonTags []string `yaml:"commonTags,omitempty"`
+}
+
+// Patch is either a file of partial documents or inline partial documents
+type Patch struct {
+	Path  string `yaml:"path,omitempty"`
+	Patch string `yaml:"patch,omitempty"`
+}
+
+// Reader returns the content of a file
+type Reader func(path string) ([]byte, error)
+
+// Find returns the kustomization file of an input path, found is false for plain input files
+func Find(path string) (file string, found bool) {
+	if filepath.Base(path) == Filename {
+		return path, true
+	}
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return "", false
+	}
+	file = filepath.Join(path, Filename)
+	if _, err := os.Stat(file); err != nil {
+		return "", false
+	}
+	return file, true
+}
+
+// Build returns the documents of a kustomization as a multi-document YAML file
+func Build(file string, read Reader) ([]byte, error) {
+	docs, err := build(file, read, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	for idx := range docs {
+		doc, err := yaml.Marshal(docs[idx])
+		if err != nil {
+			return nil, err
+		}
+		if idx > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(doc)
+	}
+	return out.Bytes(), nil
+}
+
+func build(file string, read Reader, visiting map[string]bool) ([]map[interface{}]interface{}, error) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+	if visiting[abs] {
+		return nil, util.NewInputError(fmt.Sprintf("Kustomization %s includes itself", file))
+	}
+	visiting[abs] = true
+	defer delete(visiting, abs)
+
+	content, err := read(file)
+	if err != nil {
+		return nil, err
+	}
+	kustomization := Kustomization{}
+	if err := yaml.UnmarshalStrict(content, &kustomization); err != nil {
+		return nil, util.NewInputError(fmt.Sprintf("Failed to decode kustomization %s: %s", file, err.Error()))
+	}
+	dir := filepath.Dir(file)
+
+	// Resources
+	docs := []map[interface{}]interface{}{}
+	for _, resource := range kustomization.Resources {
+		path := filepath.Join(dir, resource)
+		var resourceDocs []map[interface{}]interface{}
+		if baseFile, found := Find(path); found {
+			resourceDocs, err = build(baseFile, read, visiting)
+		} else {
+			resourceDocs, err = readDocuments(path, read)
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, resourceDocs...)
+	}
+
+	// Patches
+	for _, patch := range kustomization.Patches {
+		var patchDocs []map[interface{}]interface{}
+		switch {
+		case patch.Path != "" && patch.Patch == "":
+			patchDocs, err = readDocuments(filepath.Join(dir, patch.Path), read)
+		case patch.Path == "" && patch.Patch != "":
+			patchDocs, err = decodeDocuments(file, []byte(patch.Patch))
+		default:
+			err = util.NewInputError(fmt.Sprintf("Patches of kustomization %s must set exactly one of path and patch", file))
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, patchDoc := range patchDocs {
+			if err := applyPatch(docs, patchDoc); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Names and tags
+	if kustomization.NamePrefix != "" {
+		prefixNames(docs, kustomization.NamePrefix)
+	}
+	if len(kustomization.CommonTags) > 0 {
+		for _, doc := range docs {
+			addTags(doc, kustomization.CommonTags)
+		}
+	}
+	return docs, nil
+}
+
+func readDocuments(path string, read Reader) ([]map[interface{}]interface{}, error) {
+	content, err := read(path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDocuments(path, content)
+}
+
+func decodeDocuments(path string, content []byte) (docs []map[interface{}]interface{}, err error) {
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var doc map[interface{}]interface{}
+		if err = dec.Decode(&doc); err != nil {
+			break
+		}
+		if doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+	if err != io.EOF {
+		return nil, util.NewInputError(fmt.Sprintf("Failed to decode %s: %s", path, err.Error()))
+	}
+	return docs, nil
+}
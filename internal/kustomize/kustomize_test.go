@@ -0,0 +1,239 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package kustomize
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"base/" + Filename: `resources:
+- app.yaml
+`,
+		"base/app.yaml": `apiVersion: datasance.com/v3
+kind: Application
+metadata:
+  name: sensors
+spec:
+  microservices:
+  - name: reader
+    images:
+      x86: registry.dev/reader:latest
+    container:
+      env:
+      - key: LEVEL
+        value: debug
+      - key: TRACE
+        value: "true"
+  - name: writer
+    images:
+      x86: registry.dev/writer:latest
+`,
+		"prod/" + Filename: `resources:
+- ../base
+namePrefix: prod-
+commonTags:
+- env=prod
+patches:
+- path: registry.yaml
+`,
+		"prod/registry.yaml": `kind: Application
+metadata:
+  name: sensors
+spec:
+  microservices:
+  - name: reader
+    images:
+      x86: registry.prod/reader:1.0
+    container:
+      env:
+      - key: LEVEL
+        value: info
+      - key: TRACE
+        $patch: delete
+`,
+	})
+
+	out, err := Build(filepath.Join(dir, "prod", Filename), os.ReadFile)
+	if err != nil {
+		t.Fatalf("Failed to build: %s", err.Error())
+	}
+	docs, err := decodeDocuments("out", out)
+	if err != nil || len(docs) != 1 {
+		t.Fatalf("Unexpected documents %v", docs)
+	}
+	doc := docs[0]
+	metadata := doc["metadata"].(map[interface{}]interface{})
+	if metadata["name"] != "prod-sensors" {
+		t.Errorf("Unexpected name %v", metadata["name"])
+	}
+	if tags := metadata["tags"].([]interface{}); len(tags) != 1 || tags[0] != "env=prod" {
+		t.Errorf("Unexpected tags %v", tags)
+	}
+	msvcs := doc["spec"].(map[interface{}]interface{})["microservices"].([]interface{})
+	if len(msvcs) != 2 {
+		t.Fatalf("Unexpected Microservices %v", msvcs)
+	}
+	reader := msvcs[0].(map[interface{}]interface{})
+	if image := reader["images"].(map[interface{}]interface{})["x86"]; image != "registry.prod/reader:1.0" {
+		t.Errorf("Unexpected image %v", image)
+	}
+	env := reader["container"].(map[interface{}]interface{})["env"].([]interface{})
+	if len(env) != 1 || env[0].(map[interface{}]interface{})["value"] != "info" {
+		t.Errorf("Unexpected env %v", env)
+	}
+	if name := msvcs[1].(map[interface{}]interface{})["name"]; name != "writer" {
+		t.Errorf("Unexpected Microservice %v", name)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"loop/" + Filename: "resources:\n- .\n",
+		"patch/" + Filename: `resources:
+- app.yaml
+patches:
+- patch: |
+    kind: Application
+    metadata:
+      name: missing
+`,
+		"patch/app.yaml": "kind: Application\nmetadata:\n  name: sensors\n",
+	})
+	if _, err := Build(filepath.Join(dir, "loop", Filename), os.ReadFile); err == nil {
+		t.Errorf("Built kustomization including itself")
+	}
+	if _, err := Build(filepath.Join(dir, "patch", Filename), os.ReadFile); err == nil {
+		t.Errorf("Built kustomization patching a missing document")
+	}
+}
+
+func TestBuildNamePrefixReferences(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		Filename: `resources:
+- ecn.yaml
+namePrefix: prod-
+`,
+		"ecn.yaml": `kind: Agent
+metadata:
+  name: edge-1
+---
+kind: AgentConfig
+metadata:
+  name: edge-1
+---
+kind: Application
+metadata:
+  name: sensors
+spec:
+  microservices:
+  - name: reader
+    agent:
+      name: edge-1
+  - name: writer
+    agent:
+      name: shared-agent
+---
+kind: Secret
+metadata:
+  name: creds
+---
+kind: VolumeMount
+metadata:
+  name: creds-mount
+spec:
+  secretName: creds
+  configMapName: shared-config
+---
+kind: Role
+metadata:
+  name: reader
+---
+kind: RoleBinding
+metadata:
+  name: reader-binding
+spec:
+  roleRef:
+    kind: Role
+    name: reader
+`,
+	})
+
+	out, err := Build(filepath.Join(dir, Filename), os.ReadFile)
+	if err != nil {
+		t.Fatalf("Failed to build: %s", err.Error())
+	}
+	docs, err := decodeDocuments("out", out)
+	if err != nil || len(docs) != 7 {
+		t.Fatalf("Unexpected documents %v", docs)
+	}
+	byKind := make(map[string]map[interface{}]interface{})
+	for _, doc := range docs {
+		byKind[doc["kind"].(string)] = doc
+	}
+	spec := func(kind string) map[interface{}]interface{} {
+		return byKind[kind]["spec"].(map[interface{}]interface{})
+	}
+
+	for kind, name := range map[string]string{
+		"Agent":       "prod-edge-1",
+		"AgentConfig": "prod-edge-1",
+		"Application": "prod-sensors",
+		"Secret":      "prod-creds",
+		"VolumeMount": "prod-creds-mount",
+		"Role":        "prod-reader",
+		"RoleBinding": "prod-reader-binding",
+	} {
+		if got := getName(byKind[kind]); got != name {
+			t.Errorf("Expected %s to be named %s, got %s", kind, name, got)
+		}
+	}
+	msvcs := spec("Application")["microservices"].([]interface{})
+	agentName := func(idx int) interface{} {
+		return msvcs[idx].(map[interface{}]interface{})["agent"].(map[interface{}]interface{})["name"]
+	}
+	if name := agentName(0); name != "prod-edge-1" {
+		t.Errorf("Expected Microservice to refer to the prefixed Agent, got %v", name)
+	}
+	if name := agentName(1); name != "shared-agent" {
+		t.Errorf("Expected reference to an Agent outside of the kustomization to be kept, got %v", name)
+	}
+	if name := spec("VolumeMount")["secretName"]; name != "prod-creds" {
+		t.Errorf("Expected VolumeMount to refer to the prefixed Secret, got %v", name)
+	}
+	if name := spec("VolumeMount")["configMapName"]; name != "shared-config" {
+		t.Errorf("Expected reference to a ConfigMap outside of the kustomization to be kept, got %v", name)
+	}
+	if name := spec("RoleBinding")["roleRef"].(map[interface{}]interface{})["name"]; name != "prod-reader" {
+		t.Errorf("Expected RoleBinding to refer to the prefixed Role, got %v", name)
+	}
+}
@@ -0,0 +1,287 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package kustomize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// Keys identifying the items of lists merged item by item instead of replaced, e.g. Microservices and env vars
+var mergeKeys = []string{"name", "key"}
+
+// Directive of list items removing the matching item
+const deleteDirective = "$patch"
+
+// applyPatch merges a partial document into the document with the same kind and metadata.name
+func applyPatch(docs []map[interface{}]interface{}, patch map[interface{}]interface{}) error {
+	kind := getKind(patch)
+	name := getName(patch)
+	if kind == "" || name == "" {
+		return util.NewInputError("Patches must set kind and metadata.name")
+	}
+	for _, doc := range docs {
+		if getKind(doc) == kind && getName(doc) == name {
+			merged := merge(doc, patch).(map[interface{}]interface{})
+			for key := range doc {
+				delete(doc, key)
+			}
+			for key, value := range merged {
+				doc[key] = value
+			}
+			return nil
+		}
+	}
+	return util.NewNotFoundError(fmt.Sprintf("No %s named %s to patch", kind, name))
+}
+
+// merge returns the base value with the patch merged in, maps are merged recursively, lists of items identified
+// by a merge key are merged item by item and all other values are replaced
+func merge(base, patch interface{}) interface{} {
+	switch typedPatch := patch.(type) {
+	case map[interface{}]interface{}:
+		typedBase, ok := base.(map[interface{}]interface{})
+		if !ok {
+			return patch
+		}
+		merged := make(map[interface{}]interface{}, len(typedBase))
+		for key, value := range typedBase {
+			merged[key] = value
+		}
+		for key, value := range typedPatch {
+			if value == nil {
+				// Null removes the key
+				delete(merged, key)
+				continue
+			}
+			merged[key] = merge(typedBase[key], value)
+		}
+		return merged
+	case []interface{}:
+		typedBase, ok := base.([]interface{})
+		if !ok {
+			return patch
+		}
+		if key := listMergeKey(typedBase, typedPatch); key != "" {
+			return mergeList(typedBase, typedPatch, key)
+		}
+	}
+	return patch
+}
+
+// listMergeKey returns the merge key shared by all the items of both lists, if any
+func listMergeKey(base, patch []interface{}) string {
+	for _, key := range mergeKeys {
+		shared := len(patch) > 0
+		for _, item := range append(append([]interface{}{}, base...), patch...) {
+			itemMap, ok := item.(map[interface{}]interface{})
+			if !ok || itemMap[key] == nil {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			return key
+		}
+	}
+	return ""
+}
+
+func mergeList(base, patch []interface{}, key string) []interface{} {
+	merged := append([]interface{}{}, base...)
+	for _, item := range patch {
+		patchItem := item.(map[interface{}]interface{})
+		remove := patchItem[deleteDirective] == "delete"
+		found := false
+		for idx := range merged {
+			if merged[idx].(map[interface{}]interface{})[key] == patchItem[key] {
+				found = true
+				if remove {
+					merged = append(merged[:idx], merged[idx+1:]...)
+				} else {
+					merged[idx] = merge(merged[idx], patchItem)
+				}
+				break
+			}
+		}
+		if !found && !remove {
+			merged = append(merged, patchItem)
+		}
+	}
+	return merged
+}
+
+func getMetadata(doc map[interface{}]interface{}) map[interface{}]interface{} {
+	metadata, ok := doc["metadata"].(map[interface{}]interface{})
+	if !ok {
+		metadata = make(map[interface{}]interface{})
+		doc["metadata"] = metadata
+	}
+	return metadata
+}
+
+func getKind(doc map[interface{}]interface{}) string {
+	kind, _ := doc["kind"].(string)
+	return kind
+}
+
+func getName(doc map[interface{}]interface{}) string {
+	metadata, _ := doc["metadata"].(map[interface{}]interface{})
+	name, _ := metadata["name"].(string)
+	return name
+}
+
+// Path element walking the items of a list
+const listItems = "[]"
+
+// reference is a field of a document holding the name of another document, or a list of names
+type reference struct {
+	// Dot separated keys from the root of the document
+	path string
+	// Kinds of the referenced documents
+	kinds []string
+}
+
+var (
+	agentKinds       = []string{"Agent", "LocalAgent"}
+	applicationKinds = []string{"Application"}
+)
+
+// References rewritten along with the names of the referenced documents, by kind of the referencing document
+var references = map[string][]reference{
+	"Application": {
+		{path: "spec.microservices.[].agent.name", kinds: agentKinds},
+	},
+	"Microservice": {
+		{path: "spec.agent.name", kinds: agentKinds},
+		{path: "spec.application", kinds: applicationKinds},
+	},
+	"AgentConfig": {
+		{path: "metadata.name", kinds: agentKinds},
+	},
+	"Volume": {
+		{path: "spec.agents", kinds: agentKinds},
+	},
+	"OfflineImage": {
+		{path: "spec.agent", kinds: agentKinds},
+	},
+	"VolumeMount": {
+		{path: "spec.secretName", kinds: []string{"Secret"}},
+		{path: "spec.configMapName", kinds: []string{"ConfigMap"}},
+	},
+	"RoleBinding": {
+		{path: "spec.roleRef.name", kinds: []string{"Role"}},
+	},
+	"ServiceAccount": {
+		{path: "spec.roleRef.name", kinds: []string{"Role"}},
+	},
+}
+
+// Reference of the resources scoped to an Application, whatever their kind
+var applicationReference = reference{path: "metadata.applicationName", kinds: applicationKinds}
+
+// prefixNames prepends the prefix to the names of the documents and to the references to them. References to
+// resources that are not part of the documents are kept.
+func prefixNames(docs []map[interface{}]interface{}, prefix string) {
+	renamed := make(map[string]map[string]bool)
+	for _, doc := range docs {
+		name := getName(doc)
+		if name == "" || !hasOwnName(doc) {
+			continue
+		}
+		kind := getKind(doc)
+		if renamed[kind] == nil {
+			renamed[kind] = make(map[string]bool)
+		}
+		renamed[kind][name] = true
+		getMetadata(doc)["name"] = prefix + name
+	}
+
+	for _, doc := range docs {
+		for _, ref := range append([]reference{applicationReference}, references[getKind(doc)]...) {
+			rename := func(name string) string {
+				for _, refKind := range ref.kinds {
+					if renamed[refKind][name] {
+						return prefix + name
+					}
+				}
+				return name
+			}
+			walkReference(doc, strings.Split(ref.path, "."), rename)
+		}
+	}
+}
+
+// hasOwnName reports whether the name of a document is its own. Resources scoped to an Application are named
+// within their Application and Agent configurations are named after their Agent.
+func hasOwnName(doc map[interface{}]interface{}) bool {
+	metadata := getMetadata(doc)
+	spec, _ := doc["spec"].(map[interface{}]interface{})
+	if appName, ok := metadata["applicationName"].(string); ok && appName != "" {
+		return false
+	}
+	if appName, ok := spec["application"].(string); ok && appName != "" && getKind(doc) == "Microservice" {
+		return false
+	}
+	return getKind(doc) != "AgentConfig"
+}
+
+// walkReference renames the names found at the path of the node
+func walkReference(node interface{}, path []string, rename func(string) string) interface{} {
+	if len(path) == 0 {
+		switch typedNode := node.(type) {
+		case string:
+			return rename(typedNode)
+		case []interface{}:
+			for idx := range typedNode {
+				if name, ok := typedNode[idx].(string); ok {
+					typedNode[idx] = rename(name)
+				}
+			}
+		}
+		return node
+	}
+	switch typedNode := node.(type) {
+	case map[interface{}]interface{}:
+		if child, found := typedNode[path[0]]; found {
+			typedNode[path[0]] = walkReference(child, path[1:], rename)
+		}
+	case []interface{}:
+		if path[0] == listItems {
+			for idx := range typedNode {
+				typedNode[idx] = walkReference(typedNode[idx], path[1:], rename)
+			}
+		}
+	}
+	return node
+}
+
+// addTags appends the tags missing from a document
+func addTags(doc map[interface{}]interface{}, tags []string) {
+	metadata := getMetadata(doc)
+	existing, _ := metadata["tags"].([]interface{})
+	found := make(map[interface{}]bool, len(existing))
+	for _, tag := range existing {
+		found[tag] = true
+	}
+	for _, tag := range tags {
+		if !found[tag] {
+			existing = append(existing, tag)
+			found[tag] = true
+		}
+	}
+	metadata["tags"] = existing
+}
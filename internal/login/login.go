@@ -0,0 +1,195 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package login
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/config/secretstore"
+	"github.com/datasance/potctl/internal/execute"
+	rsc "github.com/datasance/potctl/internal/resource"
+	"github.com/datasance/potctl/pkg/util"
+	"github.com/pkg/browser"
+	"golang.org/x/oauth2"
+)
+
+// ClientSecretEnv is the environment variable holding the client secret of the client-credentials mode
+const ClientSecretEnv = "POTCTL_CLIENT_SECRET"
+
+type Options struct {
+	Namespace    string
+	Mode         string
+	ClientID     string // Defaults to the Controller client of the realm
+	ClientSecret string
+	NoBrowser    bool
+}
+
+type executor struct {
+	opt Options
+}
+
+func NewExecutor(opt Options) (execute.Executor, error) {
+	switch opt.Mode {
+	case ModeDevice, ModeBrowser, ModeClientCredentials:
+	default:
+		return nil, util.NewInputError(fmt.Sprintf("Unsupported login mode %s, supported modes are %s", opt.Mode, strings.Join(Modes(), ", ")))
+	}
+	if opt.ClientSecret == "" {
+		opt.ClientSecret = os.Getenv(ClientSecretEnv)
+	}
+	return &executor{opt: opt}, nil
+}
+
+func (exe *executor) GetName() string {
+	return exe.opt.Namespace
+}
+
+func (exe *executor) Execute() error {
+	ns, err := config.GetNamespace(exe.opt.Namespace)
+	if err != nil {
+		return err
+	}
+	controlPlane, err := ns.GetControlPlane()
+	if err != nil {
+		return err
+	}
+	auth, err := GetAuth(controlPlane)
+	if err != nil {
+		return err
+	}
+	endpoint, err := controlPlane.GetEndpoint()
+	if err != nil {
+		return err
+	}
+	baseURL, err := util.GetBaseURL(endpoint)
+	if err != nil {
+		return err
+	}
+
+	// Default to the Controller client, whose secret is known
+	clientID, clientSecret := exe.opt.ClientID, exe.opt.ClientSecret
+	if clientID == "" || clientID == auth.ControllerClient {
+		clientID = auth.ControllerClient
+		if clientSecret == "" {
+			clientSecret = auth.ControllerSecret
+		}
+	}
+	if exe.opt.Mode == ModeClientCredentials && clientSecret == "" {
+		return util.NewInputError(fmt.Sprintf("The client-credentials mode requires the secret of client %s, set --client-secret or %s", clientID, ClientSecretEnv))
+	}
+
+	provider := Provider{URL: auth.URL, Realm: auth.Realm}
+	ctx := context.Background()
+	var token *oauth2.Token
+	switch exe.opt.Mode {
+	case ModeDevice:
+		token, err = provider.DeviceLogin(ctx, clientID, clientSecret, func(uri, code string) {
+			util.PrintInfo(fmt.Sprintf("Open %s and enter the code %s", uri, code))
+		})
+	case ModeBrowser:
+		token, err = provider.BrowserLogin(ctx, clientID, clientSecret, func(url string) {
+			util.PrintInfo(fmt.Sprintf("Log in with your browser at %s", url))
+			if !exe.opt.NoBrowser {
+				if err := browser.OpenURL(url); err != nil {
+					util.PrintNotify(fmt.Sprintf("Could not open the browser: %s", err.Error()))
+				}
+			}
+		})
+	case ModeClientCredentials:
+		token, err = provider.ClientCredentialsLogin(ctx, clientID, clientSecret)
+	}
+	if err != nil {
+		return err
+	}
+	if token.RefreshToken == "" {
+		return util.NewError(fmt.Sprintf("Client %s was not issued a refresh token, enable refresh tokens for it in realm %s", clientID, auth.Realm))
+	}
+
+	// Start a session with the Controller
+	clt, err := client.SessionLogin(client.Options{BaseURL: baseURL}, token.RefreshToken, "", "")
+	if err != nil {
+		return util.NewError(fmt.Sprintf("The Controller rejected the login: %s", err.Error()))
+	}
+
+	// Only keep the refresh token, and the client to log in again in client-credentials mode. Its secret is only
+	// kept in a secret store, RenewToken reads it from the environment otherwise.
+	user := controlPlane.GetUser()
+	user.Password = ""
+	user.Login = exe.opt.Mode
+	user.ClientID = ""
+	user.ClientSecret = ""
+	if exe.opt.Mode == ModeClientCredentials && clientID != auth.ControllerClient {
+		user.ClientID = clientID
+		if config.GetSecretStore() != secretstore.BackendNone {
+			user.ClientSecret = clientSecret
+		} else {
+			util.PrintNotify(fmt.Sprintf("The secret of client %s is not kept without a secret store, set %s to renew the session", clientID, ClientSecretEnv))
+		}
+	}
+	user.AccessToken = ""
+	user.RefreshToken = clt.GetRefreshToken()
+	if err := config.SetUser(exe.opt.Namespace, user); err != nil {
+		return err
+	}
+	return config.Flush()
+}
+
+// GetAuth returns the identity provider settings of a Control Plane
+func GetAuth(controlPlane rsc.ControlPlane) (auth rsc.Auth, err error) {
+	switch cp := controlPlane.(type) {
+	case *rsc.KubernetesControlPlane:
+		auth = cp.Auth
+	case *rsc.RemoteControlPlane:
+		auth = cp.Auth
+	case *rsc.LocalControlPlane:
+		auth = cp.Auth
+	}
+	if auth.URL == "" || auth.Realm == "" {
+		return auth, util.NewInputError("The Control Plane has no identity provider URL and realm")
+	}
+	return auth, nil
+}
+
+// RenewToken logs in again the client of a user in client-credentials mode, and returns its new refresh token.
+// The secret of the client is read from ClientSecretEnv, or from the secret store.
+func RenewToken(controlPlane rsc.ControlPlane) (string, error) {
+	user := controlPlane.GetUser()
+	if user.Login != ModeClientCredentials {
+		return "", util.NewInputError(fmt.Sprintf("Cannot renew the session of login mode %s", user.Login))
+	}
+	auth, err := GetAuth(controlPlane)
+	if err != nil {
+		return "", err
+	}
+	clientID, clientSecret := user.ClientID, os.Getenv(ClientSecretEnv)
+	switch {
+	case clientID == "":
+		clientID, clientSecret = auth.ControllerClient, auth.ControllerSecret
+	case clientSecret == "":
+		clientSecret = user.ClientSecret
+	}
+	if clientSecret == "" {
+		return "", util.NewInputError(fmt.Sprintf("The session of client %s has expired, set %s to log in again", clientID, ClientSecretEnv))
+	}
+	token, err := Provider{URL: auth.URL, Realm: auth.Realm}.ClientCredentialsLogin(context.Background(), clientID, clientSecret)
+	if err != nil {
+		return "", err
+	}
+	return token.RefreshToken, nil
+}
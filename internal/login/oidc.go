@@ -0,0 +1,189 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package login
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/datasance/potctl/pkg/util"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// Login modes
+const (
+	// The user logs in with the password stored in the namespace
+	ModePassword = ""
+	// The user enters a code on another device, e.g. when potctl runs over SSH
+	ModeDevice = "device"
+	// The user logs in with a browser redirected to potctl
+	ModeBrowser = "browser"
+	// A client logs in with its secret, e.g. in CI
+	ModeClientCredentials = "client-credentials"
+)
+
+// Modes returns the modes of potctl login
+func Modes() []string {
+	return []string{ModeDevice, ModeBrowser, ModeClientCredentials}
+}
+
+// loginTimeout bounds the time left to the user to log in
+const loginTimeout = 5 * time.Minute
+
+var scopes = []string{"openid", "profile", "email"}
+
+// Provider is the OpenID Connect provider of a Keycloak realm
+type Provider struct {
+	URL   string
+	Realm string
+}
+
+func (provider Provider) endpoint() oauth2.Endpoint {
+	base := fmt.Sprintf("%s/realms/%s/protocol/openid-connect", provider.URL, provider.Realm)
+	return oauth2.Endpoint{
+		AuthURL:       base + "/auth",
+		DeviceAuthURL: base + "/auth/device",
+		TokenURL:      base + "/token",
+	}
+}
+
+func (provider Provider) config(clientID, clientSecret string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Endpoint:     provider.endpoint(),
+		Scopes:       scopes,
+	}
+}
+
+// DeviceLogin runs the OAuth2 device authorization flow, prompt shows the user where to enter the code
+func (provider Provider) DeviceLogin(ctx context.Context, clientID, clientSecret string, prompt func(uri, code string)) (*oauth2.Token, error) {
+	ctx, cancel := context.WithTimeout(ctx, loginTimeout)
+	defer cancel()
+	conf := provider.config(clientID, clientSecret)
+	auth, err := conf.DeviceAuth(ctx)
+	if err != nil {
+		return nil, util.NewError(fmt.Sprintf("Failed to start device login: %s", err.Error()))
+	}
+	uri := auth.VerificationURIComplete
+	if uri == "" {
+		uri = auth.VerificationURI
+	}
+	prompt(uri, auth.UserCode)
+	token, err := conf.DeviceAccessToken(ctx, auth)
+	if err != nil {
+		return nil, util.NewError(fmt.Sprintf("Failed to complete device login: %s", err.Error()))
+	}
+	return token, nil
+}
+
+// BrowserLogin runs the OAuth2 authorization code flow with PKCE, redirecting the browser to a local listener.
+// open is called with the URL the user must visit.
+func (provider Provider) BrowserLogin(ctx context.Context, clientID, clientSecret string, open func(url string)) (*oauth2.Token, error) {
+	ctx, cancel := context.WithTimeout(ctx, loginTimeout)
+	defer cancel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	defer listener.Close()
+
+	conf := provider.config(clientID, clientSecret)
+	conf.RedirectURL = fmt.Sprintf("http://%s/callback", listener.Addr().String())
+	verifier := oauth2.GenerateVerifier()
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+	server := &http.Server{
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/callback" {
+				http.NotFound(w, r)
+				return
+			}
+			query := r.URL.Query()
+			res := result{code: query.Get("code")}
+			switch {
+			case query.Get("state") != state:
+				res.err = util.NewError("Browser login returned an unexpected state")
+			case query.Get("error") != "":
+				res.err = util.NewError(fmt.Sprintf("Browser login failed: %s %s", query.Get("error"), query.Get("error_description")))
+			case res.code == "":
+				res.err = util.NewError("Browser login did not return a code")
+			}
+			if res.err != nil {
+				http.Error(w, res.err.Error(), http.StatusBadRequest)
+			} else {
+				fmt.Fprintln(w, "Logged in to potctl, you can close this window.")
+			}
+			select {
+			case results <- res:
+			default:
+			}
+		}),
+	}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	open(conf.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)))
+	select {
+	case <-ctx.Done():
+		return nil, util.NewError("Timed out waiting for the browser login")
+	case res := <-results:
+		if res.err != nil {
+			return nil, res.err
+		}
+		token, err := conf.Exchange(ctx, res.code, oauth2.VerifierOption(verifier))
+		if err != nil {
+			return nil, util.NewError(fmt.Sprintf("Failed to complete browser login: %s", err.Error()))
+		}
+		return token, nil
+	}
+}
+
+// ClientCredentialsLogin gets a token for a confidential client
+func (provider Provider) ClientCredentialsLogin(ctx context.Context, clientID, clientSecret string) (*oauth2.Token, error) {
+	conf := &clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     provider.endpoint().TokenURL,
+		Scopes:       scopes,
+	}
+	token, err := conf.Token(ctx)
+	if err != nil {
+		return nil, util.NewError(fmt.Sprintf("Failed to log in client %s: %s", clientID, err.Error()))
+	}
+	return token, nil
+}
+
+func randomState() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
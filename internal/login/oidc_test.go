@@ -0,0 +1,123 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package login
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func newProvider(t *testing.T) Provider {
+	mux := http.NewServeMux()
+	base := "/realms/pot/protocol/openid-connect"
+	mux.HandleFunc(base+"/auth/device", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"device_code":      "device-code",
+			"user_code":        "ABCD-EFGH",
+			"verification_uri": "https://auth.example.com/device",
+			"interval":         1,
+			"expires_in":       60,
+		})
+	})
+	mux.HandleFunc(base+"/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		valid := false
+		switch r.Form.Get("grant_type") {
+		case "urn:ietf:params:oauth:grant-type:device_code":
+			valid = r.Form.Get("device_code") == "device-code"
+		case "authorization_code":
+			valid = r.Form.Get("code") == "auth-code" && r.Form.Get("code_verifier") != ""
+		case "client_credentials":
+			id, secret, _ := r.BasicAuth()
+			valid = id == "ci" && secret == "secret"
+		}
+		if !valid {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "access-" + r.Form.Get("grant_type"),
+			"refresh_token": "refresh",
+			"token_type":    "Bearer",
+			"expires_in":    300,
+		})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return Provider{URL: server.URL, Realm: "pot"}
+}
+
+func TestDeviceLogin(t *testing.T) {
+	provider := newProvider(t)
+	prompted := ""
+	token, err := provider.DeviceLogin(context.Background(), "potctl", "", func(uri, code string) { prompted = code })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompted != "ABCD-EFGH" || token.RefreshToken != "refresh" {
+		t.Errorf("Unexpected device login, code %s, token %v", prompted, token)
+	}
+}
+
+func TestBrowserLogin(t *testing.T) {
+	provider := newProvider(t)
+	open := func(authURL string) {
+		parsed, err := url.Parse(authURL)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		query := parsed.Query()
+		if query.Get("code_challenge_method") != "S256" {
+			t.Errorf("Missing PKCE challenge in %s", authURL)
+		}
+		// Act as the browser redirected by the provider
+		go func() {
+			resp, err := http.Get(query.Get("redirect_uri") + "?code=auth-code&state=" + url.QueryEscape(query.Get("state")))
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+	}
+	token, err := provider.BrowserLogin(context.Background(), "potctl", "", open)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "access-authorization_code" {
+		t.Errorf("Unexpected token %v", token)
+	}
+}
+
+func TestClientCredentialsLogin(t *testing.T) {
+	provider := newProvider(t)
+	if _, err := provider.ClientCredentialsLogin(context.Background(), "ci", "wrong"); err == nil {
+		t.Error("Logged in with the wrong secret")
+	}
+	token, err := provider.ClientCredentialsLogin(context.Background(), "ci", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "access-client_credentials" {
+		t.Errorf("Unexpected token %v", token)
+	}
+}
@@ -30,13 +30,15 @@ type agentExecutor struct {
 	namespace string
 	name      string
 	logConfig *LogTailConfig
+	lines     *lineWriter
 }
 
-func newAgentExecutor(namespace, name string, logConfig *LogTailConfig) *agentExecutor {
+func newAgentExecutor(namespace, name string, logConfig *LogTailConfig, lines *lineWriter) *agentExecutor {
 	exe := &agentExecutor{}
 	exe.namespace = namespace
 	exe.name = name
 	exe.logConfig = logConfig
+	exe.lines = lines
 	return exe
 }
 
@@ -72,7 +74,7 @@ func (exe *agentExecutor) Execute() error {
 			return err
 		}
 
-		printContainerLogs(stdout, stderr)
+		exe.lines.writeContainerLogs(stdout, stderr)
 
 		return nil
 	case *rsc.RemoteAgent:
@@ -123,6 +125,7 @@ func (exe *agentExecutor) Execute() error {
 
 		// Create and start log stream
 		logStream := NewLogStream(wsClient)
+		logStream.lines = exe.lines
 
 		// Check for initial connection error
 		if err := wsClient.GetError(); err != nil {
@@ -20,37 +20,47 @@ import (
 	"github.com/datasance/potctl/pkg/util"
 )
 
-func NewExecutor(resourceType, namespace, name string, logConfig *LogTailConfig) (execute.Executor, error) {
+func NewExecutor(resourceType, namespace, name string, logConfig *LogTailConfig, outConfig *OutputConfig) (execute.Executor, error) {
+	exe, _, err := newExecutor(resourceType, namespace, name, logConfig, outConfig)
+	return exe, err
+}
+
+// newExecutor returns the executor along with the writer of its log lines
+func newExecutor(resourceType, namespace, name string, logConfig *LogTailConfig, outConfig *OutputConfig) (execute.Executor, *lineWriter, error) {
 	ns, err := config.GetNamespace(namespace)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// Use default config if nil
 	if logConfig == nil {
 		logConfig = DefaultLogTailConfig()
 	}
+	lines, err := newLineWriter(resourceType, name, outConfig)
+	if err != nil {
+		return nil, nil, err
+	}
 	switch resourceType {
 	case "controller":
 		baseControlPlane, err := ns.GetControlPlane()
 		if err != nil {
-			return nil, util.NewError("Could not get Control Plane for namespace " + namespace)
+			return nil, nil, util.NewError("Could not get Control Plane for namespace " + namespace)
 		}
 		switch controlPlane := baseControlPlane.(type) {
 		case *rsc.KubernetesControlPlane:
-			return newKubernetesControllerExecutor(controlPlane, namespace, name), nil
+			return newKubernetesControllerExecutor(controlPlane, namespace, name, lines), lines, nil
 		case *rsc.RemoteControlPlane:
-			return newRemoteControllerExecutor(controlPlane, namespace, name), nil
+			return newRemoteControllerExecutor(controlPlane, namespace, name, lines), lines, nil
 		case *rsc.LocalControlPlane:
-			return newLocalControllerExecutor(controlPlane, namespace, name), nil
+			return newLocalControllerExecutor(controlPlane, namespace, name, lines), lines, nil
 		}
 	case "agent":
-		return newAgentExecutor(namespace, name, logConfig), nil
+		return newAgentExecutor(namespace, name, logConfig, lines), lines, nil
 	case "microservice":
 		if len(ns.GetControllers()) == 0 {
-			return nil, util.NewError("No Controllers found in namespace " + namespace)
+			return nil, nil, util.NewError("No Controllers found in namespace " + namespace)
 		}
-		return newRemoteMicroserviceExecutor(namespace, name, logConfig), nil
+		return newRemoteMicroserviceExecutor(namespace, name, logConfig, lines), lines, nil
 	}
 	msg := "Unknown resource: '" + resourceType + "'"
-	return nil, util.NewInputError(msg)
+	return nil, nil, util.NewInputError(msg)
 }
@@ -14,8 +14,6 @@
 package logs
 
 import (
-	"fmt"
-
 	rsc "github.com/datasance/potctl/internal/resource"
 	"github.com/datasance/potctl/pkg/util"
 )
@@ -24,13 +22,15 @@ type kubernetesControllerExecutor struct {
 	controlPlane *rsc.KubernetesControlPlane
 	namespace    string
 	name         string
+	lines        *lineWriter
 }
 
-func newKubernetesControllerExecutor(controlPlane *rsc.KubernetesControlPlane, namespace, name string) *kubernetesControllerExecutor {
+func newKubernetesControllerExecutor(controlPlane *rsc.KubernetesControlPlane, namespace, name string, lines *lineWriter) *kubernetesControllerExecutor {
 	return &kubernetesControllerExecutor{
 		controlPlane: controlPlane,
 		namespace:    namespace,
 		name:         name,
+		lines:        lines,
 	}
 }
 
@@ -46,7 +46,7 @@ func (exe *kubernetesControllerExecutor) Execute() error {
 	if err != nil {
 		return err
 	}
-	fmt.Print(out.String())
+	exe.lines.writeLines(out.String(), "stdout")
 
 	return nil
 }
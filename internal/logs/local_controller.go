@@ -22,13 +22,15 @@ type localControllerExecutor struct {
 	controlPlane *rsc.LocalControlPlane
 	namespace    string
 	name         string
+	lines        *lineWriter
 }
 
-func newLocalControllerExecutor(controlPlane *rsc.LocalControlPlane, namespace, name string) *localControllerExecutor {
+func newLocalControllerExecutor(controlPlane *rsc.LocalControlPlane, namespace, name string, lines *lineWriter) *localControllerExecutor {
 	return &localControllerExecutor{
 		controlPlane: controlPlane,
 		namespace:    namespace,
 		name:         name,
+		lines:        lines,
 	}
 }
 
@@ -47,7 +49,7 @@ func (exe *localControllerExecutor) Execute() error {
 		return err
 	}
 
-	printContainerLogs(stdout, stderr)
+	exe.lines.writeContainerLogs(stdout, stderr)
 
 	return nil
 }
@@ -31,13 +31,15 @@ type remoteMicroserviceExecutor struct {
 	namespace string
 	name      string
 	logConfig *LogTailConfig
+	lines     *lineWriter
 }
 
-func newRemoteMicroserviceExecutor(namespace, name string, logConfig *LogTailConfig) *remoteMicroserviceExecutor {
+func newRemoteMicroserviceExecutor(namespace, name string, logConfig *LogTailConfig, lines *lineWriter) *remoteMicroserviceExecutor {
 	m := &remoteMicroserviceExecutor{}
 	m.namespace = namespace
 	m.name = name
 	m.logConfig = logConfig
+	m.lines = lines
 	return m
 }
 
@@ -68,7 +70,7 @@ func (ms *remoteMicroserviceExecutor) Execute() error {
 			return err
 		}
 
-		printContainerLogs(stdout, stderr)
+		ms.lines.writeContainerLogs(stdout, stderr)
 
 		return nil
 	case *rsc.RemoteAgent:
@@ -116,6 +118,7 @@ func (ms *remoteMicroserviceExecutor) Execute() error {
 
 		// Create and start log stream
 		logStream := NewLogStream(wsClient)
+		logStream.lines = ms.lines
 
 		// Check for initial connection error
 		if err := wsClient.GetError(); err != nil {
@@ -0,0 +1,97 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package logs
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/datasance/potctl/internal/execute"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// multiExecutor streams the logs of several Agents or Microservices at once, prefixing each line with the
+// name of its resource
+type multiExecutor struct {
+	resourceType string
+	exes         []execute.Executor
+}
+
+// NewMultiExecutor returns an executor streaming the logs of all the named resources at once.
+// Prefixes are colored when writing plain text to a terminal, unless NO_COLOR is set.
+func NewMultiExecutor(resourceType, namespace string, names []string, logConfig *LogTailConfig, outConfig *OutputConfig) (execute.Executor, error) {
+	if len(names) == 1 {
+		return NewExecutor(resourceType, namespace, names[0], logConfig, outConfig)
+	}
+	if outConfig == nil {
+		outConfig = &OutputConfig{}
+	}
+	color := outConfig.colorEnabled()
+	width := 0
+	for _, name := range names {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	multi := &multiExecutor{resourceType: resourceType}
+	for idx, name := range names {
+		exe, lines, err := newExecutor(resourceType, namespace, name, logConfig, outConfig)
+		if err != nil {
+			return nil, err
+		}
+		// Records carry the name of their resource in json
+		if outConfig.Output == "" {
+			lines.prefix = fmt.Sprintf("[%-*s] ", width, name)
+			if color {
+				lines.prefix = fmt.Sprintf("\x1b[%sm[%-*s]\x1b[0m ", prefixColors[idx%len(prefixColors)], width, name)
+			}
+		}
+		multi.exes = append(multi.exes, exe)
+	}
+	return multi, nil
+}
+
+func (multi *multiExecutor) GetName() string {
+	names := make([]string, len(multi.exes))
+	for idx, exe := range multi.exes {
+		names[idx] = exe.GetName()
+	}
+	return strings.Join(names, ", ")
+}
+
+// Execute streams all the logs at once, streams are not bounded by --parallelism since they may never end
+func (multi *multiExecutor) Execute() error {
+	errs := make([]error, len(multi.exes))
+	var wg sync.WaitGroup
+	for idx := range multi.exes {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			errs[idx] = multi.exes[idx].Execute()
+		}(idx)
+	}
+	wg.Wait()
+
+	msgs := []string{}
+	for idx, err := range errs {
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", multi.exes[idx].GetName(), err.Error()))
+		}
+	}
+	if len(msgs) > 0 {
+		return util.NewError(fmt.Sprintf("Failed to get the logs of %d %ss:\n%s", len(msgs), multi.resourceType, strings.Join(msgs, "\n")))
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/datasance/potctl/pkg/util"
+	"golang.org/x/term"
+)
+
+// OutputConfig holds the client side filtering and formatting of log lines
+type OutputConfig struct {
+	Grep       string // Regular expression lines must match, all lines are written if empty
+	Timestamps bool   // Whether to prepend the time each line was received
+	Output     string // Empty for plain text or json for one record per line
+}
+
+// Validate validates the OutputConfig
+func (c *OutputConfig) Validate() error {
+	if c.Output != "" && c.Output != "json" {
+		return util.NewInputError(fmt.Sprintf("Invalid output format %s, must be json or empty", c.Output))
+	}
+	if _, err := regexp.Compile(c.Grep); err != nil {
+		return util.NewInputError(fmt.Sprintf("Invalid grep expression: %v", err))
+	}
+	return nil
+}
+
+// Colors of the prefixes of the streams, cycled through when streaming more resources
+var prefixColors = []string{"36", "32", "33", "35", "34", "91", "96", "92", "93", "95"}
+
+// colorEnabled returns true when the prefixes of the streams should be colored
+func (c *OutputConfig) colorEnabled() bool {
+	return c.Output == "" && os.Getenv("NO_COLOR") == "" && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// lineRecord is written for each line with --output json
+type lineRecord struct {
+	Time     string `json:"time"`
+	Resource string `json:"resource"`
+	Name     string `json:"name"`
+	Stream   string `json:"stream,omitempty"`
+	Line     string `json:"line,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// lineWriter filters and formats the lines of a log stream before writing them
+type lineWriter struct {
+	resource   string
+	name       string
+	grep       *regexp.Regexp
+	timestamps bool
+	json       bool
+	// Prepended to each line when streaming several resources
+	prefix string
+	stdout io.Writer
+	stderr io.Writer
+	now    func() time.Time
+}
+
+func newLineWriter(resource, name string, config *OutputConfig) (*lineWriter, error) {
+	if config == nil {
+		config = &OutputConfig{}
+	}
+	w := &lineWriter{
+		resource:   resource,
+		name:       name,
+		timestamps: config.Timestamps,
+		json:       config.Output == "json",
+		stdout:     os.Stdout,
+		stderr:     os.Stderr,
+		now:        time.Now,
+	}
+	if config.Grep != "" {
+		grep, err := regexp.Compile(config.Grep)
+		if err != nil {
+			return nil, util.NewInputError(fmt.Sprintf("Invalid grep expression: %v", err))
+		}
+		w.grep = grep
+	}
+	return w, nil
+}
+
+// writeLines writes the lines of data matching --grep, stream is stdout or stderr
+func (w *lineWriter) writeLines(data, stream string) {
+	data = strings.TrimSuffix(data, "\n")
+	var buf strings.Builder
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if w.grep != nil && !w.grep.MatchString(line) {
+			continue
+		}
+		buf.WriteString(w.format(lineRecord{Stream: stream, Line: line}))
+	}
+	if buf.Len() == 0 {
+		return
+	}
+	out := w.stdout
+	if stream == "stderr" && !w.json {
+		out = w.stderr
+	}
+	w.write(out, buf.String())
+}
+
+// writeError writes an error reported by the log stream, errors are not filtered
+func (w *lineWriter) writeError(msg string) {
+	w.write(w.stdout, w.format(lineRecord{Error: msg}))
+}
+
+// writeContainerLogs writes the logs of a local container
+func (w *lineWriter) writeContainerLogs(stdout, stderr string) {
+	if stdout != "" {
+		w.writeLines(stdout, "stdout")
+	}
+	if stderr != "" {
+		w.writeLines(stderr, "stderr")
+	}
+}
+
+func (w *lineWriter) format(record lineRecord) string {
+	now := w.now().UTC()
+	if w.json {
+		record.Time = now.Format(time.RFC3339Nano)
+		record.Resource = w.resource
+		record.Name = w.name
+		bytes, err := json.Marshal(record)
+		if err != nil {
+			return ""
+		}
+		return string(bytes) + "\n"
+	}
+	line := record.Line
+	if record.Error != "" {
+		line = "Error: " + record.Error
+	}
+	if w.timestamps {
+		line = now.Format("2006-01-02T15:04:05.000Z07:00") + " " + line
+	}
+	return w.prefix + line + "\n"
+}
+
+func (w *lineWriter) write(out io.Writer, data string) {
+	stdoutMutex.Lock()
+	defer stdoutMutex.Unlock()
+	_, _ = io.WriteString(out, data)
+	if file, ok := out.(*os.File); ok {
+		_ = file.Sync()
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package logs
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func newTestLineWriter(t *testing.T, config *OutputConfig) (*lineWriter, *bytes.Buffer, *bytes.Buffer) {
+	w, err := newLineWriter("agent", "edge-1", config)
+	if err != nil {
+		t.Fatalf("Failed to create line writer: %v", err)
+	}
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	w.stdout = stdout
+	w.stderr = stderr
+	w.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC) }
+	return w, stdout, stderr
+}
+
+func TestLineWriterGrep(t *testing.T) {
+	w, stdout, stderr := newTestLineWriter(t, &OutputConfig{Grep: "ERROR|WARN"})
+	w.prefix = "[edge-1] "
+	w.writeLines("INFO started\nWARN slow\nERROR failed\n", "stdout")
+	w.writeContainerLogs("", "ERROR on stderr\nINFO on stderr")
+	if expected := "[edge-1] WARN slow\n[edge-1] ERROR failed\n"; stdout.String() != expected {
+		t.Errorf("Unexpected stdout %q, expected %q", stdout.String(), expected)
+	}
+	if expected := "[edge-1] ERROR on stderr\n"; stderr.String() != expected {
+		t.Errorf("Unexpected stderr %q, expected %q", stderr.String(), expected)
+	}
+}
+
+func TestLineWriterTimestamps(t *testing.T) {
+	w, stdout, _ := newTestLineWriter(t, &OutputConfig{Timestamps: true})
+	w.writeLines("started", "stdout")
+	w.writeError("Agent is not running")
+	expected := "2024-01-02T03:04:05.006Z started\n2024-01-02T03:04:05.006Z Error: Agent is not running\n"
+	if stdout.String() != expected {
+		t.Errorf("Unexpected output %q, expected %q", stdout.String(), expected)
+	}
+}
+
+func TestLineWriterJSON(t *testing.T) {
+	w, stdout, stderr := newTestLineWriter(t, &OutputConfig{Output: "json"})
+	w.prefix = "[edge-1] "
+	w.writeContainerLogs("started\n", "failed\n")
+	expected := `{"time":"2024-01-02T03:04:05.006Z","resource":"agent","name":"edge-1","stream":"stdout","line":"started"}` + "\n" +
+		`{"time":"2024-01-02T03:04:05.006Z","resource":"agent","name":"edge-1","stream":"stderr","line":"failed"}` + "\n"
+	if stdout.String() != expected || stderr.Len() != 0 {
+		t.Errorf("Unexpected output %q, expected %q", stdout.String(), expected)
+	}
+}
+
+func TestOutputConfigValidate(t *testing.T) {
+	if err := (&OutputConfig{Grep: "("}).Validate(); err == nil {
+		t.Errorf("Invalid regular expression accepted")
+	}
+	if err := (&OutputConfig{Output: "yaml"}).Validate(); err == nil {
+		t.Errorf("Invalid output format accepted")
+	}
+	if err := (&OutputConfig{Grep: "ERROR", Output: "json"}).Validate(); err != nil {
+		t.Errorf("Valid config rejected: %v", err)
+	}
+}
@@ -14,8 +14,6 @@
 package logs
 
 import (
-	"fmt"
-
 	"github.com/datasance/potctl/internal/config"
 	rsc "github.com/datasance/potctl/internal/resource"
 	"github.com/datasance/potctl/pkg/util"
@@ -25,13 +23,15 @@ type remoteControllerExecutor struct {
 	controlPlane *rsc.RemoteControlPlane
 	namespace    string
 	name         string
+	lines        *lineWriter
 }
 
-func newRemoteControllerExecutor(controlPlane *rsc.RemoteControlPlane, namespace, name string) *remoteControllerExecutor {
+func newRemoteControllerExecutor(controlPlane *rsc.RemoteControlPlane, namespace, name string, lines *lineWriter) *remoteControllerExecutor {
 	return &remoteControllerExecutor{
 		controlPlane: controlPlane,
 		namespace:    namespace,
 		name:         name,
+		lines:        lines,
 	}
 }
 
@@ -68,6 +68,7 @@ func (exe *remoteControllerExecutor) Execute() error {
 		return err
 	}
 	ssh.SetPort(ctrl.SSH.Port)
+	ssh.SetOptions(ctrl.SSH.Options())
 	if err := ssh.Connect(); err != nil {
 		return err
 	}
@@ -77,7 +78,7 @@ func (exe *remoteControllerExecutor) Execute() error {
 	if err != nil {
 		return err
 	}
-	fmt.Print(out.String())
+	exe.lines.writeLines(out.String(), "stdout")
 
 	return nil
 }
@@ -16,39 +16,37 @@ package logs
 import (
 	"context"
 	"fmt"
-	"os"
 	"strings"
 	"sync"
 
 	ws "github.com/datasance/potctl/internal/util/websocket"
 )
 
+// stdoutMutex serializes the writes of the log streams running at once
+var stdoutMutex sync.Mutex
+
 // LogStream handles streaming logs from WebSocket connection
 type LogStream struct {
 	wsClient    *ws.Client
 	ctx         context.Context
 	cancel      context.CancelFunc
 	cleanupOnce sync.Once
-	stdoutMutex sync.Mutex
+	// Filters and formats the log lines
+	lines *lineWriter
 }
 
 // NewLogStream creates a new LogStream handler
 func NewLogStream(wsClient *ws.Client) *LogStream {
 	ctx, cancel := context.WithCancel(context.Background())
+	lines, _ := newLineWriter("", "", nil)
 	return &LogStream{
 		wsClient: wsClient,
 		ctx:      ctx,
 		cancel:   cancel,
+		lines:    lines,
 	}
 }
 
-func (ls *LogStream) writeToStdout(data []byte) {
-	ls.stdoutMutex.Lock()
-	defer ls.stdoutMutex.Unlock()
-	os.Stdout.Write(data)
-	os.Stdout.Sync()
-}
-
 func (ls *LogStream) cleanup() {
 	ls.cleanupOnce.Do(func() {
 		if ls.wsClient != nil {
@@ -88,18 +86,8 @@ func (ls *LogStream) Start() error {
 
 				// Handle different message types
 				if msg.IsLogLineMessage() {
-					// Write log line to stdout
-					// Ensure the data ends with a newline if it doesn't already
-					data := msg.Data
-					if len(data) == 0 {
-						// Empty line - just write a newline
-						data = []byte{'\n'}
-					} else if data[len(data)-1] != '\n' {
-						// Non-empty line without newline - add one
-						data = append(data, '\n')
-					}
-					// If data already ends with newline, use it as-is
-					ls.writeToStdout(data)
+					// Write the log line, filtered and formatted
+					ls.lines.writeLines(string(msg.Data), "stdout")
 				} else if msg.IsLogStartMessage() {
 					// Log streaming started - can parse sessionId from data if needed
 					// For now, just continue
@@ -113,7 +101,7 @@ func (ls *LogStream) Start() error {
 					if errorMsg == "" {
 						errorMsg = "Log streaming error occurred"
 					}
-					ls.writeToStdout([]byte(fmt.Sprintf("Error: %s\n", errorMsg)))
+					ls.lines.writeError(errorMsg)
 					ls.cancel()
 					return
 				}
@@ -1,23 +0,0 @@
-/*
- *  *******************************************************************************
- *  * Copyright (c) 2023 Datasance Teknoloji A.S.
- *  *
- *  * This program and the accompanying materials are made available under the
- *  * terms of the Eclipse Public License v. 2.0 which is available at
- *  * http://www.eclipse.org/legal/epl-2.0
- *  *
- *  * SPDX-License-Identifier: EPL-2.0
- *  *******************************************************************************
- *
- */
-
-package logs
-
-import (
-	"os"
-)
-
-func printContainerLogs(stdout, stderr string) {
-	os.Stdout.WriteString(stdout)
-	os.Stderr.WriteString(stderr)
-}
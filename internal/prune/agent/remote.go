@@ -46,6 +46,7 @@ func (exe executor) remoteDetachedAgentPrune(agent *rsc.RemoteAgent) error {
 	if err != nil {
 		return err
 	}
+	sshAgent.SetSSHOptions(agent.SSH.Options())
 	if err := sshAgent.Prune(); err != nil {
 		return util.NewInternalError(fmt.Sprintf("Failed to Prune Iofog resource %s. %s", agent.Name, err.Error()))
 	}
@@ -17,6 +17,7 @@ type Namespace struct {
 	LocalAgents            []LocalAgent            `yaml:"localAgents,omitempty"`
 	RemoteAgents           []RemoteAgent           `yaml:"remoteAgents,omitempty"`
 	Volumes                []Volume                `yaml:"volumes,omitempty"`
+	ApplySets              []ApplySet              `yaml:"applySets,omitempty"`
 	Created                string                  `yaml:"created,omitempty"`
 	mux                    sync.Mutex
 }
@@ -233,6 +234,7 @@ func (ns *Namespace) Clone() *Namespace {
 		LocalAgents:            localAgents,
 		RemoteAgents:           remoteAgents,
 		Volumes:                ns.Volumes,
+		ApplySets:              ns.ApplySets,
 	}
 }
 
@@ -297,3 +299,36 @@ func (ns *Namespace) getVolume(name string) (volume Volume, err error) {
 	err = util.NewNotFoundError(ns.Name + "/" + name)
 	return
 }
+
+// GetApplySet returns the resources recorded for an apply set, found is false if it was never applied
+func (ns *Namespace) GetApplySet(name string) (set ApplySet, found bool) {
+	ns.mux.Lock()
+	defer ns.mux.Unlock()
+	for idx := range ns.ApplySets {
+		if ns.ApplySets[idx].Name == name {
+			set = ns.ApplySets[idx]
+			set.Resources = append([]ApplySetResource{}, set.Resources...)
+			return set, true
+		}
+	}
+	return ApplySet{Name: name}, false
+}
+
+// SetApplySet replaces the resources recorded for an apply set, an empty set is removed
+func (ns *Namespace) SetApplySet(set ApplySet) {
+	ns.mux.Lock()
+	defer ns.mux.Unlock()
+	for idx := range ns.ApplySets {
+		if ns.ApplySets[idx].Name == set.Name {
+			if len(set.Resources) == 0 {
+				ns.ApplySets = append(ns.ApplySets[:idx], ns.ApplySets[idx+1:]...)
+			} else {
+				ns.ApplySets[idx] = set
+			}
+			return
+		}
+	}
+	if len(set.Resources) > 0 {
+		ns.ApplySets = append(ns.ApplySets, set)
+	}
+}
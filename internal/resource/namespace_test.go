@@ -53,3 +53,24 @@ func TestAgents(t *testing.T) {
 		t.Errorf("Failed to get Agents, count: %d", len(ns.GetAgents()))
 	}
 }
+
+func TestApplySets(t *testing.T) {
+	ns := Namespace{Name: "apply"}
+	if _, found := ns.GetApplySet("ecn"); found {
+		t.Errorf("Found apply set that was never set")
+	}
+	resources := []ApplySetResource{{Kind: "Secret", Name: "db"}, {Kind: "Application", Name: "app"}}
+	ns.SetApplySet(ApplySet{Name: "ecn", Resources: resources})
+	set, found := ns.GetApplySet("ecn")
+	if !found || len(set.Resources) != 2 {
+		t.Errorf("Failed to get apply set, found: %v, count: %d", found, len(set.Resources))
+	}
+	ns.SetApplySet(ApplySet{Name: "ecn", Resources: resources[:1]})
+	if set, _ = ns.GetApplySet("ecn"); len(set.Resources) != 1 {
+		t.Errorf("Failed to update apply set, count: %d", len(set.Resources))
+	}
+	ns.SetApplySet(ApplySet{Name: "ecn"})
+	if _, found := ns.GetApplySet("ecn"); found {
+		t.Errorf("Found emptied apply set")
+	}
+}
@@ -15,7 +15,6 @@ package resource
 
 import (
 	"github.com/datasance/potctl/pkg/iofog/install"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type AgentScripts struct {
@@ -84,7 +83,7 @@ func (agent *RemoteAgent) Sanitize() (err error) {
 	if agent.SSH.Port == 0 {
 		agent.SSH.Port = 22
 	}
-	if agent.SSH.KeyFile, err = util.FormatPath(agent.SSH.KeyFile); err != nil {
+	if err = agent.SSH.Sanitize(); err != nil {
 		return
 	}
 	return
@@ -116,7 +115,7 @@ func (agent *RemoteAgent) Clone() Agent {
 }
 
 func (agent *RemoteAgent) ValidateSSH() error {
-	if agent.Host == "" || agent.SSH.User == "" || agent.SSH.Port == 0 || agent.SSH.KeyFile == "" {
+	if agent.Host == "" || agent.SSH.User == "" || agent.SSH.Port == 0 || !agent.SSH.HasCredentials() {
 		return NewNoSSHConfigError("Agent")
 	}
 	return nil
@@ -15,7 +15,6 @@ package resource
 
 import (
 	"github.com/datasance/potctl/pkg/iofog/install"
-	"github.com/datasance/potctl/pkg/util"
 )
 
 type ControllerScripts struct {
@@ -63,7 +62,7 @@ func (ctrl *RemoteController) Sanitize() (err error) {
 		ctrl.SSH.Port = 22
 	}
 	// Format file paths
-	if ctrl.SSH.KeyFile, err = util.FormatPath(ctrl.SSH.KeyFile); err != nil {
+	if err = ctrl.SSH.Sanitize(); err != nil {
 		return
 	}
 	return
@@ -94,7 +93,7 @@ func (ctrl *RemoteController) Clone() Controller {
 }
 
 func (ctrl *RemoteController) ValidateSSH() error {
-	if ctrl.Host == "" || ctrl.SSH.User == "" || ctrl.SSH.Port == 0 || ctrl.SSH.KeyFile == "" {
+	if ctrl.Host == "" || ctrl.SSH.User == "" || ctrl.SSH.Port == 0 || !ctrl.SSH.HasCredentials() {
 		return NewNoSSHConfigError("Controller")
 	}
 	return nil
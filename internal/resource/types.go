@@ -18,6 +18,7 @@ import (
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/apps"
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/pkg/util"
 )
 
 type Microservice = apps.Microservice
@@ -43,9 +44,96 @@ type Package struct {
 }
 
 type SSH struct {
-	User    string `yaml:"user,omitempty"`
-	Port    int    `yaml:"port,omitempty"`
-	KeyFile string `yaml:"keyFile,omitempty"`
+	User               string        `yaml:"user,omitempty"`
+	Port               int           `yaml:"port,omitempty"`
+	KeyFile            string        `yaml:"keyFile,omitempty"`
+	CertFile           string        `yaml:"certFile,omitempty"`           // OpenSSH certificate of the key
+	UseAgent           bool          `yaml:"useAgent,omitempty"`           // Authenticate with the agent on SSH_AUTH_SOCK
+	HostKeyFingerprint string        `yaml:"hostKeyFingerprint,omitempty"` // Pinned on first connection
+	HostKeyType        string        `yaml:"hostKeyType,omitempty"`        // Type of the pinned host key
+	ProxyJump          []SSHJumpHost `yaml:"proxyJump,omitempty"`          // Bastions to connect through, in order
+}
+
+// SSHJumpHost is a bastion host. Unset user and credentials are those of the target host
+type SSHJumpHost struct {
+	Host     string `yaml:"host"`
+	User     string `yaml:"user,omitempty"`
+	Port     int    `yaml:"port,omitempty"`
+	KeyFile  string `yaml:"keyFile,omitempty"`
+	CertFile string `yaml:"certFile,omitempty"`
+	UseAgent bool   `yaml:"useAgent,omitempty"`
+}
+
+// Options returns the settings of SSH clients connecting with this configuration
+func (ssh *SSH) Options() util.SSHOptions {
+	options := util.SSHOptions{
+		HostKeyFingerprint: ssh.HostKeyFingerprint,
+		HostKeyType:        ssh.HostKeyType,
+		UseAgent:           ssh.UseAgent,
+		CertFile:           ssh.CertFile,
+	}
+	for _, hop := range ssh.ProxyJump {
+		jumpHost := util.SSHJumpHost{
+			Host:     hop.Host,
+			User:     hop.User,
+			Port:     hop.Port,
+			KeyFile:  hop.KeyFile,
+			CertFile: hop.CertFile,
+			UseAgent: hop.UseAgent,
+		}
+		if jumpHost.User == "" {
+			jumpHost.User = ssh.User
+		}
+		if jumpHost.KeyFile == "" && !jumpHost.UseAgent {
+			jumpHost.KeyFile = ssh.KeyFile
+			jumpHost.CertFile = ssh.CertFile
+			jumpHost.UseAgent = ssh.UseAgent
+		}
+		if jumpHost.Port == 0 {
+			jumpHost.Port = 22
+		}
+		options.ProxyJump = append(options.ProxyJump, jumpHost)
+	}
+	return options
+}
+
+// Sanitize formats the file paths and sets the default port of jump hosts
+func (ssh *SSH) Sanitize() (err error) {
+	if ssh.KeyFile, err = util.FormatPath(ssh.KeyFile); err != nil {
+		return
+	}
+	if ssh.CertFile, err = util.FormatPath(ssh.CertFile); err != nil {
+		return
+	}
+	for idx := range ssh.ProxyJump {
+		hop := &ssh.ProxyJump[idx]
+		if hop.Host == "" {
+			return util.NewInputError("Jump hosts must specify a host")
+		}
+		if hop.Port == 0 {
+			hop.Port = 22
+		}
+		if hop.KeyFile, err = util.FormatPath(hop.KeyFile); err != nil {
+			return
+		}
+		if hop.CertFile, err = util.FormatPath(hop.CertFile); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// KeepHostKey keeps the host key pinned by a previous deploy to the same host and port, deploy files don't carry it
+func (ssh *SSH) KeepHostKey(host string, previous *SSH, previousHost string) {
+	if ssh.HostKeyFingerprint == "" && host == previousHost && ssh.Port == previous.Port {
+		ssh.HostKeyFingerprint = previous.HostKeyFingerprint
+		ssh.HostKeyType = previous.HostKeyType
+	}
+}
+
+// HasCredentials returns true if a private key or the SSH agent can be used to authenticate
+func (ssh *SSH) HasCredentials() bool {
+	return ssh.KeyFile != "" || ssh.UseAgent
 }
 
 type KubeImages struct {
@@ -128,6 +216,18 @@ type Volume struct {
 	Permissions string   `json:"permissions" yaml:"permissions"`
 }
 
+// ApplySet records the resources deployed by potctl apply from a set of files, so that the resources
+// removed from the files can be pruned
+type ApplySet struct {
+	Name      string             `json:"name" yaml:"name"`
+	Resources []ApplySetResource `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+type ApplySetResource struct {
+	Kind string `json:"kind" yaml:"kind"`
+	Name string `json:"name" yaml:"name"`
+}
+
 type OfflineImage struct {
 	Name     string            `json:"name" yaml:"name"`
 	X86Image string            `json:"x86,omitempty" yaml:"x86,omitempty"`
@@ -26,6 +26,9 @@ type IofogUser struct {
 	SubscriptionKey string `yaml:"subscriptionKey,omitempty"`
 	AccessToken     string `yaml:"accessToken,omitempty"`
 	RefreshToken    string `yaml:"refreshToken,omitempty"`
+	Login           string `yaml:"login,omitempty"`        // Mode of potctl login, empty if the password is used
+	ClientID        string `yaml:"clientId,omitempty"`     // Client of the client-credentials mode if not the Controller client
+	ClientSecret    string `yaml:"clientSecret,omitempty"` // Secret of ClientID, only kept with a secret store
 }
 
 func (user *IofogUser) EncodePassword() {
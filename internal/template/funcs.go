@@ -0,0 +1,82 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package template
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	texttemplate "text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Functions available to templates in addition to the text/template builtins
+var funcs = texttemplate.FuncMap{
+	"env": os.Getenv,
+	"default": func(def, value interface{}) interface{} {
+		if isEmpty(value) {
+			return def
+		}
+		return value
+	},
+	"required": func(msg string, value interface{}) (interface{}, error) {
+		if isEmpty(value) {
+			return nil, errors.New(msg)
+		}
+		return value, nil
+	},
+	"quote": func(value interface{}) string {
+		return fmt.Sprintf("%q", fmt.Sprint(value))
+	},
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+	"trim":    strings.TrimSpace,
+	"replace": func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"join": func(sep string, values []interface{}) string {
+		strs := make([]string, len(values))
+		for idx := range values {
+			strs[idx] = fmt.Sprint(values[idx])
+		}
+		return strings.Join(strs, sep)
+	},
+	"indent": indent,
+	"nindent": func(spaces int, s string) string {
+		return "\n" + indent(spaces, s)
+	},
+	"toYaml": func(value interface{}) (string, error) {
+		out, err := yaml.Marshal(value)
+		return strings.TrimSuffix(string(out), "\n"), err
+	},
+}
+
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+func isEmpty(value interface{}) bool {
+	switch typed := value.(type) {
+	case nil:
+		return true
+	case string:
+		return typed == ""
+	case map[string]interface{}:
+		return len(typed) == 0
+	case []interface{}:
+		return len(typed) == 0
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/datasance/potctl/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	FlagDescValues    = "YAML file of values available to templates as .Values, can be repeated"
+	FlagDescSet       = "Set a value available to templates, e.g. site.name=plant-1, can be repeated"
+	FlagDescExpandEnv = "Expand ${VAR} references to environment variables"
+)
+
+// Options of the templating layer applied to input files before they are decoded
+type Options struct {
+	// YAML files of values, merged in order
+	ValuesFiles []string
+	// key=value pairs with dotted keys, merged after the values files
+	Set []string
+	// Expand ${VAR} references to environment variables before rendering
+	ExpandEnv bool
+}
+
+// Enabled returns true if input files are rendered, templating is opt-in
+func (opt *Options) Enabled() bool {
+	return opt != nil && (len(opt.ValuesFiles) > 0 || len(opt.Set) > 0 || opt.ExpandEnv)
+}
+
+// Values returns the merged values available to templates as .Values
+func (opt *Options) Values() (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	for _, file := range opt.ValuesFiles {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var fileValues interface{}
+		if err := yaml.Unmarshal(content, &fileValues); err != nil {
+			return nil, util.NewInputError(fmt.Sprintf("Failed to decode values file %s: %s", file, err.Error()))
+		}
+		if fileValues == nil {
+			continue
+		}
+		normalized, ok := normalize(fileValues).(map[string]interface{})
+		if !ok {
+			return nil, util.NewInputError(fmt.Sprintf("Values file %s must contain a map", file))
+		}
+		merge(values, normalized)
+	}
+	for _, pair := range opt.Set {
+		if err := set(values, pair); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// Render returns the input rendered with the values of the options, or the input unchanged if templating is
+// not enabled
+func Render(name string, input []byte, opt *Options) ([]byte, error) {
+	if !opt.Enabled() {
+		return input, nil
+	}
+	values, err := opt.Values()
+	if err != nil {
+		return nil, err
+	}
+	if opt.ExpandEnv {
+		if input, err = expandEnv(input); err != nil {
+			return nil, err
+		}
+	}
+	tmpl, err := texttemplate.New(name).Funcs(funcs).Option("missingkey=error").Parse(string(input))
+	if err != nil {
+		return nil, util.NewInputError(fmt.Sprintf("Failed to parse template %s: %s", name, err.Error()))
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, map[string]interface{}{"Values": values}); err != nil {
+		return nil, util.NewInputError(fmt.Sprintf("Failed to render template %s: %s", name, err.Error()))
+	}
+	return out.Bytes(), nil
+}
+
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces ${VAR} references, all the variables must be set
+func expandEnv(input []byte) ([]byte, error) {
+	missing := make(map[string]bool)
+	expanded := envReference.ReplaceAllFunc(input, func(ref []byte) []byte {
+		name := string(envReference.FindSubmatch(ref)[1])
+		value, found := os.LookupEnv(name)
+		if !found {
+			missing[name] = true
+		}
+		return []byte(value)
+	})
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, util.NewInputError("Environment variables not set: " + strings.Join(names, ", "))
+	}
+	return expanded, nil
+}
+
+// set merges a key=value pair, the value is decoded as a YAML scalar so that numbers and booleans keep their type
+func set(values map[string]interface{}, pair string) error {
+	idx := strings.Index(pair, "=")
+	if idx <= 0 {
+		return util.NewInputError(fmt.Sprintf("Invalid value %s, expected key=value", pair))
+	}
+	keys := strings.Split(pair[:idx], ".")
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(pair[idx+1:]), &value); err != nil {
+		value = pair[idx+1:]
+	}
+	if _, isMap := value.(map[interface{}]interface{}); isMap {
+		value = pair[idx+1:]
+	}
+	if _, isSlice := value.([]interface{}); isSlice {
+		value = pair[idx+1:]
+	}
+	if value == nil {
+		value = ""
+	}
+	current := values
+	for _, key := range keys[:len(keys)-1] {
+		if key == "" {
+			return util.NewInputError(fmt.Sprintf("Invalid key in %s", pair))
+		}
+		child, ok := current[key].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			current[key] = child
+		}
+		current = child
+	}
+	if keys[len(keys)-1] == "" {
+		return util.NewInputError(fmt.Sprintf("Invalid key in %s", pair))
+	}
+	current[keys[len(keys)-1]] = value
+	return nil
+}
+
+// merge deep merges src into dst, values of src take precedence
+func merge(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			merge(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// normalize converts the maps decoded by yaml to maps keyed by strings
+func normalize(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(typed))
+		for key, child := range typed {
+			normalized[fmt.Sprint(key)] = normalize(child)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(typed))
+		for idx := range typed {
+			normalized[idx] = normalize(typed[idx])
+		}
+		return normalized
+	}
+	return value
+}
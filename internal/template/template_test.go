@@ -0,0 +1,65 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package template
+
+import (
+	"os"
+	"path"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	valuesFile := path.Join(t.TempDir(), "values.yaml")
+	if err := os.WriteFile(valuesFile, []byte("site:\n  name: plant-1\n  replicas: 1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("POTCTL_TEST_REGISTRY", "registry.local")
+	opt := &Options{
+		ValuesFiles: []string{valuesFile},
+		Set:         []string{"site.replicas=3", "debug=true"},
+		ExpandEnv:   true,
+	}
+	input := `name: {{ .Values.site.name | upper }}
+image: ${POTCTL_TEST_REGISTRY}/app
+replicas: {{ .Values.site.replicas }}
+debug: {{ .Values.debug }}
+tag: {{ index .Values "tag" | default "latest" | quote }}`
+	expected := `name: PLANT-1
+image: registry.local/app
+replicas: 3
+debug: true
+tag: "latest"`
+	out, err := Render("app.yaml", []byte(input), opt)
+	if err != nil {
+		t.Fatalf("Failed to render: %s", err.Error())
+	}
+	if string(out) != expected {
+		t.Errorf("Unexpected output:\n%s", out)
+	}
+
+	if _, err := Render("app.yaml", []byte("name: {{ .Values.missing.name }}"), opt); err == nil {
+		t.Errorf("Rendered missing value")
+	}
+	if _, err := Render("app.yaml", []byte("name: ${POTCTL_TEST_UNSET}"), opt); err == nil {
+		t.Errorf("Expanded unset environment variable")
+	}
+}
+
+func TestRenderDisabled(t *testing.T) {
+	input := []byte("command: echo {{ .Values }} ${HOME}")
+	out, err := Render("app.yaml", input, &Options{})
+	if err != nil || string(out) != string(input) {
+		t.Errorf("Rendered input without templating enabled: %s", out)
+	}
+}
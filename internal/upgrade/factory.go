@@ -14,7 +14,10 @@
 package upgrade
 
 import (
+	"time"
+
 	"github.com/datasance/potctl/internal/execute"
+	"github.com/datasance/potctl/internal/util/selector"
 	"github.com/datasance/potctl/pkg/util"
 )
 
@@ -22,11 +25,26 @@ type Options struct {
 	ResourceType string
 	Namespace    string
 	Name         string
+	// Fleet upgrades of the Agents having all Tags and matching the Selector, or of All Agents of the Namespace
+	Tags           []string
+	Selector       *selector.Selector
+	All            bool
+	BatchSize      int
+	MaxUnavailable int
+	// Version expected after the upgrade, any new version if empty
+	Version string
+	Timeout time.Duration
 }
 
 func NewExecutor(opt Options) (execute.Executor, error) {
 	switch opt.ResourceType {
 	case "agent":
+		if opt.All || len(opt.Tags) > 0 || opt.Selector != nil {
+			return newRolloutExecutor(opt)
+		}
+		if opt.Name == "" {
+			return nil, util.NewInputError("Specify an Agent name, --tag, --selector or --all")
+		}
 		return newAgentExecutor(opt), nil
 	default:
 		return nil, util.NewInputError("Unsupported resource: " + opt.ResourceType)
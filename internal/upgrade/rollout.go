@@ -0,0 +1,324 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package upgrade
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	clientutil "github.com/datasance/potctl/internal/util/client"
+	"github.com/datasance/potctl/internal/util/selector"
+	"github.com/datasance/potctl/pkg/iofog/install"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+const (
+	agentRunningStatus = "RUNNING"
+	// Interval at which upgraded Agents are polled for their status
+	rolloutPollInterval = 10 * time.Second
+	// DefaultRolloutTimeout is the default time an Agent has to pass the health gate after its upgrade
+	DefaultRolloutTimeout = 10 * time.Minute
+)
+
+// agentUpgrader upgrades, gets and rolls back Agents
+type agentUpgrader interface {
+	UpgradeAgent(name string) error
+	GetAgent(name string) (*client.AgentInfo, error)
+	RollbackAgent(name string) error
+}
+
+// controllerUpgrader reaches the Agents through the Controller of the Namespace
+type controllerUpgrader struct {
+	namespace string
+}
+
+func (upgrader *controllerUpgrader) UpgradeAgent(name string) error {
+	return clientutil.ExecuteWithAuthRetry(upgrader.namespace, func(clt *client.Client) error {
+		return clt.UpgradeAgent(name)
+	})
+}
+
+func (upgrader *controllerUpgrader) GetAgent(name string) (agent *client.AgentInfo, err error) {
+	err = clientutil.ExecuteWithAuthRetry(upgrader.namespace, func(clt *client.Client) (err error) {
+		agent, err = clt.GetAgentByName(name)
+		return
+	})
+	return
+}
+
+func (upgrader *controllerUpgrader) RollbackAgent(name string) error {
+	return clientutil.ExecuteWithAuthRetry(upgrader.namespace, func(clt *client.Client) error {
+		return clt.RollbackAgent(name)
+	})
+}
+
+// rolloutExecutor upgrades a fleet of Agents in batches, waiting for each batch to report the new version
+// and RUNNING before continuing. Agents failing the health gate after changing version are rolled back.
+type rolloutExecutor struct {
+	namespace      string
+	tags           []string
+	selector       *selector.Selector
+	batchSize      int
+	maxUnavailable int
+	version        string
+	timeout        time.Duration
+	pollInterval   time.Duration
+	upgrader       agentUpgrader
+}
+
+func newRolloutExecutor(opt Options) (*rolloutExecutor, error) {
+	if opt.Name != "" {
+		return nil, util.NewInputError("Cannot specify an Agent name together with --tag, --selector or --all")
+	}
+	if opt.BatchSize < 1 {
+		return nil, util.NewInputError("Batch size must be at least 1")
+	}
+	exe := &rolloutExecutor{
+		namespace:      opt.Namespace,
+		tags:           opt.Tags,
+		selector:       opt.Selector,
+		batchSize:      opt.BatchSize,
+		maxUnavailable: opt.MaxUnavailable,
+		version:        opt.Version,
+		timeout:        opt.Timeout,
+		pollInterval:   rolloutPollInterval,
+		upgrader:       &controllerUpgrader{namespace: opt.Namespace},
+	}
+	// Default to as many unavailable Agents as there are Agents in a batch
+	if exe.maxUnavailable <= 0 {
+		exe.maxUnavailable = exe.batchSize
+	}
+	if exe.timeout <= 0 {
+		exe.timeout = DefaultRolloutTimeout
+	}
+	return exe, nil
+}
+
+func (exe *rolloutExecutor) GetName() string {
+	if exe.selector != nil {
+		return fmt.Sprintf("Agents matching %s", exe.selector)
+	}
+	if len(exe.tags) > 0 {
+		return fmt.Sprintf("Agents tagged %s", strings.Join(exe.tags, ","))
+	}
+	return fmt.Sprintf("Agents of Namespace %s", exe.namespace)
+}
+
+// rolloutResult is the outcome of the upgrade of a single Agent
+type rolloutResult struct {
+	name string
+	err  error
+	// The Agent failed the health gate, as opposed to the upgrade request failing
+	unavailable bool
+}
+
+func (exe *rolloutExecutor) Execute() error {
+	agents, err := exe.selectAgents()
+	if err != nil {
+		return err
+	}
+	return exe.rollout(agents)
+}
+
+// rollout upgrades the Agents in batches. Only Agents made unavailable by the rollout count against --max-unavailable.
+func (exe *rolloutExecutor) rollout(agents []client.AgentInfo) error {
+	// Agents that are down can't be upgraded, they are skipped without eating into the budget
+	pending := []client.AgentInfo{}
+	unavailable := 0
+	for idx := range agents {
+		agent := &agents[idx]
+		switch {
+		case !isRunning(agent):
+			util.PrintNotify(fmt.Sprintf("Skipping Agent %s, its status is %s", agent.Name, agent.DaemonStatus))
+		case exe.version != "" && agent.Version == exe.version:
+			util.PrintInfo(fmt.Sprintf("Agent %s is already at version %s", agent.Name, agent.Version))
+		default:
+			pending = append(pending, *agent)
+		}
+	}
+
+	failed := []string{}
+	upgraded := 0
+	for len(pending) > 0 {
+		size := exe.batchSize
+		if room := exe.maxUnavailable - unavailable; room < size {
+			size = room
+		}
+		if size <= 0 {
+			return util.NewError(fmt.Sprintf("Rollout halted with %d Agents left to upgrade: %d Agents failed the health gate, which reaches --max-unavailable %d. Failed Agents: %s",
+				len(pending), unavailable, exe.maxUnavailable, formatNames(failed)))
+		}
+		if size > len(pending) {
+			size = len(pending)
+		}
+		batch := pending[:size]
+		pending = pending[size:]
+
+		util.SpinStart(fmt.Sprintf("Upgrading Agents %s", formatAgentNames(batch)))
+		for _, result := range exe.upgradeBatch(batch) {
+			if result.err != nil {
+				util.PrintNotify(fmt.Sprintf("Agent %s failed the upgrade: %s", result.name, result.err.Error()))
+				failed = append(failed, result.name)
+				if result.unavailable {
+					unavailable++
+				}
+				continue
+			}
+			upgraded++
+		}
+		util.SpinStop()
+		util.PrintInfo(fmt.Sprintf("Upgraded %d/%d Agents", upgraded, upgraded+len(failed)+len(pending)))
+	}
+
+	if len(failed) > 0 {
+		return util.NewError(fmt.Sprintf("Agents %s failed the upgrade, those failing the health gate after changing version were rolled back", formatNames(failed)))
+	}
+	return nil
+}
+
+// selectAgents returns the Agents of the Namespace having all the tags and matching the selector, sorted by name
+func (exe *rolloutExecutor) selectAgents() ([]client.AgentInfo, error) {
+	if err := clientutil.SyncAgentInfo(exe.namespace); err != nil {
+		return nil, err
+	}
+	backendAgents, err := clientutil.GetBackendAgents(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	agents := []client.AgentInfo{}
+	for idx := range backendAgents {
+		if hasTags(backendAgents[idx].Tags, exe.tags) && (exe.selector == nil || exe.selector.MatchesTags(backendAgents[idx].Tags)) {
+			agents = append(agents, backendAgents[idx])
+		}
+	}
+	if len(agents) == 0 {
+		return nil, util.NewNotFoundError(fmt.Sprintf("No %s found", exe.GetName()))
+	}
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].Name < agents[j].Name
+	})
+	return agents, nil
+}
+
+// upgradeBatch upgrades the Agents of the batch at once and waits for each of them to pass the health gate
+func (exe *rolloutExecutor) upgradeBatch(batch []client.AgentInfo) []rolloutResult {
+	results := make([]rolloutResult, len(batch))
+	var wg sync.WaitGroup
+	for idx := range batch {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			agent := &batch[idx]
+			results[idx] = rolloutResult{name: agent.Name}
+			if err := exe.upgrader.UpgradeAgent(agent.Name); err != nil {
+				results[idx].err = err
+				return
+			}
+			changed, err := exe.waitForUpgrade(agent)
+			if err != nil {
+				results[idx].err = err
+				results[idx].unavailable = true
+				// An Agent still at its previous version has nothing to roll back
+				if changed {
+					exe.rollback(agent.Name)
+				}
+			}
+		}(idx)
+	}
+	wg.Wait()
+	return results
+}
+
+// waitForUpgrade polls the Agent until it reports the new version and RUNNING, or the timeout is reached.
+// It also reports whether the Agent was seen with a version other than its previous one.
+func (exe *rolloutExecutor) waitForUpgrade(previous *client.AgentInfo) (bool, error) {
+	deadline := time.Now().Add(exe.timeout)
+	status, version := previous.DaemonStatus, previous.Version
+	changed := false
+	for time.Now().Before(deadline) {
+		time.Sleep(exe.pollInterval)
+		agent, err := exe.upgrader.GetAgent(previous.Name)
+		if err != nil {
+			// The Agent may not be reachable through the Controller while it restarts
+			install.Verbose(fmt.Sprintf("Failed to get status of Agent %s: %s", previous.Name, err.Error()))
+			continue
+		}
+		status, version = agent.DaemonStatus, agent.Version
+		if agent.Version != "" && agent.Version != previous.Version {
+			changed = true
+		}
+		if exe.isUpgraded(previous, agent) && isRunning(agent) {
+			return changed, nil
+		}
+	}
+	return changed, util.NewError(fmt.Sprintf("Timed out after %s waiting for the new version and %s, last reported version %s and status %s",
+		exe.timeout, agentRunningStatus, version, status))
+}
+
+func (exe *rolloutExecutor) isUpgraded(previous, agent *client.AgentInfo) bool {
+	if exe.version != "" {
+		return agent.Version == exe.version
+	}
+	return agent.Version != "" && agent.Version != previous.Version
+}
+
+func (exe *rolloutExecutor) rollback(name string) {
+	if err := exe.upgrader.RollbackAgent(name); err != nil {
+		util.PrintNotify(fmt.Sprintf("Failed to roll back Agent %s: %s", name, err.Error()))
+		return
+	}
+	util.PrintNotify(fmt.Sprintf("Rolled back Agent %s", name))
+}
+
+func isRunning(agent *client.AgentInfo) bool {
+	return strings.EqualFold(agent.DaemonStatus, agentRunningStatus)
+}
+
+// hasTags reports whether agentTags contain all of tags
+func hasTags(agentTags *[]string, tags []string) bool {
+	for _, tag := range tags {
+		found := false
+		if agentTags != nil {
+			for _, agentTag := range *agentTags {
+				if agentTag == tag {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func formatAgentNames(agents []client.AgentInfo) string {
+	names := make([]string, len(agents))
+	for idx := range agents {
+		names[idx] = agents[idx].Name
+	}
+	return formatNames(names)
+}
+
+func formatNames(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
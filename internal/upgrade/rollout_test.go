@@ -0,0 +1,158 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package upgrade
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+)
+
+const (
+	oldVersion = "1.0.0"
+	newVersion = "2.0.0"
+)
+
+// fakeAgent is the state of an Agent as reported by the fake upgrader after its upgrade
+type fakeAgent struct {
+	upgradeErr error
+	version    string
+	status     string
+}
+
+type fakeUpgrader struct {
+	mutex      sync.Mutex
+	agents     map[string]fakeAgent
+	upgraded   []string
+	rolledBack []string
+}
+
+func (upgrader *fakeUpgrader) UpgradeAgent(name string) error {
+	upgrader.mutex.Lock()
+	defer upgrader.mutex.Unlock()
+	upgrader.upgraded = append(upgrader.upgraded, name)
+	return upgrader.agents[name].upgradeErr
+}
+
+func (upgrader *fakeUpgrader) GetAgent(name string) (*client.AgentInfo, error) {
+	agent := upgrader.agents[name]
+	return &client.AgentInfo{Name: name, Version: agent.version, DaemonStatus: agent.status}, nil
+}
+
+func (upgrader *fakeUpgrader) RollbackAgent(name string) error {
+	upgrader.mutex.Lock()
+	defer upgrader.mutex.Unlock()
+	upgrader.rolledBack = append(upgrader.rolledBack, name)
+	return nil
+}
+
+func TestRollout(t *testing.T) {
+	healthy := fakeAgent{version: newVersion, status: agentRunningStatus}
+	tests := []struct {
+		name       string
+		status     map[string]string
+		agents     map[string]fakeAgent
+		batchSize  int
+		upgraded   []string
+		rolledBack []string
+		fail       bool
+	}{
+		{
+			name:     "agents already down are skipped without halting",
+			status:   map[string]string{"b": "UNKNOWN"},
+			agents:   map[string]fakeAgent{"a": healthy, "c": healthy},
+			upgraded: []string{"a", "c"},
+		},
+		{
+			name:       "unhealthy agents with a new version are rolled back",
+			agents:     map[string]fakeAgent{"a": {version: newVersion, status: "STOPPED"}},
+			upgraded:   []string{"a"},
+			rolledBack: []string{"a"},
+			fail:       true,
+		},
+		{
+			name:     "agents that never changed version are not rolled back",
+			agents:   map[string]fakeAgent{"a": {version: oldVersion, status: agentRunningStatus}},
+			upgraded: []string{"a"},
+			fail:     true,
+		},
+		{
+			name:       "failed health gates halt the rollout",
+			agents:     map[string]fakeAgent{"a": {version: newVersion, status: "STOPPED"}, "b": healthy, "c": healthy},
+			upgraded:   []string{"a"},
+			rolledBack: []string{"a"},
+			fail:       true,
+		},
+		{
+			name:     "failed upgrade requests don't halt the rollout",
+			agents:   map[string]fakeAgent{"a": {upgradeErr: errors.New("bad request")}, "b": healthy},
+			upgraded: []string{"a", "b"},
+			fail:     true,
+		},
+		{
+			name:       "batches are upgraded together",
+			agents:     map[string]fakeAgent{"a": healthy, "b": {version: newVersion, status: "STOPPED"}, "c": healthy},
+			batchSize:  2,
+			upgraded:   []string{"a", "b", "c"},
+			rolledBack: []string{"b"},
+			fail:       true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			agents := []client.AgentInfo{}
+			for _, name := range []string{"a", "b", "c"} {
+				status, found := test.status[name]
+				if !found {
+					if _, found = test.agents[name]; !found {
+						continue
+					}
+					status = agentRunningStatus
+				}
+				agents = append(agents, client.AgentInfo{Name: name, Version: oldVersion, DaemonStatus: status})
+			}
+			batchSize := test.batchSize
+			if batchSize == 0 {
+				batchSize = 1
+			}
+			upgrader := &fakeUpgrader{agents: test.agents}
+			exe, err := newRolloutExecutor(Options{Namespace: "default", All: true, BatchSize: batchSize, Version: newVersion, Timeout: 20 * time.Millisecond})
+			if err != nil {
+				t.Fatal(err)
+			}
+			exe.pollInterval = time.Millisecond
+			exe.upgrader = upgrader
+
+			err = exe.rollout(agents)
+			if (err != nil) != test.fail {
+				t.Errorf("Expected failure %v, got %v", test.fail, err)
+			}
+			sort.Strings(upgrader.upgraded)
+			if !reflect.DeepEqual(upgrader.upgraded, test.upgraded) {
+				t.Errorf("Expected upgraded Agents %v, got %v", test.upgraded, upgrader.upgraded)
+			}
+			if len(upgrader.rolledBack) > 0 || len(test.rolledBack) > 0 {
+				sort.Strings(upgrader.rolledBack)
+				if !reflect.DeepEqual(upgrader.rolledBack, test.rolledBack) {
+					t.Errorf("Expected rolled back Agents %v, got %v", test.rolledBack, upgrader.rolledBack)
+				}
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ package client
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
@@ -29,6 +30,11 @@ func InvalidateCache() {
 	pkg.agentCacheRequestChan <- newAgentCacheRequest("")
 }
 
+// InvalidateAgentCache will clear the cached lists of Agents while keeping the cached clients
+func InvalidateAgentCache() {
+	pkg.agentCacheRequestChan <- newAgentCacheRequest("")
+}
+
 // NewControllerClient will return cached client or create new client and cache it
 func NewControllerClient(namespace string) (*client.Client, error) {
 	request := newClientCacheRequest(namespace)
@@ -103,6 +109,35 @@ func ParseFQName(fqName, resourceType string) (appName, name string, err error)
 	}
 }
 
+// GetApplicationMicroserviceNames returns the AppName/MsvcName of the Microservices of an Application,
+// system Microservices excluded
+func GetApplicationMicroserviceNames(namespace, appName string) ([]string, error) {
+	var msvcs []client.MicroserviceInfo
+	err := ExecuteWithAuthRetry(namespace, func(clt *client.Client) error {
+		response, err := clt.GetMicroservicesByApplication(appName)
+		if err != nil {
+			return err
+		}
+		msvcs = response.Microservices
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for idx := range msvcs {
+		if util.IsSystemMsvc(&msvcs[idx]) {
+			continue
+		}
+		names = append(names, appName+"/"+msvcs[idx].Name)
+	}
+	if len(names) == 0 {
+		return nil, util.NewNotFoundError(fmt.Sprintf("No Microservices found in Application %s", appName))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 func GetMicroserviceUUID(namespace, appName, name string) (uuid string, err error) {
 	clt, err := NewControllerClient(namespace)
 	if err != nil {
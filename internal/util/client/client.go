@@ -15,10 +15,12 @@ package client
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
 	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/internal/login"
 	rsc "github.com/datasance/potctl/internal/resource"
 	"github.com/datasance/potctl/pkg/iofog"
 	"github.com/datasance/potctl/pkg/util"
@@ -219,7 +221,7 @@ func newControllerClient(namespace string) (*client.Client, error) {
 
 		// Use SessionLogin to attempt to refresh the session
 		util.SpinHandlePrompt()
-		refreshedClient, err := client.SessionLogin(client.Options{BaseURL: baseURL}, refreshToken, user.Email, user.GetRawPassword())
+		refreshedClient, err := sessionLogin(namespace, baseURL, controlPlane, refreshToken)
 		if err != nil {
 			fmt.Println("Error: Failed to refresh session:", err)
 			return nil, fmt.Errorf("failed to refresh session: %v", err)
@@ -253,7 +255,7 @@ func newControllerClient(namespace string) (*client.Client, error) {
 
 	// Create a new client and login
 	util.SpinHandlePrompt()
-	newClient, err := client.SessionLogin(client.Options{BaseURL: baseURL}, user.RefreshToken, user.Email, user.GetRawPassword())
+	newClient, err := sessionLogin(namespace, baseURL, controlPlane, user.RefreshToken)
 	if err != nil {
 		return nil, err
 	}
@@ -271,6 +273,24 @@ func newControllerClient(namespace string) (*client.Client, error) {
 	return newClient, nil
 }
 
+// sessionLogin starts a session with the Controller from a refresh token, falling back to the password of the user.
+// Users of potctl login have no password, clients of the client-credentials mode log in again instead.
+func sessionLogin(namespace string, baseURL *url.URL, controlPlane rsc.ControlPlane, refreshToken string) (*client.Client, error) {
+	user := controlPlane.GetUser()
+	ioClient, err := client.SessionLogin(client.Options{BaseURL: baseURL}, refreshToken, user.Email, user.GetRawPassword())
+	if err == nil || user.Login == login.ModePassword {
+		return ioClient, err
+	}
+	if user.Login != login.ModeClientCredentials {
+		return nil, util.NewError(fmt.Sprintf("The session of namespace %s has expired, run potctl login -n %s --mode %s: %s", namespace, namespace, user.Login, err.Error()))
+	}
+	refreshToken, err = login.RenewToken(controlPlane)
+	if err != nil {
+		return nil, err
+	}
+	return client.SessionLogin(client.Options{BaseURL: baseURL}, refreshToken, "", "")
+}
+
 func getBackendAgents(namespace string, ioClient *client.Client) ([]client.AgentInfo, error) {
 	var agentList client.ListAgentsResponse
 	var err error
@@ -385,7 +405,7 @@ func refreshClientAuthentication(namespace string) (*client.Client, error) {
 
 	// Re-authenticate using SessionLogin
 	util.SpinHandlePrompt()
-	refreshedClient, err := client.SessionLogin(client.Options{BaseURL: baseURL}, user.RefreshToken, user.Email, user.GetRawPassword())
+	refreshedClient, err := sessionLogin(namespace, baseURL, controlPlane, user.RefreshToken)
 	if err != nil {
 		util.SpinHandlePromptComplete()
 		return nil, fmt.Errorf("failed to refresh authentication: %v", err)
@@ -0,0 +1,99 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	"github.com/datasance/potctl/internal/util/selector"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// SelectAgents returns the Agents of the Namespace whose tags match the selector, sorted by name
+func SelectAgents(namespace string, sel *selector.Selector) ([]client.AgentInfo, error) {
+	if err := SyncAgentInfo(namespace); err != nil {
+		return nil, err
+	}
+	backendAgents, err := GetBackendAgents(namespace)
+	if err != nil {
+		return nil, err
+	}
+	agents := []client.AgentInfo{}
+	for idx := range backendAgents {
+		if sel.MatchesTags(backendAgents[idx].Tags) {
+			agents = append(agents, backendAgents[idx])
+		}
+	}
+	if len(agents) == 0 {
+		return nil, util.NewNotFoundError(fmt.Sprintf("No Agents matching %s found in Namespace %s", sel, namespace))
+	}
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].Name < agents[j].Name
+	})
+	return agents, nil
+}
+
+// SelectAgentNames returns the names of the Agents of the Namespace whose tags match the selector
+func SelectAgentNames(namespace string, sel *selector.Selector) ([]string, error) {
+	agents, err := SelectAgents(namespace, sel)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(agents))
+	for idx := range agents {
+		names[idx] = agents[idx].Name
+	}
+	return names, nil
+}
+
+// SelectMicroserviceNames returns the AppName/MsvcName of the Microservices of the Namespace running on
+// Agents whose tags match the selector, system Microservices excluded
+func SelectMicroserviceNames(namespace string, sel *selector.Selector) ([]string, error) {
+	agents, err := SelectAgents(namespace, sel)
+	if err != nil {
+		return nil, err
+	}
+	agentUUIDs := make(map[string]bool, len(agents))
+	for idx := range agents {
+		agentUUIDs[agents[idx].UUID] = true
+	}
+
+	var msvcs []client.MicroserviceInfo
+	err = ExecuteWithAuthRetry(namespace, func(clt *client.Client) error {
+		response, err := clt.GetAllMicroservices()
+		if err != nil {
+			return err
+		}
+		msvcs = response.Microservices
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for idx := range msvcs {
+		msvc := &msvcs[idx]
+		if util.IsSystemMsvc(msvc) || !agentUUIDs[msvc.AgentUUID] {
+			continue
+		}
+		names = append(names, msvc.Application+"/"+msvc.Name)
+	}
+	if len(names) == 0 {
+		return nil, util.NewNotFoundError(fmt.Sprintf("No Microservices running on Agents matching %s found in Namespace %s", sel, namespace))
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,178 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines printed around each change
+const DefaultContext = 3
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type op struct {
+	kind opKind
+	line string
+}
+
+// Unified returns the unified diff between from and to, or an empty string if they are identical
+func Unified(fromName, toName, from, to string, context int) string {
+	if from == to {
+		return ""
+	}
+	ops := lineOps(splitLines(from), splitLines(to))
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "--- %s\n+++ %s\n", fromName, toName)
+	for _, h := range hunks(ops, context) {
+		builder.WriteString(h)
+	}
+	return builder.String()
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// lineOps computes the edit script turning a into b from their longest common subsequence
+func lineOps(a, b []string) []op {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := []op{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{opEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{opDelete, a[i]})
+			i++
+		default:
+			ops = append(ops, op{opInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{opDelete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{opInsert, b[j]})
+	}
+	return ops
+}
+
+// hunks groups changes that are less than 2*context lines apart
+func hunks(ops []op, context int) []string {
+	result := []string{}
+	idx := 0
+	for idx < len(ops) {
+		// Find next change
+		for idx < len(ops) && ops[idx].kind == opEqual {
+			idx++
+		}
+		if idx == len(ops) {
+			break
+		}
+		start := idx - context
+		if start < 0 {
+			start = 0
+		}
+		// Extend until a run of unchanged lines is longer than both contexts
+		end := idx
+		for end < len(ops) {
+			if ops[end].kind != opEqual {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == opEqual {
+				run++
+			}
+			if run == len(ops) || run-end > 2*context {
+				break
+			}
+			end = run
+		}
+		stop := end + context
+		if stop > len(ops) {
+			stop = len(ops)
+		}
+		result = append(result, formatHunk(ops, start, stop))
+		idx = stop
+	}
+	return result
+}
+
+func formatHunk(ops []op, start, stop int) string {
+	// Line numbers of the hunk in both files
+	fromLine, toLine := 1, 1
+	for _, o := range ops[:start] {
+		if o.kind != opInsert {
+			fromLine++
+		}
+		if o.kind != opDelete {
+			toLine++
+		}
+	}
+	fromCount, toCount := 0, 0
+	var body strings.Builder
+	for _, o := range ops[start:stop] {
+		switch o.kind {
+		case opEqual:
+			fromCount++
+			toCount++
+			body.WriteString(" " + o.line + "\n")
+		case opDelete:
+			fromCount++
+			body.WriteString("-" + o.line + "\n")
+		case opInsert:
+			toCount++
+			body.WriteString("+" + o.line + "\n")
+		}
+	}
+	if fromCount == 0 {
+		fromLine--
+	}
+	if toCount == 0 {
+		toLine--
+	}
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@\n%s", fromLine, fromCount, toLine, toCount, body.String())
+}
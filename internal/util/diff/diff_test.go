@@ -0,0 +1,50 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package diff
+
+import (
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	for _, entry := range []struct {
+		from     string
+		to       string
+		expected string
+	}{
+		{
+			"a\nb\n", "a\nb\n", "",
+		},
+		{
+			"a\nb\nc\n", "a\nB\nc\n",
+			"--- live\n+++ file\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			"", "a\n",
+			"--- live\n+++ file\n@@ -0,0 +1,1 @@\n+a\n",
+		},
+		{
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", "1\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			"--- live\n+++ file\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+ten\n",
+		},
+		{
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			"--- live\n+++ file\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+ten\n",
+		},
+	} {
+		if result := Unified("live", "file", entry.from, entry.to, DefaultContext); result != entry.expected {
+			t.Errorf("Unified(%q, %q):\n%s\nexpected:\n%s", entry.from, entry.to, result, entry.expected)
+		}
+	}
+}
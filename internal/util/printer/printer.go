@@ -0,0 +1,318 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/datasance/potctl/internal/config"
+	"github.com/datasance/potctl/pkg/util"
+	"gopkg.in/yaml.v2"
+	"k8s.io/client-go/util/jsonpath"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+const (
+	FormatTable         = ""
+	FormatWide          = "wide"
+	FormatYAML          = "yaml"
+	FormatJSON          = "json"
+	FormatName          = "name"
+	FormatJSONPath      = "jsonpath"
+	FormatCustomColumns = "custom-columns"
+)
+
+const FlagDesc = "Output format. One of: yaml|json|wide|name|jsonpath=TEMPLATE|custom-columns=SPEC"
+
+const listKind = "List"
+
+// Options describes how resources are written to the terminal
+type Options struct {
+	Format   string
+	Template string
+}
+
+// Parse validates the value of an --output flag
+func Parse(output string) (*Options, error) {
+	format, template := output, ""
+	if idx := strings.Index(output, "="); idx != -1 {
+		format, template = output[:idx], output[idx+1:]
+	}
+	format = strings.ToLower(strings.TrimSpace(format))
+
+	switch format {
+	case FormatTable, FormatWide, FormatYAML, FormatJSON, FormatName:
+		if template != "" {
+			return nil, util.NewInputError(fmt.Sprintf("Output format %s does not take a template", format))
+		}
+	case FormatJSONPath, FormatCustomColumns:
+		if template == "" {
+			return nil, util.NewInputError(fmt.Sprintf("Output format %s requires a template, e.g. -o %s=...", format, format))
+		}
+	default:
+		return nil, util.NewInputError(fmt.Sprintf("Unknown output format: %s. %s", output, FlagDesc))
+	}
+
+	return &Options{
+		Format:   format,
+		Template: template,
+	}, nil
+}
+
+// IsTable returns true if the output should be a human readable table
+func (opt *Options) IsTable() bool {
+	return opt == nil || opt.Format == FormatTable || opt.Format == FormatWide
+}
+
+// IsWide returns true if tables should include every available column
+func (opt *Options) IsWide() bool {
+	return opt != nil && opt.Format == FormatWide
+}
+
+// PrintTable writes rows of columns, the first row being the column headers
+func PrintTable(writer io.Writer, table [][]string) error {
+	minWidth := 16
+	tabWidth := 8
+	padding := 1
+	tabWriter := tabwriter.NewWriter(writer, minWidth, tabWidth, padding, '\t', 0)
+	defer tabWriter.Flush()
+
+	for _, row := range table {
+		for _, col := range row {
+			if _, err := fmt.Fprintf(tabWriter, "%s\t", col); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(tabWriter, "\n"); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(tabWriter, "\n")
+	return err
+}
+
+type list struct {
+	APIVersion string          `yaml:"apiVersion" json:"apiVersion"`
+	Kind       string          `yaml:"kind" json:"kind"`
+	Items      []config.Header `yaml:"items" json:"items"`
+}
+
+// PrintHeaders writes the documents as a List in the requested format
+func (opt *Options) PrintHeaders(writer io.Writer, headers []config.Header) error {
+	if headers == nil {
+		headers = []config.Header{}
+	}
+
+	switch opt.Format {
+	case FormatName:
+		for idx := range headers {
+			if _, err := fmt.Fprintf(writer, "%s/%s\n", strings.ToLower(string(headers[idx].Kind)), headers[idx].Metadata.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatYAML:
+		// Go through yaml.v2 so resources without json tags match describe output
+		return printYAML(writer, list{APIVersion: config.LatestAPIVersion, Kind: listKind, Items: headers})
+	}
+
+	// Remaining formats operate on the JSON representation of the List
+	obj, err := headersToJSON(headers)
+	if err != nil {
+		return err
+	}
+	return opt.printJSONObject(writer, obj, "items")
+}
+
+// PrintObject writes a single object that is not a resource document, e.g. a Controller API response
+func (opt *Options) PrintObject(writer io.Writer, obj interface{}) error {
+	switch opt.Format {
+	case FormatYAML:
+		return printYAML(writer, obj)
+	case FormatTable, FormatWide:
+		return util.NewInputError("Cannot print object as a table")
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return err
+	}
+	return opt.printJSONObject(writer, generic, "")
+}
+
+// printJSONObject handles the JSON based formats. itemsKey is the key holding the rows for custom-columns and name, empty if obj is the only row
+func (opt *Options) printJSONObject(writer io.Writer, obj interface{}, itemsKey string) error {
+	rows := []interface{}{obj}
+	if itemsKey != "" {
+		if m, ok := obj.(map[string]interface{}); ok {
+			if items, ok := m[itemsKey].([]interface{}); ok {
+				rows = items
+			}
+		}
+	}
+
+	switch opt.Format {
+	case FormatJSON:
+		b, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(writer, string(b))
+		return err
+	case FormatJSONPath:
+		return printJSONPath(writer, opt.Template, obj)
+	case FormatCustomColumns:
+		return printCustomColumns(writer, opt.Template, rows)
+	case FormatName:
+		for _, row := range rows {
+			name := lookupString(row, "{.metadata.name}")
+			if name == "" {
+				name = lookupString(row, "{.name}")
+			}
+			if _, err := fmt.Fprintln(writer, name); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return util.NewInputError(fmt.Sprintf("Unknown output format: %s", opt.Format))
+	}
+}
+
+func printYAML(writer io.Writer, obj interface{}) error {
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(b)
+	return err
+}
+
+func headersToJSON(headers []config.Header) (interface{}, error) {
+	b, err := yaml.Marshal(list{APIVersion: config.LatestAPIVersion, Kind: listKind, Items: headers})
+	if err != nil {
+		return nil, err
+	}
+	b, err = k8syaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	var obj interface{}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func parseJSONPath(template string) (*jsonpath.JSONPath, error) {
+	template = strings.TrimSpace(template)
+	if !strings.HasPrefix(template, "{") {
+		template = "{" + template + "}"
+	}
+	parser := jsonpath.New("output").AllowMissingKeys(true)
+	if err := parser.Parse(template); err != nil {
+		return nil, util.NewInputError(fmt.Sprintf("Invalid jsonpath template %s: %s", template, err.Error()))
+	}
+	return parser, nil
+}
+
+func printJSONPath(writer io.Writer, template string, obj interface{}) error {
+	parser, err := parseJSONPath(template)
+	if err != nil {
+		return err
+	}
+	if err := parser.Execute(writer, obj); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(writer)
+	return err
+}
+
+type column struct {
+	header string
+	parser *jsonpath.JSONPath
+}
+
+func parseCustomColumns(spec string) ([]column, error) {
+	columns := []column{}
+	for _, part := range strings.Split(spec, ",") {
+		colon := strings.Index(part, ":")
+		if colon == -1 {
+			return nil, util.NewInputError(fmt.Sprintf("Invalid custom-columns %s, expected HEADER:JSONPATH", part))
+		}
+		parser, err := parseJSONPath(part[colon+1:])
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, column{
+			header: strings.TrimSpace(part[:colon]),
+			parser: parser,
+		})
+	}
+	return columns, nil
+}
+
+func printCustomColumns(writer io.Writer, spec string, rows []interface{}) error {
+	columns, err := parseCustomColumns(spec)
+	if err != nil {
+		return err
+	}
+
+	table := make([][]string, len(rows)+1)
+	for _, col := range columns {
+		table[0] = append(table[0], col.header)
+	}
+	for idx, row := range rows {
+		for _, col := range columns {
+			value, err := executeJSONPath(col.parser, row)
+			if err != nil {
+				return err
+			}
+			if value == "" {
+				value = "<none>"
+			}
+			table[idx+1] = append(table[idx+1], value)
+		}
+	}
+	return PrintTable(writer, table)
+}
+
+func executeJSONPath(parser *jsonpath.JSONPath, obj interface{}) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := parser.Execute(buf, obj); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func lookupString(obj interface{}, template string) string {
+	parser, err := parseJSONPath(template)
+	if err != nil {
+		return ""
+	}
+	value, err := executeJSONPath(parser, obj)
+	if err != nil {
+		return ""
+	}
+	return value
+}
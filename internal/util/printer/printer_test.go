@@ -0,0 +1,111 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/datasance/potctl/internal/config"
+)
+
+func TestParse(t *testing.T) {
+	for _, entry := range []struct {
+		input    string
+		format   string
+		template string
+		isErr    bool
+	}{
+		{"", FormatTable, "", false},
+		{"wide", FormatWide, "", false},
+		{"YAML", FormatYAML, "", false},
+		{"json", FormatJSON, "", false},
+		{"name", FormatName, "", false},
+		{"jsonpath={.items[*].metadata.name}", FormatJSONPath, "{.items[*].metadata.name}", false},
+		{"custom-columns=NAME:.metadata.name", FormatCustomColumns, "NAME:.metadata.name", false},
+		{"jsonpath", "", "", true},
+		{"yaml=foo", "", "", true},
+		{"xml", "", "", true},
+	} {
+		opt, err := Parse(entry.input)
+		if entry.isErr {
+			if err == nil {
+				t.Errorf("Expected error for %s", entry.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %s", entry.input, err.Error())
+			continue
+		}
+		if opt.Format != entry.format || opt.Template != entry.template {
+			t.Errorf("Parse(%s) = %s, %s; expected %s, %s", entry.input, opt.Format, opt.Template, entry.format, entry.template)
+		}
+	}
+}
+
+func TestPrintHeaders(t *testing.T) {
+	headers := []config.Header{
+		{
+			APIVersion: config.LatestAPIVersion,
+			Kind:       config.VolumeKind,
+			Metadata: config.HeaderMetadata{
+				Name:      "data",
+				Namespace: "default",
+			},
+			Spec: map[string]interface{}{
+				"source": "/tmp/data",
+			},
+		},
+	}
+
+	for _, entry := range []struct {
+		output   string
+		expected string
+	}{
+		{"name", "volume/data\n"},
+		{"jsonpath={.items[*].spec.source}", "/tmp/data\n"},
+		{"jsonpath=.items[0].metadata.namespace", "default\n"},
+		{"json", `"kind": "List"`},
+		{"yaml", "kind: List"},
+	} {
+		opt, err := Parse(entry.output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := new(bytes.Buffer)
+		if err := opt.PrintHeaders(buf, headers); err != nil {
+			t.Errorf("Unexpected error for %s: %s", entry.output, err.Error())
+			continue
+		}
+		if !strings.Contains(buf.String(), entry.expected) {
+			t.Errorf("Output %s: expected %q in %q", entry.output, entry.expected, buf.String())
+		}
+	}
+
+	// Custom columns print one row per item
+	opt, err := Parse("custom-columns=NAME:.metadata.name,TAGS:.metadata.tags")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if err := opt.PrintHeaders(buf, headers); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "NAME") || !strings.Contains(lines[1], "data") || !strings.Contains(lines[1], "<none>") {
+		t.Errorf("Unexpected custom-columns output %q", buf.String())
+	}
+}
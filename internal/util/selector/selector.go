@@ -0,0 +1,213 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package selector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// FlagDesc is the description of the --selector flag
+const FlagDesc = "Selector on Agent tags, supports '=', '==', '!=', 'in', 'notin', 'KEY' and '!KEY' (e.g. -l site=plant-3,tier!=edge)"
+
+type operator int
+
+const (
+	opEquals operator = iota
+	opNotEquals
+	opIn
+	opNotIn
+	opExists
+	opNotExists
+)
+
+type requirement struct {
+	key    string
+	op     operator
+	values []string
+}
+
+// Selector matches sets of tags. Tags of the form KEY=VALUE are labels, other tags are keys without a value.
+type Selector struct {
+	requirements []requirement
+}
+
+// Parse parses a comma separated list of requirements, all of which must match
+func Parse(expression string) (*Selector, error) {
+	sel := &Selector{}
+	for _, part := range split(expression) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		req, err := parseRequirement(part)
+		if err != nil {
+			return nil, err
+		}
+		sel.requirements = append(sel.requirements, req)
+	}
+	if len(sel.requirements) == 0 {
+		return nil, util.NewInputError(fmt.Sprintf("Empty selector '%s'", expression))
+	}
+	return sel, nil
+}
+
+// split splits the expression on the commas which are not within parentheses
+func split(expression string) []string {
+	parts := []string{}
+	depth, start := 0, 0
+	for idx, char := range expression {
+		switch char {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, expression[start:idx])
+				start = idx + 1
+			}
+		}
+	}
+	return append(parts, expression[start:])
+}
+
+func parseRequirement(part string) (requirement, error) {
+	invalid := util.NewInputError(fmt.Sprintf("Invalid selector requirement '%s'", part))
+	if strings.HasPrefix(part, "!") {
+		key := strings.TrimSpace(part[1:])
+		if !isValidKey(key) {
+			return requirement{}, invalid
+		}
+		return requirement{key: key, op: opNotExists}, nil
+	}
+	for _, op := range []struct {
+		token string
+		op    operator
+	}{{"!=", opNotEquals}, {"==", opEquals}, {"=", opEquals}} {
+		if idx := strings.Index(part, op.token); idx >= 0 {
+			key := strings.TrimSpace(part[:idx])
+			value := strings.TrimSpace(part[idx+len(op.token):])
+			if !isValidKey(key) || strings.ContainsAny(value, "=!(), ") {
+				return requirement{}, invalid
+			}
+			return requirement{key: key, op: op.op, values: []string{value}}, nil
+		}
+	}
+	if fields := strings.Fields(part); len(fields) >= 2 && (fields[1] == "in" || fields[1] == "notin") {
+		key := fields[0]
+		set := strings.TrimSpace(strings.Join(fields[2:], " "))
+		if !isValidKey(key) || !strings.HasPrefix(set, "(") || !strings.HasSuffix(set, ")") {
+			return requirement{}, invalid
+		}
+		values := []string{}
+		for _, value := range strings.Split(set[1:len(set)-1], ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		if len(values) == 0 {
+			return requirement{}, invalid
+		}
+		op := opIn
+		if fields[1] == "notin" {
+			op = opNotIn
+		}
+		return requirement{key: key, op: op, values: values}, nil
+	}
+	if !isValidKey(part) {
+		return requirement{}, invalid
+	}
+	return requirement{key: part, op: opExists}, nil
+}
+
+func isValidKey(key string) bool {
+	return key != "" && !strings.ContainsAny(key, "=!(), ")
+}
+
+// Matches reports whether the tags satisfy all the requirements of the selector
+func (sel *Selector) Matches(tags []string) bool {
+	labels := ParseTags(tags)
+	for _, req := range sel.requirements {
+		if !req.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesTags is Matches for the optional tags returned by the Controller
+func (sel *Selector) MatchesTags(tags *[]string) bool {
+	if tags == nil {
+		return sel.Matches(nil)
+	}
+	return sel.Matches(*tags)
+}
+
+func (req *requirement) matches(labels map[string]string) bool {
+	value, found := labels[req.key]
+	switch req.op {
+	case opExists:
+		return found
+	case opNotExists:
+		return !found
+	case opEquals, opIn:
+		return found && contains(req.values, value)
+	case opNotEquals, opNotIn:
+		return !found || !contains(req.values, value)
+	}
+	return false
+}
+
+func (sel *Selector) String() string {
+	parts := make([]string, len(sel.requirements))
+	for idx, req := range sel.requirements {
+		switch req.op {
+		case opExists:
+			parts[idx] = req.key
+		case opNotExists:
+			parts[idx] = "!" + req.key
+		case opEquals:
+			parts[idx] = req.key + "=" + req.values[0]
+		case opNotEquals:
+			parts[idx] = req.key + "!=" + req.values[0]
+		case opIn:
+			parts[idx] = fmt.Sprintf("%s in (%s)", req.key, strings.Join(req.values, ","))
+		case opNotIn:
+			parts[idx] = fmt.Sprintf("%s notin (%s)", req.key, strings.Join(req.values, ","))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// ParseTags maps tags of the form KEY=VALUE to their value, other tags map to an empty value
+func ParseTags(tags []string) map[string]string {
+	labels := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		key, value, _ := strings.Cut(tag, "=")
+		labels[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return labels
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package selector
+
+import "testing"
+
+func TestMatches(t *testing.T) {
+	tags := []string{"site=plant-3", "tier=edge", "gpu"}
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{"site=plant-3", true},
+		{"site==plant-3", true},
+		{"site=plant-4", false},
+		{"site!=plant-4", true},
+		{"site!=plant-3", false},
+		{"owner!=ops", true},
+		{"site in (plant-1, plant-3)", true},
+		{"site notin (plant-1,plant-3)", false},
+		{"owner notin (ops)", true},
+		{"gpu", true},
+		{"!gpu", false},
+		{"!owner", true},
+		{"site=plant-3,tier in (edge,core),gpu", true},
+		{"site=plant-3,tier=core", false},
+	}
+	for _, test := range tests {
+		sel, err := Parse(test.expression)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", test.expression, err)
+		}
+		if actual := sel.Matches(tags); actual != test.expected {
+			t.Errorf("Expected %s to match %v, got %v", test.expression, test.expected, actual)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, expression := range []string{"", ",", "site in plant-3", "site in ()", "=plant-3", "!", "site=a=b"} {
+		if _, err := Parse(expression); err == nil {
+			t.Errorf("Expected %q to be rejected", expression)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	expression := "site=plant-3,tier!=edge,zone in (a,b),gpu,!arm"
+	sel, err := Parse(expression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sel.String() != expression {
+		t.Errorf("Expected %s, got %s", expression, sel.String())
+	}
+}
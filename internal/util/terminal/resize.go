@@ -0,0 +1,83 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package terminal
+
+import (
+	"os"
+	"sync"
+
+	ws "github.com/datasance/potctl/internal/util/websocket"
+	"golang.org/x/term"
+)
+
+// windowSize tracks the window size forwarded to the exec session
+type windowSize struct {
+	mutex    sync.Mutex
+	rows     int // Last size sent
+	cols     int
+	wantRows int // Last size of the local terminal
+	wantCols int
+	sent     bool
+	acked    bool // The Agent acknowledged a size, so that later changes can be forwarded
+}
+
+// sendWindowSize forwards the size of the local terminal to the exec session
+func (t *Terminal) sendWindowSize() {
+	cols, rows, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || rows <= 0 || cols <= 0 {
+		return
+	}
+	t.forwardWindowSize(rows, cols)
+}
+
+// forwardWindowSize sends the window size if it changed since last sent. Only the initial size is sent until the
+// Agent acknowledges it, Agents that don't support resizing never do.
+func (t *Terminal) forwardWindowSize(rows, cols int) {
+	t.size.mutex.Lock()
+	defer t.size.mutex.Unlock()
+	t.size.wantRows = rows
+	t.size.wantCols = cols
+	if t.size.sent && !t.size.acked {
+		return
+	}
+	if rows == t.size.rows && cols == t.size.cols {
+		return
+	}
+	msg, err := ws.NewResizeMessage(uint16(rows), uint16(cols), t.wsClient.GetMicroserviceUUID(), t.wsClient.GetExecID())
+	if err != nil {
+		return
+	}
+	if err := t.wsClient.SendMessage(msg); err != nil {
+		return
+	}
+	t.size.rows = rows
+	t.size.cols = cols
+	t.size.sent = true
+}
+
+// handleControl handles the control messages of the exec session. The first acknowledged resize forwards the
+// changes of the window size that happened while waiting for it.
+func (t *Terminal) handleControl(msg *ws.Message) {
+	if !msg.IsAck(ws.ControlResize) {
+		return
+	}
+	t.size.mutex.Lock()
+	acked := t.size.acked
+	t.size.acked = true
+	rows, cols := t.size.wantRows, t.size.wantCols
+	t.size.mutex.Unlock()
+	if !acked {
+		t.forwardWindowSize(rows, cols)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package terminal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ws "github.com/datasance/potctl/internal/util/websocket"
+	"github.com/gorilla/websocket"
+)
+
+func TestForwardWindowSize(t *testing.T) {
+	received := make(chan *ws.Control, 16)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			msg, err := ws.Decode(data)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if control, ok := msg.GetControl(); ok && control.Type == ws.ControlResize {
+				received <- control
+			}
+		}
+	}))
+	defer server.Close()
+
+	wsClient := ws.NewClient("msvc-uuid")
+	if err := wsClient.Connect("ws"+strings.TrimPrefix(server.URL, "http"), http.Header{}); err != nil {
+		t.Fatal(err)
+	}
+	defer wsClient.Close()
+	term := NewTerminal(wsClient)
+	defer term.cancel()
+
+	expectSize := func(rows, cols uint16) {
+		t.Helper()
+		select {
+		case control := <-received:
+			if control.Rows != rows || control.Cols != cols {
+				t.Errorf("Expected size %dx%d, got %dx%d", rows, cols, control.Rows, control.Cols)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected size %dx%d to be forwarded", rows, cols)
+		}
+	}
+
+	// The initial size is sent, changes wait for the Agent to acknowledge it
+	term.forwardWindowSize(24, 80)
+	expectSize(24, 80)
+	term.forwardWindowSize(30, 100)
+	term.forwardWindowSize(40, 120)
+
+	// The acknowledgement forwards the latest size, later changes are forwarded as they happen
+	ack, err := ws.NewResizeMessage(24, 80, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	term.handleControl(ack)
+	expectSize(40, 120)
+	term.forwardWindowSize(40, 120)
+	term.forwardWindowSize(50, 132)
+	expectSize(50, 132)
+
+	select {
+	case control := <-received:
+		t.Errorf("Unexpected size %dx%d forwarded", control.Rows, control.Cols)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
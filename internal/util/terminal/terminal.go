@@ -37,6 +37,7 @@ type Terminal struct {
 	inputBuffer []rune
 	cursorPos   int
 	resizeCh    chan os.Signal
+	size        windowSize
 	// lastCommand   string
 	lastCtrlCTime time.Time
 	prompt        string
@@ -260,6 +261,7 @@ func (t *Terminal) Start() error {
 
 	// Set up signal handling for window resize
 	signal.Notify(t.resizeCh, unix.SIGWINCH)
+	t.sendWindowSize()
 	go t.handleResize()
 
 	// Create error channel to coordinate exit
@@ -290,6 +292,10 @@ func (t *Terminal) Start() error {
 					t.cancel()
 					return
 				}
+				if msg.IsControlMessage() {
+					t.handleControl(msg)
+					continue
+				}
 				output := string(msg.Data)
 				t.writeToStdout([]byte(output))
 			}
@@ -346,8 +352,14 @@ func (t *Terminal) Start() error {
 }
 
 func (t *Terminal) handleResize() {
-	for range t.resizeCh {
-		// Local resize only; no forwarding
+	defer signal.Stop(t.resizeCh)
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-t.resizeCh:
+			t.sendWindowSize()
+		}
 	}
 }
 
@@ -35,6 +35,7 @@ type Terminal struct {
 	inputBuffer []rune
 	cursorPos   int
 	resizeCh    chan os.Signal
+	size        windowSize
 	// lastCommand   string
 	lastCtrlCTime time.Time
 	prompt        string
@@ -45,6 +46,9 @@ type Terminal struct {
 	// isEditorMode  bool
 }
 
+// Interval at which the console size is checked for changes
+const resizePollInterval = 250 * time.Millisecond
+
 // var promptPattern = regexp.MustCompile(`(?m)^.*[@].*[$#] ?$`)
 
 func NewTerminal(wsClient *ws.Client) *Terminal {
@@ -147,9 +151,9 @@ func (t *Terminal) Start() error {
 	}
 	defer t.cleanup()
 
-	// Windows doesn't support SIGWINCH, so we skip resize handling
-	// signal.Notify(t.resizeCh, syscall.SIGWINCH)
-	// go t.handleResize()
+	// Windows doesn't support SIGWINCH, so the console size is polled instead
+	t.sendWindowSize()
+	go t.handleResize()
 
 	// Create error channel to coordinate exit
 	errCh := make(chan error, 1)
@@ -179,6 +183,10 @@ func (t *Terminal) Start() error {
 					t.cancel()
 					return
 				}
+				if msg.IsControlMessage() {
+					t.handleControl(msg)
+					continue
+				}
 				output := string(msg.Data)
 				t.writeToStdout([]byte(output))
 			}
@@ -234,12 +242,18 @@ func (t *Terminal) Start() error {
 	}
 }
 
-// func (t *Terminal) handleResize() {
-// 	// Windows doesn't support SIGWINCH, so this function is empty
-// 	for range t.resizeCh {
-// 		// No-op on Windows
-// 	}
-// }
+func (t *Terminal) handleResize() {
+	ticker := time.NewTicker(resizePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+			t.sendWindowSize()
+		}
+	}
+}
 
 func (t *Terminal) Stop() {
 	t.cancel()
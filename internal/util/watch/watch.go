@@ -0,0 +1,69 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package watch
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// DefaultInterval is the default interval at which the Controller is polled
+const DefaultInterval = 2 * time.Second
+
+// FlagDesc descriptions shared by the commands supporting --watch
+const (
+	FlagDescWatch    = "Watch for changes after printing the resources"
+	FlagDescEvents   = "Watch for changes and print one line per status transition instead of changed rows"
+	FlagDescInterval = "Interval at which the Controller is polled for changes"
+)
+
+// Options of watch mode
+type Options struct {
+	// Print status transitions instead of the changed resources
+	Events   bool
+	Interval time.Duration
+}
+
+// Poll calls poll until interrupted. An error of the first call is returned, later errors are reported and
+// polling continues, so that a Controller restarting during a rollout does not end the watch.
+func Poll(opt *Options, poll func() error) error {
+	interval := opt.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if err := poll(); err != nil {
+		return err
+	}
+	for {
+		time.Sleep(interval)
+		if err := poll(); err != nil {
+			util.PrintNotify(fmt.Sprintf("Failed to poll for changes: %s", err.Error()))
+		}
+	}
+}
+
+// FormatEvent formats a status transition of a resource as a single line
+func FormatEvent(kind, name, from, to string) string {
+	timestamp := time.Now().Format(time.RFC3339)
+	switch {
+	case from == "":
+		return fmt.Sprintf("%s\t%s %s added with status %s", timestamp, kind, name, to)
+	case to == "":
+		return fmt.Sprintf("%s\t%s %s deleted", timestamp, kind, name)
+	default:
+		return fmt.Sprintf("%s\t%s %s %s -> %s", timestamp, kind, name, from, to)
+	}
+}
@@ -34,6 +34,7 @@ type Client struct {
 	lastMessageType  int // Track the last message type
 	err              error
 	errMutex         sync.Mutex
+	writeMutex       sync.Mutex // Connections support a single concurrent writer
 	closeOnce        sync.Once
 	// Keep-alive fields
 	pingTicker   *time.Ticker
@@ -202,6 +203,8 @@ func (c *Client) SendMessage(msg *Message) error {
 		return util.NewError(fmt.Sprintf("failed to encode message: %v", err))
 	}
 
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
 	return c.conn.WriteMessage(websocket.BinaryMessage, data)
 }
 
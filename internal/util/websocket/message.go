@@ -108,3 +108,71 @@ func (m *Message) IsLogStopMessage() bool {
 func (m *Message) IsLogErrorMessage() bool {
 	return m.Type == MessageTypeLogError
 }
+
+// Control message types.
+// Control messages are relayed by the Controller to the Agent running the exec session, which may not support them.
+// potctl only relies on a control message once the Agent acknowledged it by sending back a control message of the
+// same type: stdin is not sent to a command and window sizes are not forwarded before that.
+const (
+	ControlExec       = "exec"        // Run a command instead of an interactive shell
+	ControlStdinClose = "stdin-close" // No more stdin will be sent
+	ControlResize     = "resize"      // Window size of the local terminal changed
+)
+
+// Control is the payload of control messages sent to the exec session
+type Control struct {
+	Type    string   `msgpack:"type"`
+	Command []string `msgpack:"command,omitempty"`
+	Tty     bool     `msgpack:"tty"`
+	Rows    uint16   `msgpack:"rows,omitempty"`
+	Cols    uint16   `msgpack:"cols,omitempty"`
+}
+
+// NewResizeMessage creates a control message carrying the window size of the local terminal
+func NewResizeMessage(rows, cols uint16, microserviceUUID string, execID string) (*Message, error) {
+	return NewControlMessage(&Control{Type: ControlResize, Tty: true, Rows: rows, Cols: cols}, microserviceUUID, execID)
+}
+
+// GetControl decodes the payload of a control message
+func (m *Message) GetControl() (*Control, bool) {
+	if !m.IsControlMessage() {
+		return nil, false
+	}
+	var control Control
+	if err := msgpack.Unmarshal(m.Data, &control); err != nil {
+		return nil, false
+	}
+	return &control, true
+}
+
+// IsAck checks if the message acknowledges a control message of the given type
+func (m *Message) IsAck(controlType string) bool {
+	control, ok := m.GetControl()
+	return ok && control.Type == controlType
+}
+
+// ExitStatus is the payload of the close message ending a command
+type ExitStatus struct {
+	ExitCode int `msgpack:"exitCode"`
+}
+
+// NewControlMessage creates a control message with the given payload
+func NewControlMessage(control *Control, microserviceUUID string, execID string) (*Message, error) {
+	payload, err := msgpack.Marshal(control)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode control message: %v", err)
+	}
+	return NewMessage(MessageTypeControl, payload, microserviceUUID, execID), nil
+}
+
+// GetExitStatus decodes the exit status carried by a close message, if any
+func (m *Message) GetExitStatus() (*ExitStatus, bool) {
+	if !m.IsCloseMessage() || len(m.Data) == 0 {
+		return nil, false
+	}
+	var status ExitStatus
+	if err := msgpack.Unmarshal(m.Data, &status); err != nil {
+		return nil, false
+	}
+	return &status, true
+}
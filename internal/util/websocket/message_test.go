@@ -0,0 +1,70 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package websocket
+
+import (
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestControlMessage(t *testing.T) {
+	msg, err := NewResizeMessage(40, 120, "msvc-uuid", "exec-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := msg.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := Decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	control, ok := decoded.GetControl()
+	if !ok {
+		t.Fatal("Expected control message")
+	}
+	if control.Type != ControlResize || control.Rows != 40 || control.Cols != 120 || !control.Tty {
+		t.Errorf("Unexpected control %+v", control)
+	}
+	if !decoded.IsAck(ControlResize) || decoded.IsAck(ControlExec) {
+		t.Error("Expected acknowledgement of resize only")
+	}
+
+	// Other messages are neither controls nor acknowledgements
+	stdout := NewMessage(MessageTypeStdout, data, "msvc-uuid", "exec-id")
+	if _, ok := stdout.GetControl(); ok || stdout.IsAck(ControlResize) {
+		t.Error("Unexpected control in stdout message")
+	}
+}
+
+func TestGetExitStatus(t *testing.T) {
+	payload, err := msgpack.Marshal(&ExitStatus{ExitCode: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, ok := NewMessage(MessageTypeClose, payload, "", "").GetExitStatus()
+	if !ok || status.ExitCode != 2 {
+		t.Errorf("Unexpected exit status %v", status)
+	}
+
+	// Sessions closed without status
+	if _, ok := NewMessage(MessageTypeClose, nil, "", "").GetExitStatus(); ok {
+		t.Error("Unexpected exit status of empty close message")
+	}
+	if _, ok := NewMessage(MessageTypeStdout, payload, "", "").GetExitStatus(); ok {
+		t.Error("Unexpected exit status of stdout message")
+	}
+}
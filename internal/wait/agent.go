@@ -0,0 +1,50 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package wait
+
+import (
+	"github.com/datasance/potctl/internal/describe"
+	clientutil "github.com/datasance/potctl/internal/util/client"
+)
+
+type agentChecker struct {
+	namespace string
+	name      string
+}
+
+func newAgentChecker(namespace, name string) *agentChecker {
+	return &agentChecker{
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+func (chk *agentChecker) check(condition string) (state, error) {
+	// Agents are cached for the lifetime of the command
+	clientutil.InvalidateAgentCache()
+	header, err := describe.GetHeader(&describe.Options{
+		Resource:  "agent",
+		Namespace: chk.namespace,
+		Name:      chk.name,
+	})
+	if err != nil {
+		return state{}, err
+	}
+	status, message := describe.GetStatus(header)
+	return state{
+		status:  status,
+		met:     isStatus(status, condition),
+		message: message,
+	}, nil
+}
@@ -0,0 +1,78 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package wait
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	clientutil "github.com/datasance/potctl/internal/util/client"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// applicationChecker waits for all the Microservices of an Application to reach the condition
+type applicationChecker struct {
+	namespace string
+	name      string
+}
+
+func newApplicationChecker(namespace, name string) *applicationChecker {
+	return &applicationChecker{
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+func (chk *applicationChecker) check(condition string) (state, error) {
+	var msvcs []client.MicroserviceInfo
+	err := clientutil.ExecuteWithAuthRetry(chk.namespace, func(clt *client.Client) error {
+		response, err := clt.GetMicroservicesByApplication(chk.name)
+		if err != nil {
+			return err
+		}
+		msvcs = response.Microservices
+		return nil
+	})
+	if err != nil {
+		return state{}, err
+	}
+
+	total, matching := 0, 0
+	failed := []string{}
+	for idx := range msvcs {
+		msvc := &msvcs[idx]
+		if util.IsSystemMsvc(msvc) {
+			continue
+		}
+		total++
+		switch {
+		case isStatus(msvc.Status.Status, condition):
+			matching++
+		case isAnyStatus(msvc.Status.Status, failedMicroserviceStatuses):
+			failed = append(failed, fmt.Sprintf("%s is %s", msvc.Name, msvc.Status.Status))
+		}
+	}
+
+	result := state{
+		status: fmt.Sprintf("%d/%d Microservices %s", matching, total, condition),
+		// An Application without Microservices is still being deployed
+		met: total > 0 && matching == total,
+	}
+	if !result.met && len(failed) > 0 {
+		result.failed = true
+		result.message = strings.Join(failed, ", ")
+	}
+	return result, nil
+}
@@ -0,0 +1,57 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package wait
+
+import (
+	"fmt"
+
+	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	clientutil "github.com/datasance/potctl/internal/util/client"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// certificateChecker waits for a Certificate to be issued and valid
+type certificateChecker struct {
+	namespace string
+	name      string
+}
+
+func newCertificateChecker(namespace, name string) *certificateChecker {
+	return &certificateChecker{
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+func (chk *certificateChecker) check(condition string) (state, error) {
+	if !isStatus(condition, "Ready") {
+		return state{}, util.NewInputError(fmt.Sprintf("Unknown condition %s for Certificates, only Ready is supported", condition))
+	}
+	expired := false
+	err := clientutil.ExecuteWithAuthRetry(chk.namespace, func(clt *client.Client) error {
+		certificate, err := clt.GetCertificate(chk.name)
+		if err != nil {
+			return err
+		}
+		expired = certificate.IsExpired
+		return nil
+	})
+	if err != nil {
+		return state{}, err
+	}
+	if expired {
+		return state{status: "Expired", failed: true}, nil
+	}
+	return state{status: "Ready", met: true}, nil
+}
@@ -0,0 +1,50 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package wait
+
+import (
+	"github.com/datasance/iofog-go-sdk/v3/pkg/client"
+	clientutil "github.com/datasance/potctl/internal/util/client"
+)
+
+// controlPlaneChecker waits for the Controller API of the Namespace to answer
+type controlPlaneChecker struct {
+	namespace string
+}
+
+func newControlPlaneChecker(namespace string) *controlPlaneChecker {
+	return &controlPlaneChecker{
+		namespace: namespace,
+	}
+}
+
+func (chk *controlPlaneChecker) check(condition string) (state, error) {
+	var status string
+	err := clientutil.ExecuteWithAuthRetry(chk.namespace, func(clt *client.Client) error {
+		ctrlStatus, err := clt.GetStatus()
+		if err != nil {
+			return err
+		}
+		status = ctrlStatus.Status
+		return nil
+	})
+	if err != nil {
+		return state{}, err
+	}
+	return state{
+		status: status,
+		// A Controller answering is Ready, other conditions must match the reported status
+		met: isStatus(condition, "Ready") || isStatus(status, condition),
+	}, nil
+}
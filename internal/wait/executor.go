@@ -0,0 +1,79 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package wait
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/datasance/potctl/pkg/iofog/install"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// Interval at which the resource is checked
+const pollInterval = 2 * time.Second
+
+type executor struct {
+	resource  string
+	name      string
+	condition string
+	timeout   time.Duration
+	checker   checker
+}
+
+func (exe *executor) GetName() string {
+	return exe.name
+}
+
+func (exe *executor) Execute() error {
+	description := exe.resource
+	if exe.name != "" {
+		description = fmt.Sprintf("%s %s", exe.resource, exe.name)
+	}
+	util.SpinStart(fmt.Sprintf("Waiting for %s to be %s", description, exe.condition))
+	defer util.SpinStop()
+
+	deadline := time.Now().Add(exe.timeout)
+	last := "unknown"
+	for {
+		current, err := exe.checker.check(exe.condition)
+		switch {
+		case err != nil:
+			var inputErr *util.InputError
+			if errors.As(err, &inputErr) {
+				return err
+			}
+			// The resource may not exist yet or the Controller may be restarting
+			install.Verbose(fmt.Sprintf("Failed to get status of %s: %s", description, err.Error()))
+			last = err.Error()
+		case current.met:
+			return nil
+		case current.failed:
+			msg := fmt.Sprintf("%s is %s", description, current.status)
+			if current.message != "" {
+				msg = fmt.Sprintf("%s: %s", msg, current.message)
+			}
+			return util.NewError(msg)
+		default:
+			last = current.status
+		}
+
+		if !time.Now().Add(pollInterval).Before(deadline) {
+			return util.NewError(fmt.Sprintf("Timed out after %s waiting for %s to be %s, last status: %s",
+				exe.timeout, description, exe.condition, last))
+		}
+		time.Sleep(pollInterval)
+	}
+}
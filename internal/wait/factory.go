@@ -0,0 +1,97 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package wait
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/datasance/potctl/internal/execute"
+	"github.com/datasance/potctl/pkg/util"
+)
+
+// DefaultTimeout is the default time to wait for the condition
+const DefaultTimeout = 5 * time.Minute
+
+type Options struct {
+	Resource  string
+	Name      string
+	Namespace string
+	// For is the condition to wait for, in the form condition=NAME
+	For     string
+	Timeout time.Duration
+}
+
+// state is the outcome of a single check of a resource
+type state struct {
+	// Current status of the resource, as reported in errors and progress
+	status string
+	met    bool
+	// The resource reached a state it won't recover from by itself
+	failed  bool
+	message string
+}
+
+// checker reports the state of a resource against a condition
+type checker interface {
+	check(condition string) (state, error)
+}
+
+func NewExecutor(opt *Options) (execute.Executor, error) {
+	condition, err := parseCondition(opt.For)
+	if err != nil {
+		return nil, err
+	}
+	if opt.Resource != "controlplane" && opt.Name == "" {
+		return nil, util.NewInputError(fmt.Sprintf("Must specify the name of the %s to wait for", opt.Resource))
+	}
+
+	var chk checker
+	switch opt.Resource {
+	case "agent":
+		chk = newAgentChecker(opt.Namespace, opt.Name)
+	case "microservice":
+		chk = newMicroserviceChecker(opt.Namespace, opt.Name)
+	case "application":
+		chk = newApplicationChecker(opt.Namespace, opt.Name)
+	case "controlplane":
+		chk = newControlPlaneChecker(opt.Namespace)
+	case "certificate":
+		chk = newCertificateChecker(opt.Namespace, opt.Name)
+	default:
+		return nil, util.NewInputError(fmt.Sprintf("Cannot wait for resource %s", opt.Resource))
+	}
+
+	timeout := opt.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &executor{
+		resource:  opt.Resource,
+		name:      opt.Name,
+		condition: condition,
+		timeout:   timeout,
+		checker:   chk,
+	}, nil
+}
+
+// parseCondition returns the name of the condition of a --for value of the form condition=NAME
+func parseCondition(value string) (string, error) {
+	const prefix = "condition="
+	if !strings.HasPrefix(value, prefix) || len(value) == len(prefix) {
+		return "", util.NewInputError(fmt.Sprintf("Invalid condition '%s', expected --for=condition=NAME", value))
+	}
+	return value[len(prefix):], nil
+}
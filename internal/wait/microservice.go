@@ -0,0 +1,52 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package wait
+
+import (
+	"github.com/datasance/potctl/internal/describe"
+)
+
+// Microservice statuses that are not recovered from without a redeploy
+var failedMicroserviceStatuses = []string{"FAILED", "DELETED"}
+
+type microserviceChecker struct {
+	namespace string
+	name      string
+}
+
+func newMicroserviceChecker(namespace, name string) *microserviceChecker {
+	return &microserviceChecker{
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+func (chk *microserviceChecker) check(condition string) (state, error) {
+	header, err := describe.GetHeader(&describe.Options{
+		Resource:  "microservice",
+		Namespace: chk.namespace,
+		Name:      chk.name,
+	})
+	if err != nil {
+		return state{}, err
+	}
+	status, message := describe.GetStatus(header)
+	met := isStatus(status, condition)
+	return state{
+		status:  status,
+		met:     met,
+		failed:  !met && isAnyStatus(status, failedMicroserviceStatuses),
+		message: message,
+	}, nil
+}
@@ -0,0 +1,42 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package wait
+
+import "strings"
+
+// Aliases of conditions for the statuses reported by the Controller
+var conditionAliases = map[string]string{
+	"READY": "RUNNING",
+}
+
+// isStatus reports whether the status satisfies the condition, ignoring case
+func isStatus(status, condition string) bool {
+	if status == "" {
+		return false
+	}
+	if strings.EqualFold(status, condition) {
+		return true
+	}
+	alias, found := conditionAliases[strings.ToUpper(condition)]
+	return found && strings.EqualFold(status, alias)
+}
+
+func isAnyStatus(status string, statuses []string) bool {
+	for _, candidate := range statuses {
+		if strings.EqualFold(status, candidate) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package wait
+
+import "testing"
+
+func TestParseCondition(t *testing.T) {
+	if condition, err := parseCondition("condition=Running"); err != nil || condition != "Running" {
+		t.Errorf("Expected condition Running, got %s, %v", condition, err)
+	}
+	for _, value := range []string{"", "condition=", "Running", "delete"} {
+		if _, err := parseCondition(value); err == nil {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestIsStatus(t *testing.T) {
+	tests := []struct {
+		status    string
+		condition string
+		expected  bool
+	}{
+		{"RUNNING", "Running", true},
+		{"RUNNING", "Ready", true},
+		{"PULLING", "Running", false},
+		{"STOPPED", "Stopped", true},
+		{"", "Running", false},
+	}
+	for _, test := range tests {
+		if actual := isStatus(test.status, test.condition); actual != test.expected {
+			t.Errorf("Expected isStatus(%s, %s) to be %v", test.status, test.condition, test.expected)
+		}
+	}
+}
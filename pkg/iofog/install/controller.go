@@ -47,6 +47,7 @@ type ControllerOptions struct {
 	Port                int
 	Namespace           string
 	PrivKeyFilename     string
+	SSH                 util.SSHOptions
 	Version             string
 	Image               string
 	SystemMicroservices RemoteSystemMicroservices
@@ -131,6 +132,7 @@ func NewController(options *ControllerOptions) (*Controller, error) {
 		return nil, err
 	}
 	ssh.SetPort(options.Port)
+	ssh.SetOptions(options.SSH)
 	if options.Image == "" {
 		options.Image = util.GetControllerImage()
 	}
@@ -187,6 +189,16 @@ func NewController(options *ControllerOptions) (*Controller, error) {
 	return ctrl, nil
 }
 
+// HostKeyFingerprint returns the fingerprint of the host key verified on the last connection
+func (ctrl *Controller) HostKeyFingerprint() string {
+	return ctrl.ssh.HostKeyFingerprint()
+}
+
+// HostKeyType returns the type of the host key verified on the last connection
+func (ctrl *Controller) HostKeyType() string {
+	return ctrl.ssh.HostKeyType()
+}
+
 func (ctrl *Controller) SetControllerExternalDatabase(host, user, password, provider, databaseName string, port int, ssl *bool, ca *string) {
 
 	ctrl.db = database{
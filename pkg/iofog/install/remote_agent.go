@@ -262,6 +262,20 @@ func (agent *RemoteAgent) SetAirgap(airgap bool) {
 	agent.airgap = airgap
 }
 
+func (agent *RemoteAgent) SetSSHOptions(options util.SSHOptions) {
+	agent.ssh.SetOptions(options)
+}
+
+// HostKeyFingerprint returns the fingerprint of the host key verified on the last connection
+func (agent *RemoteAgent) HostKeyFingerprint() string {
+	return agent.ssh.HostKeyFingerprint()
+}
+
+// HostKeyType returns the type of the host key verified on the last connection
+func (agent *RemoteAgent) HostKeyType() string {
+	return agent.ssh.HostKeyType()
+}
+
 // func (agent *RemoteAgent) SetRepository(repo, token string) {
 // 	if repo == "" || agent.customInstall {
 // 		return
@@ -25,6 +25,9 @@ type IofogUser struct {
 	SubscriptionKey string
 	AccessToken     string
 	RefreshToken    string
+	Login           string
+	ClientID        string
+	ClientSecret    string
 }
 
 type Auth struct {
@@ -142,6 +142,29 @@ func (err *HTTPError) Error() string {
 	return "Unexpected HTTP response\n" + err.message
 }
 
+// HostKeyError export
+type HostKeyError struct {
+	Host     string
+	Expected string
+	Actual   string
+}
+
+// NewHostKeyError export
+func NewHostKeyError(host, expected, actual string) *HostKeyError {
+	return &HostKeyError{
+		Host:     host,
+		Expected: expected,
+		Actual:   actual,
+	}
+}
+
+// Error export
+func (err *HostKeyError) Error() string {
+	return fmt.Sprintf("SSH host key verification failed\nThe host key of %s has changed. Expected %s but the host presented %s.\n"+
+		"Someone could be intercepting the connection. If the host was reinstalled, trust the new key with `potctl configure agent|controller NAME --accept-host-key`",
+		err.Host, err.Expected, err.Actual)
+}
+
 type UnmarshalError struct {
 	message string
 }
@@ -0,0 +1,87 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LockTimeout is how long LockFile waits for another process to release a lock
+var LockTimeout = 30 * time.Second
+
+const lockRetryInterval = 50 * time.Millisecond
+
+// LockFile takes an advisory lock on a lock file, created if missing, and returns the function releasing it.
+// Locks are held by processes, so goroutines of a single process must synchronize by other means.
+func LockFile(filename string) (unlock func(), err error) {
+	file, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(LockTimeout)
+	for {
+		locked, err := tryLock(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			file.Close()
+			return nil, NewError(fmt.Sprintf("Timed out waiting for another potctl process to release %s", filename))
+		}
+		time.Sleep(lockRetryInterval)
+	}
+	return func() {
+		_ = unlockFile(file)
+		file.Close()
+	}, nil
+}
+
+// WriteFileAtomic replaces a file with its new content, so that readers never see a partial file even if
+// potctl is interrupted. The content is synced to disk before replacing the file.
+func WriteFileAtomic(filename string, data []byte, perm os.FileMode) (err error) {
+	dir := filepath.Dir(filename)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), filename); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
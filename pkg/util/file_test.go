@@ -0,0 +1,66 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(filename, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFileAtomic(filename, []byte("new"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(filename)
+	if err != nil || string(content) != "new" {
+		t.Errorf("Unexpected content %s: %v", content, err)
+	}
+	info, err := os.Stat(filename)
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Unexpected mode %v: %v", info.Mode(), err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Errorf("Temporary files were left in %s: %v", dir, entries)
+	}
+}
+
+func TestLockFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "default.lock")
+	unlock, err := LockFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeout := LockTimeout
+	LockTimeout = 100 * time.Millisecond
+	defer func() { LockTimeout = timeout }()
+	if _, err := LockFile(filename); err == nil {
+		t.Fatal("Locked a file that is already locked")
+	}
+
+	unlock()
+	unlockAgain, err := LockFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to lock a released file: %v", err)
+	}
+	unlockAgain()
+}
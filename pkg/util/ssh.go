@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -35,12 +36,31 @@ const (
 )
 
 type SecureShellClient struct {
-	user            string
-	host            string
-	port            int
-	privKeyFilename string
-	config          *ssh.ClientConfig
-	conn            *ssh.Client
+	user               string
+	host               string
+	port               int
+	privKeyFilename    string
+	options            SSHOptions
+	hostKeyFingerprint string // Fingerprint of the key presented by the host on the last connection
+	hostKeyType        string // Type of the key presented by the host on the last connection
+	config             *ssh.ClientConfig
+	conn               *ssh.Client
+	agentConn          net.Conn             // Connection to the SSH agent, if used
+	jumpClients        []*SecureShellClient // Connected jump hosts, in order
+}
+
+// SSHOptions holds the optional settings of a SecureShellClient
+type SSHOptions struct {
+	// SHA256 fingerprint the host key must match. If empty, the host key is verified against known_hosts
+	HostKeyFingerprint string
+	// Type of the pinned host key, the host is asked to present a key of this type
+	HostKeyType string
+	// Authenticate with the keys of the SSH agent listening on SSH_AUTH_SOCK
+	UseAgent bool
+	// OpenSSH certificate of the private key. Defaults to <key file>-cert.pub if it exists
+	CertFile string
+	// Jump hosts the connection is tunnelled through, in order
+	ProxyJump []SSHJumpHost
 }
 
 func NewSecureShellClient(user, host, privKeyFilename string) (*SecureShellClient, error) {
@@ -50,25 +70,6 @@ func NewSecureShellClient(user, host, privKeyFilename string) (*SecureShellClien
 		port:            22,
 		privKeyFilename: privKeyFilename,
 	}
-	// Parse keys
-	SSHVerbose("Parsing keys")
-	key, err := cl.getPublicKey()
-	if err != nil {
-		return nil, err
-	}
-
-	// Instantiate config
-	SSHVerbose("Configuring SSH client")
-	cl.config = &ssh.ClientConfig{
-		User: cl.user,
-		Auth: []ssh.AuthMethod{
-			key,
-		},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-	}
-	SSHVerbose("Config:")
-	SSHVerbose(fmt.Sprintf("User: %s", cl.user))
-	SSHVerbose(fmt.Sprintf("Auth method: %v", key))
 	return cl, nil
 }
 
@@ -77,6 +78,20 @@ func (cl *SecureShellClient) SetPort(port int) {
 	cl.port = port
 }
 
+func (cl *SecureShellClient) SetOptions(options SSHOptions) {
+	cl.options = options
+}
+
+// HostKeyFingerprint returns the SHA256 fingerprint of the key presented by the host, empty until connected
+func (cl *SecureShellClient) HostKeyFingerprint() string {
+	return cl.hostKeyFingerprint
+}
+
+// HostKeyType returns the type of the key presented by the host, empty until connected
+func (cl *SecureShellClient) HostKeyType() string {
+	return cl.hostKeyType
+}
+
 func (cl *SecureShellClient) Connect() (err error) {
 	// Don't bother connecting twice
 	SSHVerbose("Initialiasing connection")
@@ -84,19 +99,48 @@ func (cl *SecureShellClient) Connect() (err error) {
 		return nil
 	}
 
+	// Instantiate config, credentials depend on the options so they are only loaded now
+	SSHVerbose("Configuring SSH client")
+	if cl.config, err = cl.getConfig(); err != nil {
+		return err
+	}
+	SSHVerbose("Config:")
+	SSHVerbose(fmt.Sprintf("User: %s", cl.user))
+
 	// Connect
-	endpoint := cl.host + ":" + strconv.Itoa(cl.port)
-	SSHVerbose(fmt.Sprintf("TCP dialing %s", endpoint))
-	cl.conn, err = ssh.Dial("tcp", endpoint, cl.config)
+	cl.conn, err = cl.dial(cl.config)
 	if err != nil {
+		cl.closeJumpHosts()
+		cl.closeAgent()
 		return err
 	}
 
 	return nil
 }
 
+func (cl *SecureShellClient) getConfig() (*ssh.ClientConfig, error) {
+	auth, err := cl.getAuthMethods()
+	if err != nil {
+		return nil, err
+	}
+	config := &ssh.ClientConfig{
+		User:            cl.user,
+		Auth:            auth,
+		HostKeyCallback: cl.verifyHostKey,
+	}
+	// Hosts have a key of each type, ask for the one that can be verified
+	if cl.options.HostKeyFingerprint != "" && cl.options.HostKeyType != "" {
+		config.HostKeyAlgorithms = hostKeyAlgorithms(cl.options.HostKeyType)
+	} else {
+		config.HostKeyAlgorithms = knownHostKeyAlgorithms(net.JoinHostPort(cl.host, strconv.Itoa(cl.port)))
+	}
+	return config, nil
+}
+
 func (cl *SecureShellClient) Disconnect() error {
 	SSHVerbose("Disconnecting...")
+	defer cl.closeAgent()
+	defer cl.closeJumpHosts()
 	if cl.conn == nil {
 		return nil
 	}
@@ -153,28 +197,6 @@ func format(err error, stdout, stderr fmt.Stringer) error {
 	return errors.New(msg)
 }
 
-func (cl *SecureShellClient) getPublicKey() (authMeth ssh.AuthMethod, err error) {
-	// Read priv key file, MUST BE RSA
-	SSHVerbose(fmt.Sprintf("Reading private key: %s", cl.privKeyFilename))
-	key, err := os.ReadFile(cl.privKeyFilename)
-	if err != nil {
-		return
-	}
-
-	// Parse key
-	SSHVerbose("Parsing key")
-	signer, err := ssh.ParsePrivateKey(key)
-	if err != nil {
-		return
-	}
-
-	// Return pubkey obj
-	SSHVerbose("Creating auth method based on key pair")
-	authMeth = ssh.PublicKeys(signer)
-
-	return
-}
-
 func (cl *SecureShellClient) RunUntil(condition *regexp.Regexp, cmd string, ignoredErrors []string) (err error) {
 	// Retry until string condition matches
 	for iter := 0; iter < 30; iter++ {
@@ -0,0 +1,189 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/term"
+)
+
+// SSHPassphraseEnv is the environment variable holding the passphrase of encrypted private keys
+const SSHPassphraseEnv = "POTCTL_SSH_PASSPHRASE"
+
+var (
+	passphraseMutex sync.Mutex
+	passphrases     = make(map[string][]byte) // Passphrases entered interactively, by key file
+)
+
+// getAuthMethods returns a single public key method so that the private key, its certificate and the agent keys are all offered.
+// The SSH client only tries each method type once.
+func (cl *SecureShellClient) getAuthMethods() ([]ssh.AuthMethod, error) {
+	signers := []ssh.Signer{}
+	if cl.privKeyFilename != "" {
+		keySigners, err := getKeySigners(cl.privKeyFilename, cl.options.CertFile)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, keySigners...)
+	} else if cl.options.CertFile != "" {
+		return nil, NewInputError(fmt.Sprintf("Certificate %s requires the private key it was issued for", cl.options.CertFile))
+	}
+
+	var agentClient agent.ExtendedAgent
+	if cl.options.UseAgent {
+		socket := os.Getenv("SSH_AUTH_SOCK")
+		if socket == "" {
+			return nil, NewInputError("Cannot use the SSH agent, SSH_AUTH_SOCK is not set")
+		}
+		SSHVerbose(fmt.Sprintf("Connecting to SSH agent %s", socket))
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			return nil, err
+		}
+		cl.agentConn = conn
+		agentClient = agent.NewClient(conn)
+	}
+
+	if len(signers) == 0 && agentClient == nil {
+		return nil, NewInputError("No SSH credentials specified. Provide a private key or enable the SSH agent")
+	}
+
+	return []ssh.AuthMethod{
+		ssh.PublicKeysCallback(func() ([]ssh.Signer, error) {
+			if agentClient == nil {
+				return signers, nil
+			}
+			agentSigners, err := agentClient.Signers()
+			if err != nil {
+				return nil, err
+			}
+			SSHVerbose(fmt.Sprintf("SSH agent offers %d keys", len(agentSigners)))
+			return append(append([]ssh.Signer{}, signers...), agentSigners...), nil
+		}),
+	}, nil
+}
+
+func (cl *SecureShellClient) closeAgent() {
+	if cl.agentConn == nil {
+		return
+	}
+	cl.agentConn.Close()
+	cl.agentConn = nil
+}
+
+// getKeySigners parses the private key and, if there is one, the OpenSSH certificate issued for it
+func getKeySigners(keyFilename, certFilename string) ([]ssh.Signer, error) {
+	SSHVerbose(fmt.Sprintf("Reading private key: %s", keyFilename))
+	key, err := os.ReadFile(keyFilename)
+	if err != nil {
+		return nil, err
+	}
+	SSHVerbose("Parsing key")
+	signer, err := parsePrivateKey(keyFilename, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Like OpenSSH, pick up the certificate next to the key
+	if certFilename == "" {
+		if _, err := os.Stat(keyFilename + "-cert.pub"); err == nil {
+			certFilename = keyFilename + "-cert.pub"
+		}
+	}
+	if certFilename == "" {
+		return []ssh.Signer{signer}, nil
+	}
+
+	SSHVerbose(fmt.Sprintf("Reading certificate: %s", certFilename))
+	certBytes, err := os.ReadFile(certFilename)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey(certBytes)
+	if err != nil {
+		return nil, err
+	}
+	cert, ok := pubKey.(*ssh.Certificate)
+	if !ok {
+		return nil, NewInputError(fmt.Sprintf("%s is not an OpenSSH certificate", certFilename))
+	}
+	certSigner, err := ssh.NewCertSigner(cert, signer)
+	if err != nil {
+		return nil, NewInputError(fmt.Sprintf("Certificate %s does not match private key %s: %s", certFilename, keyFilename, err.Error()))
+	}
+	// Offer the certificate first, the plain key is kept for hosts which do not trust the CA
+	return []ssh.Signer{certSigner, signer}, nil
+}
+
+func parsePrivateKey(filename string, key []byte) (ssh.Signer, error) {
+	signer, err := ssh.ParsePrivateKey(key)
+	var missingErr *ssh.PassphraseMissingError
+	if !errors.As(err, &missingErr) {
+		return signer, err
+	}
+
+	passphrase, err := getPassphrase(filename)
+	if err != nil {
+		return nil, err
+	}
+	signer, err = ssh.ParsePrivateKeyWithPassphrase(key, passphrase)
+	if errors.Is(err, x509.IncorrectPasswordError) {
+		passphraseMutex.Lock()
+		delete(passphrases, filename)
+		passphraseMutex.Unlock()
+		return nil, NewInputError(fmt.Sprintf("Incorrect passphrase for private key %s", filename))
+	}
+	return signer, err
+}
+
+// getPassphrase reads the passphrase from the environment or prompts for it once per key file
+func getPassphrase(filename string) ([]byte, error) {
+	if passphrase := os.Getenv(SSHPassphraseEnv); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+
+	// Serialize prompts of concurrent connections
+	passphraseMutex.Lock()
+	defer passphraseMutex.Unlock()
+	if passphrase, exists := passphrases[filename]; exists {
+		return passphrase, nil
+	}
+
+	stdin := int(os.Stdin.Fd())
+	if !term.IsTerminal(stdin) {
+		return nil, NewInputError(fmt.Sprintf("Private key %s is encrypted. Set %s or run potctl from a terminal to enter the passphrase", filename, SSHPassphraseEnv))
+	}
+	wasRunning := SpinPause()
+	defer func() {
+		if wasRunning {
+			SpinUnpause()
+		}
+	}()
+	fmt.Fprintf(os.Stderr, "Enter passphrase for key %s: ", filename)
+	passphrase, err := term.ReadPassword(stdin)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	passphrases[filename] = passphrase
+	return passphrase, nil
+}
@@ -0,0 +1,100 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestGetKeySigners(t *testing.T) {
+	dir := t.TempDir()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(priv, "", []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(dir, "id_ed25519")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Encrypted key with passphrase from the environment
+	t.Setenv(SSHPassphraseEnv, "wrong")
+	if _, err := getKeySigners(keyFile, ""); err == nil {
+		t.Error("Expected error for incorrect passphrase")
+	}
+	t.Setenv(SSHPassphraseEnv, "secret")
+	signers, err := getKeySigners(keyFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signers) != 1 {
+		t.Fatalf("Expected 1 signer, got %d", len(signers))
+	}
+
+	// Certificate next to the key is picked up
+	caPub, caPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caSigner, err := ssh.NewSignerFromKey(caPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := &ssh.Certificate{
+		Key:             signers[0].PublicKey(),
+		CertType:        ssh.UserCert,
+		ValidPrincipals: []string{"iofog"},
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	if err := cert.SignCert(rand.Reader, caSigner); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile+"-cert.pub", ssh.MarshalAuthorizedKey(cert), 0644); err != nil {
+		t.Fatal(err)
+	}
+	signers, err = getKeySigners(keyFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signers) != 2 {
+		t.Fatalf("Expected certificate and key signers, got %d", len(signers))
+	}
+	if _, ok := signers[0].PublicKey().(*ssh.Certificate); !ok {
+		t.Error("Expected certificate to be offered first")
+	}
+
+	// A plain public key is not a certificate
+	caPublicKey, err := ssh.NewPublicKey(caPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notCert := filepath.Join(dir, "ca.pub")
+	if err := os.WriteFile(notCert, ssh.MarshalAuthorizedKey(caPublicKey), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getKeySigners(keyFile, notCert); err == nil {
+		t.Error("Expected error for public key used as certificate")
+	}
+}
@@ -0,0 +1,227 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// File in which potctl records the host keys it trusted on first use
+var knownHostsFile string
+
+// Serializes the updates of the known_hosts file by goroutines, the file lock only excludes other processes
+var knownHostsMutex sync.Mutex
+
+// SetKnownHostsFile sets the known_hosts file managed by potctl
+func SetKnownHostsFile(filename string) {
+	knownHostsFile = filename
+}
+
+var errHostKeyRetrieved = errors.New("host key retrieved")
+
+// verifyHostKey checks the host key against the pinned fingerprint if there is one, otherwise against
+// ~/.ssh/known_hosts and the potctl known_hosts file. Unknown hosts are trusted on first use.
+func (cl *SecureShellClient) verifyHostKey(hostname string, remote net.Addr, key ssh.PublicKey) error {
+	fingerprint := ssh.FingerprintSHA256(key)
+	cl.hostKeyFingerprint = fingerprint
+	cl.hostKeyType = key.Type()
+	SSHVerbose(fmt.Sprintf("Host key fingerprint: %s", fingerprint))
+
+	if cl.options.HostKeyFingerprint != "" {
+		if cl.options.HostKeyFingerprint != fingerprint {
+			return NewHostKeyError(hostname, cl.options.HostKeyFingerprint, fingerprint)
+		}
+		return nil
+	}
+
+	if files := existingKnownHostsFiles(); len(files) > 0 {
+		callback, err := knownhosts.New(files...)
+		if err != nil {
+			return err
+		}
+		err = callback(hostname, remote, key)
+		if err == nil {
+			return nil
+		}
+		var keyErr *knownhosts.KeyError
+		if !errors.As(err, &keyErr) {
+			return err
+		}
+		// Only a known key of the same type proves the key changed, hosts have a key of each type
+		for _, want := range keyErr.Want {
+			if want.Key.Type() == key.Type() {
+				return NewHostKeyError(hostname, ssh.FingerprintSHA256(want.Key), fingerprint)
+			}
+		}
+	}
+
+	// Trust on first use
+	PrintNotify(fmt.Sprintf("Permanently added host key %s of %s to the list of known hosts", fingerprint, hostname))
+	return recordHostKey(hostname, key, false)
+}
+
+// knownHostKeyAlgorithms returns the algorithms of the keys known for the host, so that the host presents one of them
+// rather than a key of another type. Nil if the host is unknown.
+func knownHostKeyAlgorithms(hostname string) (algorithms []string) {
+	files := existingKnownHostsFiles()
+	if len(files) == 0 {
+		return nil
+	}
+	callback, err := knownhosts.New(files...)
+	if err != nil {
+		return nil
+	}
+	// The probe key matches no entry, so the error lists every known key of the host
+	var keyErr *knownhosts.KeyError
+	if err := callback(hostname, &net.TCPAddr{IP: net.IPv4zero}, probeKey{}); !errors.As(err, &keyErr) {
+		return nil
+	}
+	seen := map[string]bool{}
+	for _, want := range keyErr.Want {
+		for _, algorithm := range hostKeyAlgorithms(want.Key.Type()) {
+			if !seen[algorithm] {
+				seen[algorithm] = true
+				algorithms = append(algorithms, algorithm)
+			}
+		}
+	}
+	return algorithms
+}
+
+// hostKeyAlgorithms returns the algorithms a host can sign with using a key of the given type
+func hostKeyAlgorithms(keyType string) []string {
+	if keyType == ssh.KeyAlgoRSA {
+		return []string{ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA}
+	}
+	return []string{keyType}
+}
+
+// probeKey is a public key no host presents
+type probeKey struct{}
+
+func (probeKey) Type() string {
+	return "potctl-probe"
+}
+
+func (probeKey) Marshal() []byte {
+	return []byte("potctl-probe")
+}
+
+func (probeKey) Verify(data []byte, sig *ssh.Signature) error {
+	return errors.New("probe key cannot verify signatures")
+}
+
+// AcceptHostKey retrieves the key currently presented by the host without authenticating, records it
+// in the potctl known_hosts file and returns its fingerprint. Jump hosts are verified as usual.
+func (cl *SecureShellClient) AcceptHostKey() (string, error) {
+	var hostKey ssh.PublicKey
+	config := &ssh.ClientConfig{
+		User: cl.user,
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			hostKey = key
+			// Abort the handshake, the key is all we need
+			return errHostKeyRetrieved
+		},
+	}
+	endpoint := net.JoinHostPort(cl.host, strconv.Itoa(cl.port))
+	SSHVerbose(fmt.Sprintf("Retrieving host key of %s", endpoint))
+	conn, err := cl.dial(config)
+	if err == nil {
+		conn.Close()
+	}
+	cl.closeJumpHosts()
+	if hostKey == nil {
+		return "", err
+	}
+	if err := recordHostKey(endpoint, hostKey, true); err != nil {
+		return "", err
+	}
+	cl.hostKeyFingerprint = ssh.FingerprintSHA256(hostKey)
+	cl.hostKeyType = hostKey.Type()
+	return cl.hostKeyFingerprint, nil
+}
+
+func existingKnownHostsFiles() (files []string) {
+	candidates := []string{knownHostsFile}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(homeDir, ".ssh", "known_hosts"))
+	}
+	for _, file := range candidates {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err == nil {
+			files = append(files, file)
+		}
+	}
+	return
+}
+
+// recordHostKey replaces the entries of the host in the potctl known_hosts file with key. Unless replaceAll
+// is set, only the entries of the same key type are replaced.
+func recordHostKey(hostname string, key ssh.PublicKey, replaceAll bool) error {
+	if knownHostsFile == "" {
+		return nil
+	}
+	address := knownhosts.Normalize(hostname)
+
+	if err := os.MkdirAll(filepath.Dir(knownHostsFile), 0755); err != nil {
+		return err
+	}
+	// Concurrent deploys trust hosts at the same time
+	knownHostsMutex.Lock()
+	defer knownHostsMutex.Unlock()
+	unlock, err := LockFile(knownHostsFile + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	content, err := os.ReadFile(knownHostsFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	lines := []string{}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if hostsContain(fields[0], address) && (replaceAll || len(fields) < 2 || fields[1] == key.Type()) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, knownhosts.Line([]string{address}, key))
+	return WriteFileAtomic(knownHostsFile, []byte(strings.Join(lines, "\n")+"\n"), 0600)
+}
+
+func hostsContain(hosts, address string) bool {
+	for _, host := range strings.Split(hosts, ",") {
+		if host == address {
+			return true
+		}
+	}
+	return false
+}
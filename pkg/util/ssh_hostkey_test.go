@@ -0,0 +1,199 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func newTestHostKey(t *testing.T) ssh.PublicKey {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestVerifyHostKey(t *testing.T) {
+	dir := t.TempDir()
+	// Keep the known_hosts of the user running the tests out of the way
+	t.Setenv("HOME", dir)
+	SetKnownHostsFile(filepath.Join(dir, "known_hosts"))
+	defer SetKnownHostsFile("")
+
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 2222}
+	hostname := "edge:2222"
+	key := newTestHostKey(t)
+	otherKey := newTestHostKey(t)
+
+	// Unknown host is trusted on first use and recorded
+	cl := &SecureShellClient{}
+	if err := cl.verifyHostKey(hostname, remote, key); err != nil {
+		t.Fatalf("Unexpected error on first use: %s", err.Error())
+	}
+	if cl.HostKeyFingerprint() != ssh.FingerprintSHA256(key) {
+		t.Errorf("Unexpected fingerprint %s", cl.HostKeyFingerprint())
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "known_hosts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(content), "[edge]:2222 ") {
+		t.Errorf("Unexpected known_hosts content %q", string(content))
+	}
+
+	// Known host is verified
+	cl = &SecureShellClient{}
+	if err := cl.verifyHostKey(hostname, remote, key); err != nil {
+		t.Errorf("Unexpected error for known host: %s", err.Error())
+	}
+	var hostKeyErr *HostKeyError
+	if err := cl.verifyHostKey(hostname, remote, otherKey); !errors.As(err, &hostKeyErr) {
+		t.Errorf("Expected host key error for changed key, got %v", err)
+	}
+
+	// Pinned fingerprint takes precedence over known_hosts
+	cl = &SecureShellClient{options: SSHOptions{HostKeyFingerprint: ssh.FingerprintSHA256(otherKey)}}
+	if err := cl.verifyHostKey(hostname, remote, otherKey); err != nil {
+		t.Errorf("Unexpected error for pinned key: %s", err.Error())
+	}
+	if err := cl.verifyHostKey(hostname, remote, key); !errors.As(err, &hostKeyErr) {
+		t.Errorf("Expected host key error for unpinned key, got %v", err)
+	}
+
+	// Recording a new key replaces the entry of the host
+	if err := recordHostKey(hostname, otherKey, false); err != nil {
+		t.Fatal(err)
+	}
+	content, err = os.ReadFile(filepath.Join(dir, "known_hosts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(content)), "\n"); len(lines) != 1 {
+		t.Errorf("Expected a single known_hosts entry, got %q", string(content))
+	}
+	cl = &SecureShellClient{}
+	if err := cl.verifyHostKey(hostname, remote, otherKey); err != nil {
+		t.Errorf("Unexpected error for replaced key: %s", err.Error())
+	}
+}
+
+func TestVerifyHostKeyOfOtherType(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	SetKnownHostsFile(filepath.Join(dir, "known_hosts"))
+	defer SetKnownHostsFile("")
+
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}
+	hostname := "edge:22"
+	key := newTestHostKey(t)
+	ecdsaKey := newTestECDSAHostKey(t)
+
+	if algorithms := knownHostKeyAlgorithms(hostname); algorithms != nil {
+		t.Errorf("Expected no algorithms for unknown host, got %v", algorithms)
+	}
+	if err := recordHostKey(hostname, key, false); err != nil {
+		t.Fatal(err)
+	}
+	if algorithms := knownHostKeyAlgorithms(hostname); len(algorithms) != 1 || algorithms[0] != ssh.KeyAlgoED25519 {
+		t.Errorf("Unexpected algorithms %v", algorithms)
+	}
+
+	// A key of another type is not a changed key, it is trusted on first use alongside the known one
+	cl := &SecureShellClient{}
+	if err := cl.verifyHostKey(hostname, remote, ecdsaKey); err != nil {
+		t.Errorf("Unexpected error for key of other type: %s", err.Error())
+	}
+	if err := cl.verifyHostKey(hostname, remote, key); err != nil {
+		t.Errorf("Unexpected error for known key: %s", err.Error())
+	}
+	if algorithms := knownHostKeyAlgorithms(hostname); len(algorithms) != 2 {
+		t.Errorf("Expected algorithms of both keys, got %v", algorithms)
+	}
+
+	// Accepting a host key replaces the keys of every type
+	if err := recordHostKey(hostname, ecdsaKey, true); err != nil {
+		t.Fatal(err)
+	}
+	var hostKeyErr *HostKeyError
+	if err := cl.verifyHostKey(hostname, remote, newTestHostKey(t)); err != nil {
+		t.Errorf("Unexpected error for new ed25519 key after replacing: %s", err.Error())
+	}
+	if err := cl.verifyHostKey(hostname, remote, newTestECDSAHostKey(t)); !errors.As(err, &hostKeyErr) {
+		t.Errorf("Expected host key error for changed ecdsa key, got %v", err)
+	}
+}
+
+func newTestECDSAHostKey(t *testing.T) ssh.PublicKey {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.NewPublicKey(&private.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestConnectPinnedKeyOfMultiKeyHost(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	SetKnownHostsFile(filepath.Join(dir, "known_hosts"))
+	defer SetKnownHostsFile("")
+
+	// Host with an ECDSA and an ed25519 key, clients prefer ECDSA by default
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaSigner, err := ssh.NewSignerFromKey(ecdsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519Signer := newTestSigner(t)
+	addr := newTestSSHServer(t, false, ecdsaSigner, ed25519Signer)
+
+	cl, err := NewSecureShellClient("potctl", addr.IP.String(), newTestKeyFile(t, dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl.SetPort(addr.Port)
+	cl.SetOptions(SSHOptions{
+		HostKeyFingerprint: ssh.FingerprintSHA256(ed25519Signer.PublicKey()),
+		HostKeyType:        ssh.KeyAlgoED25519,
+	})
+	if err := cl.Connect(); err != nil {
+		t.Fatalf("Unexpected error connecting with pinned ed25519 key: %s", err.Error())
+	}
+	defer cl.Disconnect()
+	if cl.HostKeyType() != ssh.KeyAlgoED25519 {
+		t.Errorf("Unexpected host key type %s", cl.HostKeyType())
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHJumpHost is a bastion through which SSH connections are tunnelled
+type SSHJumpHost struct {
+	Host     string
+	User     string
+	Port     int
+	KeyFile  string
+	CertFile string
+	UseAgent bool
+}
+
+// dial connects to the host, through each jump host if any
+func (cl *SecureShellClient) dial(config *ssh.ClientConfig) (*ssh.Client, error) {
+	endpoint := net.JoinHostPort(cl.host, strconv.Itoa(cl.port))
+	if len(cl.options.ProxyJump) == 0 {
+		SSHVerbose(fmt.Sprintf("TCP dialing %s", endpoint))
+		return ssh.Dial("tcp", endpoint, config)
+	}
+
+	var jump *ssh.Client
+	for idx := range cl.options.ProxyJump {
+		hop := &cl.options.ProxyJump[idx]
+		jumpClient := &SecureShellClient{
+			user:            hop.User,
+			host:            hop.Host,
+			port:            hop.Port,
+			privKeyFilename: hop.KeyFile,
+			options: SSHOptions{
+				UseAgent: hop.UseAgent,
+				CertFile: hop.CertFile,
+			},
+		}
+		if jumpClient.port == 0 {
+			jumpClient.port = 22
+		}
+		// Track the client first so that its agent connection is closed on failure
+		cl.jumpClients = append(cl.jumpClients, jumpClient)
+		jumpConfig, err := jumpClient.getConfig()
+		if err != nil {
+			return nil, err
+		}
+		jumpEndpoint := net.JoinHostPort(jumpClient.host, strconv.Itoa(jumpClient.port))
+		if jump == nil {
+			SSHVerbose(fmt.Sprintf("TCP dialing jump host %s", jumpEndpoint))
+			jumpClient.conn, err = ssh.Dial("tcp", jumpEndpoint, jumpConfig)
+		} else {
+			SSHVerbose(fmt.Sprintf("Dialing jump host %s through previous jump host", jumpEndpoint))
+			jumpClient.conn, err = dialThrough(jump, jumpEndpoint, jumpConfig)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to jump host %s: %w", jumpEndpoint, err)
+		}
+		jump = jumpClient.conn
+	}
+
+	SSHVerbose(fmt.Sprintf("Dialing %s through jump host", endpoint))
+	return dialThrough(jump, endpoint, config)
+}
+
+// dialThrough opens an SSH connection to endpoint tunnelled through an existing connection
+func dialThrough(jump *ssh.Client, endpoint string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	conn, err := jump.Dial("tcp", endpoint)
+	if err != nil {
+		return nil, err
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, endpoint, config)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+// closeJumpHosts closes the jump host connections, last hop first
+func (cl *SecureShellClient) closeJumpHosts() {
+	for idx := len(cl.jumpClients) - 1; idx >= 0; idx-- {
+		if err := cl.jumpClients[idx].Disconnect(); err != nil {
+			SSHVerbose(fmt.Sprintf("Failed to close jump host connection: %s", err.Error()))
+		}
+	}
+	cl.jumpClients = nil
+}
@@ -0,0 +1,175 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2023 Datasance Teknoloji A.S.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func newTestSigner(t *testing.T) ssh.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+// newTestKeyFile writes a client private key to dir and returns its file name
+func newTestKeyFile(t *testing.T, dir string) string {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(dir, "id_ed25519")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return keyFile
+}
+
+// newTestSSHServer serves SSH with the host keys, accepting any client key. Servers that forward act as jump hosts.
+func newTestSSHServer(t *testing.T, forward bool, hostKeys ...ssh.Signer) *net.TCPAddr {
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(ssh.ConnMetadata, ssh.PublicKey) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+	}
+	for _, hostKey := range hostKeys {
+		config.AddHostKey(hostKey)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSSH(conn, config, forward)
+		}
+	}()
+	return listener.Addr().(*net.TCPAddr)
+}
+
+func serveTestSSH(conn net.Conn, config *ssh.ServerConfig, forward bool) {
+	defer conn.Close()
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChan := range chans {
+		if !forward || newChan.ChannelType() != "direct-tcpip" {
+			_ = newChan.Reject(ssh.Prohibited, "unsupported channel")
+			continue
+		}
+		var target struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if err := ssh.Unmarshal(newChan.ExtraData(), &target); err != nil {
+			_ = newChan.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		targetConn, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+		if err != nil {
+			_ = newChan.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, channelReqs, err := newChan.Accept()
+		if err != nil {
+			targetConn.Close()
+			continue
+		}
+		go ssh.DiscardRequests(channelReqs)
+		go func() {
+			defer channel.Close()
+			defer targetConn.Close()
+			go func() {
+				_, _ = io.Copy(targetConn, channel)
+			}()
+			_, _ = io.Copy(channel, targetConn)
+		}()
+	}
+}
+
+func TestConnectThroughJumpHosts(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	SetKnownHostsFile(filepath.Join(dir, "known_hosts"))
+	defer SetKnownHostsFile("")
+
+	keyFile := newTestKeyFile(t, dir)
+	target := newTestSSHServer(t, false, newTestSigner(t))
+	inner := newTestSSHServer(t, true, newTestSigner(t))
+	outer := newTestSSHServer(t, true, newTestSigner(t))
+
+	cl, err := NewSecureShellClient("potctl", target.IP.String(), keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl.SetPort(target.Port)
+	cl.SetOptions(SSHOptions{
+		ProxyJump: []SSHJumpHost{
+			{Host: outer.IP.String(), Port: outer.Port, User: "bastion", KeyFile: keyFile},
+			{Host: inner.IP.String(), Port: inner.Port, User: "bastion", KeyFile: keyFile},
+		},
+	})
+	if err := cl.Connect(); err != nil {
+		t.Fatalf("Unexpected error connecting through jump hosts: %s", err.Error())
+	}
+	if len(cl.jumpClients) != 2 {
+		t.Errorf("Expected 2 jump clients, got %d", len(cl.jumpClients))
+	}
+	if err := cl.Disconnect(); err != nil {
+		t.Errorf("Unexpected error disconnecting: %s", err.Error())
+	}
+
+	// Hosts behind an unreachable jump host are unreachable
+	cl, err = NewSecureShellClient("potctl", target.IP.String(), keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl.SetPort(target.Port)
+	cl.SetOptions(SSHOptions{
+		ProxyJump: []SSHJumpHost{{Host: "127.0.0.1", Port: 1, User: "bastion", KeyFile: keyFile}},
+	})
+	if err := cl.Connect(); err == nil {
+		cl.Disconnect()
+		t.Error("Expected error connecting through unreachable jump host")
+	}
+}